@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -30,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/internal/telemetry"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
@@ -79,6 +81,24 @@ func (c *timeoutFallbackClient) CallContext(ctxIn context.Context, result interf
 	return c.impl.CallContext(ctx, result, method, args...)
 }
 
+// tracingFallbackClient traces the calls to the fallback node and forwards the
+// trace context, so that they show up in the trace of the request served here.
+type tracingFallbackClient struct {
+	impl types.FallbackClient
+}
+
+func (c *tracingFallbackClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) (err error) {
+	ctx, _, spanEnd := telemetry.StartClientSpan(ctx, "fallback "+method,
+		telemetry.StringAttribute("rpc.system", "jsonrpc"),
+		telemetry.StringAttribute("rpc.method", method),
+	)
+	defer spanEnd(&err)
+
+	header := make(http.Header)
+	telemetry.Inject(ctx, header)
+	return c.impl.CallContext(rpc.NewContextWithHeaders(ctx, header), result, method, args...)
+}
+
 func CreateFallbackClient(fallbackClientUrl string, fallbackClientTimeout time.Duration, isArchiveNode bool) (types.FallbackClient, error) {
 	if fallbackClientUrl == "" {
 		return nil, nil
@@ -116,6 +136,7 @@ func CreateFallbackClient(fallbackClientUrl string, fallbackClientTimeout time.D
 			timeout: fallbackClientTimeout,
 		}
 	}
+	fallbackClient = &tracingFallbackClient{impl: fallbackClient}
 	return fallbackClient, nil
 }
 
@@ -143,6 +164,7 @@ func createRegisterAPIBackend(backend *Backend, filterConfig filters.Config, fal
 	}
 	filterSystem := filters.NewFilterSystem(backend.apiBackend, filterConfig)
 	backend.stack.RegisterAPIs(backend.apiBackend.GetAPIs(filterSystem))
+	backend.stack.SetResponseCachePolicy(ethapi.NewCachePolicy(backend.apiBackend))
 	return filterSystem, nil
 }
 
@@ -172,16 +194,26 @@ func (a *APIBackend) GetAPIs(filterSystem *filters.FilterSystem) []rpc.API {
 	})
 
 	apis = append(apis, rpc.API{
-		Namespace: "net",
+		Namespace: "eth",
 		Version:   "1.0",
-		Service:   NewPublicNetAPI(a.ChainConfig().ChainID.Uint64()),
+		Service:   NewCallManyAPI(a),
+		Public:    true,
+	}, rpc.API{
+		Namespace: "eth",
+		Version:   "1.0",
+		Service:   NewCallManySubscriptionAPI(a),
+		Public:    true,
+	}, rpc.API{
+		Namespace: "eth",
+		Version:   "1.0",
+		Service:   NewFinalityAPI(a),
 		Public:    true,
 	})
 
 	apis = append(apis, rpc.API{
-		Namespace: "txpool",
+		Namespace: "net",
 		Version:   "1.0",
-		Service:   NewPublicTxPoolAPI(),
+		Service:   NewPublicNetAPI(a.ChainConfig().ChainID.Uint64()),
 		Public:    true,
 	})
 
@@ -539,6 +571,22 @@ func StateAndHeaderFromHeader(ctx context.Context, chainDb ethdb.Database, bc *c
 	if header == nil {
 		return nil, nil, errors.New("header not found")
 	}
+	ctx, span, spanEnd := telemetry.StartSpan(ctx, "arbitrum.StateAndHeaderFromHeader",
+		telemetry.Int64Attribute("block.number", header.Number.Int64()),
+	)
+	statedb, header, err := stateAndHeaderFromHeader(ctx, chainDb, bc, maxRecreateStateDepth, header, archiveClientsManager)
+	var archiveErr *types.ErrUseArchiveFallback
+	if errors.Is(err, types.ErrUseFallback) || errors.As(err, &archiveErr) {
+		// Not a failure, the request is forwarded to a fallback node.
+		span.SetAttributes(telemetry.BoolAttribute("fallback", true))
+		spanEnd(nil)
+	} else {
+		spanEnd(&err)
+	}
+	return statedb, header, err
+}
+
+func stateAndHeaderFromHeader(ctx context.Context, chainDb ethdb.Database, bc *core.BlockChain, maxRecreateStateDepth int64, header *types.Header, archiveClientsManager *archiveFallbackClientsManager) (*state.StateDB, *types.Header, error) {
 	if !bc.Config().IsArbitrumNitro(header.Number) {
 		return nil, header, types.ErrUseFallback
 	}
@@ -696,14 +744,14 @@ func (a *APIBackend) TxIndexDone() bool {
 	return a.BlockChain().TxIndexDone()
 }
 
+// Arbitrum doesn't have a pool, the pool methods are served from the
+// transactions published by this node which weren't included yet.
 func (a *APIBackend) GetPoolTransactions() (types.Transactions, error) {
-	// Arbitrum doesn't have a pool
-	return types.Transactions{}, nil
+	return a.b.txTracker.all(), nil
 }
 
 func (a *APIBackend) GetPoolTransaction(txHash common.Hash) *types.Transaction {
-	// Arbitrum doesn't have a pool
-	return nil
+	return a.b.txTracker.get(txHash)
 }
 
 func (a *APIBackend) GetPoolNonce(ctx context.Context, addr common.Address) (uint64, error) {
@@ -715,15 +763,15 @@ func (a *APIBackend) GetPoolNonce(ctx context.Context, addr common.Address) (uin
 }
 
 func (a *APIBackend) Stats() (pending int, queued int) {
-	panic("not implemented") // TODO: Implement
+	return a.b.txTracker.stats(a.b.stateNonceAt())
 }
 
 func (a *APIBackend) TxPoolContent() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
-	panic("not implemented") // TODO: Implement
+	return a.b.txTracker.content(a.b.stateNonceAt())
 }
 
 func (a *APIBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
-	panic("not implemented") // TODO: Implement
+	return a.b.txTracker.contentFrom(addr, a.b.stateNonceAt()(addr))
 }
 
 func (a *APIBackend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.Subscription {
@@ -45,6 +45,13 @@ func (a *archiveFallbackClientsManager) lastAvailableBlock() uint64 {
 }
 
 func (a *archiveFallbackClientsManager) fallbackClient(blockNum uint64) types.FallbackClient {
+	client, _ := a.fallbackClientAndLastBlock(blockNum)
+	return client
+}
+
+// fallbackClientAndLastBlock returns a client serving blockNum together with the
+// last block served by that client.
+func (a *archiveFallbackClientsManager) fallbackClientAndLastBlock(blockNum uint64) (types.FallbackClient, uint64) {
 	var possibleClients []types.FallbackClient
 	var chosenLastBlock uint64
 	for _, lastBlockAndClient := range a.lastBlockAndClients {
@@ -60,7 +67,7 @@ func (a *archiveFallbackClientsManager) fallbackClient(blockNum uint64) types.Fa
 		}
 	}
 	if len(possibleClients) != 0 {
-		return possibleClients[rand.Intn(len(possibleClients))]
+		return possibleClients[rand.Intn(len(possibleClients))], chosenLastBlock
 	}
-	return nil
+	return nil, 0
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/replica"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/internal/shutdowncheck"
 	"github.com/ethereum/go-ethereum/log"
@@ -31,8 +32,14 @@ type Backend struct {
 	scope  event.SubscriptionScope
 
 	filterMaps *filtermaps.FilterMaps
+	logIndexDb ethdb.Database // Local log index database in replica mode, nil otherwise
+
+	replica    bool                      // Set if the chain database follows a primary node
+	replicaCh  chan replica.CatchUpEvent // Notifies about the replica catching up with the primary
+	replicaSub event.Subscription        // Subscription for the replica catch-up events
 
 	shutdownTracker *shutdowncheck.ShutdownTracker
+	txTracker       *txTracker
 
 	chanTxs      chan *types.Transaction
 	chanClose    chan struct{} //close coroutine
@@ -49,10 +56,12 @@ func NewBackend(stack *node.Node, config *Config, chainDb ethdb.Database, publis
 		chainDb: chainDb,
 
 		shutdownTracker: shutdowncheck.NewShutdownTracker(chainDb),
+		txTracker:       newTxTracker(types.LatestSignerForChainID(publisher.BlockChain().Config().ChainID)),
 
 		chanTxs:      make(chan *types.Transaction, 100),
 		chanClose:    make(chan struct{}),
 		chanNewBlock: make(chan struct{}, 1),
+		replica:      stack.Config().ReplicaOf != "",
 	}
 
 	scheme, err := rawdb.ParseStateScheme(config.StateScheme, chainDb)
@@ -64,15 +73,30 @@ func NewBackend(stack *node.Node, config *Config, chainDb ethdb.Database, publis
 		History:        config.LogHistory,
 		Disabled:       config.LogNoHistory,
 		ExportFileName: config.LogExportCheckpoints,
+		CheckpointFile: config.LogCheckpoints,
 		HashScheme:     scheme == rawdb.HashScheme,
 	}
+	// A replica can not write into the database of its primary, so the log
+	// index is rendered locally into a separate database.
+	logIndexDb := ethdb.KeyValueStore(chainDb)
+	if backend.replica {
+		log.Info("Serving chain data of primary node in replica mode")
+		backend.replicaCh = make(chan replica.CatchUpEvent, 1)
+		backend.replicaSub = stack.SubscribeReplicaCatchUp(backend.replicaCh)
+		if backend.logIndexDb, err = stack.OpenDatabase("logindex", 0, 0, "arbitrum/db/logindex/", false); err != nil {
+			backend.replicaSub.Unsubscribe()
+			return nil, nil, err
+		}
+		logIndexDb = backend.logIndexDb
+		fmConfig.HashScheme = false // no trie nodes in the dedicated database
+	}
 	chainView := backend.newChainView(backend.arb.BlockChain().CurrentBlock())
 	historyCutoff, _ := backend.arb.BlockChain().HistoryPruningCutoff()
 	var finalBlock uint64
 	if fb := backend.arb.BlockChain().CurrentFinalBlock(); fb != nil {
 		finalBlock = fb.Number.Uint64()
 	}
-	backend.filterMaps = filtermaps.NewFilterMaps(chainDb, chainView, historyCutoff, finalBlock, filtermaps.DefaultParams, fmConfig)
+	backend.filterMaps = filtermaps.NewFilterMaps(logIndexDb, chainView, historyCutoff, finalBlock, filtermaps.DefaultParams, fmConfig)
 	if len(config.AllowMethod) > 0 {
 		rpcFilter := make(map[string]bool)
 		for _, method := range config.AllowMethod {
@@ -109,7 +133,11 @@ func (b *Backend) ResetWithGenesisBlock(gb *types.Block) {
 }
 
 func (b *Backend) EnqueueL2Message(ctx context.Context, tx *types.Transaction, options *arbitrum_types.ConditionalOptions) error {
-	return b.arb.PublishTransaction(ctx, tx, options)
+	if err := b.arb.PublishTransaction(ctx, tx, options); err != nil {
+		return err
+	}
+	b.txTracker.add(tx)
+	return nil
 }
 
 func (b *Backend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.Subscription {
@@ -122,9 +150,32 @@ func (b *Backend) Start() error {
 	b.shutdownTracker.MarkStartup()
 	b.shutdownTracker.Start()
 	go b.updateFilterMapsHeads()
+	go b.trackTxsLoop()
+	if b.replica {
+		go b.followPrimary()
+	}
 	return nil
 }
 
+// followPrimary adopts the chain head of the primary node whenever the replica
+// database caught up with it.
+func (b *Backend) followPrimary() {
+	defer b.replicaSub.Unsubscribe()
+
+	for {
+		select {
+		case <-b.replicaCh:
+			if err := b.arb.BlockChain().ReloadHead(); err != nil {
+				log.Warn("Failed to reload chain head of primary", "err", err)
+			}
+		case _, more := <-b.chanClose:
+			if !more {
+				return
+			}
+		}
+	}
+}
+
 func (b *Backend) updateFilterMapsHeads() {
 	headEventCh := make(chan core.ChainEvent, 10)
 	blockProcCh := make(chan bool, 10)
@@ -189,6 +240,9 @@ func (b *Backend) updateFilterMapsHeads() {
 func (b *Backend) Stop() error {
 	b.scope.Close()
 	b.filterMaps.Stop()
+	if b.logIndexDb != nil {
+		b.logIndexDb.Close()
+	}
 	b.shutdownTracker.Stop()
 	b.chainDb.Close()
 	close(b.chanClose)
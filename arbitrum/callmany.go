@@ -0,0 +1,286 @@
+package arbitrum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// errcodeMethodNotFound is the JSON-RPC error code of calls to unknown methods.
+const errcodeMethodNotFound = -32601
+
+// CallManyRange is the inclusive block range an eth_callMany request executes over.
+type CallManyRange struct {
+	FromBlock rpc.BlockNumber `json:"fromBlock"`
+	ToBlock   rpc.BlockNumber `json:"toBlock"`
+}
+
+// CallManyResult is the outcome of the call at a single block. Reverts and other
+// execution failures are reported per block rather than failing the whole range.
+type CallManyResult struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	Output      hexutil.Bytes  `json:"output"`
+	GasUsed     hexutil.Uint64 `json:"gasUsed"`
+	Error       string         `json:"error,omitempty"`
+}
+
+// CallManyAPI executes a call at every step-th block of a range.
+type CallManyAPI struct {
+	b *APIBackend
+}
+
+func NewCallManyAPI(b *APIBackend) *CallManyAPI {
+	return &CallManyAPI{b}
+}
+
+// CallMany executes the call at blocks fromBlock, fromBlock+step, ... up to toBlock
+// and returns the results in block order.
+func (api *CallManyAPI) CallMany(ctx context.Context, args TransactionArgs, blockRange CallManyRange, step *hexutil.Uint64) ([]*CallManyResult, error) {
+	var results []*CallManyResult
+	err := api.b.callMany(ctx, args, blockRange, step, func(result *CallManyResult) error {
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// CallManySubscriptionAPI streams eth_callMany results as they are produced.
+type CallManySubscriptionAPI struct {
+	b *APIBackend
+}
+
+func NewCallManySubscriptionAPI(b *APIBackend) *CallManySubscriptionAPI {
+	return &CallManySubscriptionAPI{b}
+}
+
+// CallMany sends a notification with the result of the call for each block of
+// the range, in block order. If the range can't be completed, the last
+// notification carries the error and no block number.
+func (api *CallManySubscriptionAPI) CallMany(ctx context.Context, args TransactionArgs, blockRange CallManyRange, step *hexutil.Uint64) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		// The request context ends once the subscription is created.
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-rpcSub.Err():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		err := api.b.callMany(ctx, args, blockRange, step, func(result *CallManyResult) error {
+			return notifier.Notify(rpcSub.ID, result)
+		})
+		if err != nil && ctx.Err() == nil {
+			notifier.Notify(rpcSub.ID, &CallManyResult{Error: err.Error()})
+		}
+	}()
+	return rpcSub, nil
+}
+
+// callManySegment is a run of step-aligned blocks served by the same backend.
+type callManySegment struct {
+	from, to uint64
+	client   types.FallbackClient // nil if the segment is executed locally
+}
+
+// splitCallManyRange splits the blocks from, from+step, ... <= to into segments,
+// redirecting pre-Nitro blocks to the classic fallback and archived blocks to the
+// archive redirect serving them, split at each redirect's last block.
+func splitCallManyRange(from, to, step, genesis uint64, fallback types.FallbackClient, archive *archiveFallbackClientsManager) []callManySegment {
+	var segments []callManySegment
+	for current := from; current <= to; {
+		last, client := to, types.FallbackClient(nil)
+		switch {
+		case current < genesis:
+			last, client = min(to, genesis-1), fallback
+		case archive != nil && current <= archive.lastAvailableBlock():
+			var lastBlock uint64
+			if client, lastBlock = archive.fallbackClientAndLastBlock(current); client != nil {
+				last = min(to, lastBlock)
+			}
+		}
+		last = current + (last-current)/step*step
+		segments = append(segments, callManySegment{from: current, to: last, client: client})
+
+		if to-last < step {
+			break
+		}
+		current = last + step
+	}
+	return segments
+}
+
+func (a *APIBackend) callMany(ctx context.Context, args TransactionArgs, blockRange CallManyRange, step *hexutil.Uint64, emit func(*CallManyResult) error) error {
+	from, err := a.blockNumberToUint(ctx, blockRange.FromBlock)
+	if err != nil {
+		return err
+	}
+	to, err := a.blockNumberToUint(ctx, blockRange.ToBlock)
+	if err != nil {
+		return err
+	}
+	stride := uint64(1)
+	if step != nil {
+		stride = uint64(*step)
+	}
+	if stride == 0 {
+		return errors.New("step must be positive")
+	}
+	if from > to {
+		return fmt.Errorf("invalid block range: from %d is after to %d", from, to)
+	}
+	if head := a.BlockChain().CurrentBlock().Number.Uint64(); to > head {
+		return fmt.Errorf("block range ends beyond the current head %d", head)
+	}
+	if maxCount := a.b.config.CallManyMaxBlockCount; maxCount != 0 && (to-from)/stride >= maxCount {
+		return fmt.Errorf("block range covers more than %d blocks", maxCount)
+	}
+	genesis := a.ChainConfig().ArbitrumChainParams.GenesisBlockNum
+	for _, segment := range splitCallManyRange(from, to, stride, genesis, a.fallbackClient, a.archiveClientsManager) {
+		switch {
+		case segment.client != nil && segment.from < genesis:
+			err = forwardCalls(ctx, segment, args, stride, emit)
+		case segment.client != nil:
+			err = forwardCallMany(ctx, segment, args, stride, emit)
+		default:
+			err = a.localCallMany(ctx, segment, args, stride, emit)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forwardCallMany redirects a segment of archived blocks to the archive node
+// serving it. Archive nodes not implementing eth_callMany are sent a separate
+// eth_call for every block instead.
+func forwardCallMany(ctx context.Context, segment callManySegment, args TransactionArgs, step uint64, emit func(*CallManyResult) error) error {
+	var results []*CallManyResult
+	blockRange := CallManyRange{FromBlock: rpc.BlockNumber(segment.from), ToBlock: rpc.BlockNumber(segment.to)}
+	if err := segment.client.CallContext(ctx, &results, "eth_callMany", args, blockRange, hexutil.Uint64(step)); err != nil {
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == errcodeMethodNotFound {
+			log.Debug("Archive node doesn't support eth_callMany, forwarding single calls", "from", segment.from, "to", segment.to)
+			return forwardCalls(ctx, segment, args, step, emit)
+		}
+		return fmt.Errorf("failed to forward blocks %d-%d: %w", segment.from, segment.to, err)
+	}
+	for _, result := range results {
+		if err := emit(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forwardCalls redirects a segment of blocks to a node not implementing
+// eth_callMany, like the classic node serving pre-Nitro blocks, forwarding every
+// block as a separate eth_call. Such nodes don't report the gas used and the
+// block hash of a call, those fields are left empty.
+func forwardCalls(ctx context.Context, segment callManySegment, args TransactionArgs, step uint64, emit func(*CallManyResult) error) error {
+	for number := segment.from; ; number += step {
+		var (
+			output      hexutil.Bytes
+			callResult  = &CallManyResult{BlockNumber: hexutil.Uint64(number)}
+			blockNumber = rpc.BlockNumber(number)
+		)
+		if err := segment.client.CallContext(ctx, &output, "eth_call", args, blockNumber); err != nil {
+			// Errors returned by the node are execution failures of the block,
+			// anything else means the node couldn't be reached.
+			var rpcErr rpc.Error
+			if !errors.As(err, &rpcErr) {
+				return fmt.Errorf("failed to forward block %d: %w", number, err)
+			}
+			callResult.Error = err.Error()
+			var dataErr rpc.DataError
+			if errors.As(err, &dataErr) {
+				if data, ok := dataErr.ErrorData().(string); ok {
+					callResult.Output = common.FromHex(data)
+				}
+			}
+		} else {
+			callResult.Output = output
+		}
+		if err := emit(callResult); err != nil {
+			return err
+		}
+		if segment.to-number < step {
+			break
+		}
+	}
+	return nil
+}
+
+// localCallMany executes the call over a segment of locally available blocks.
+// States missing from the database are recreated by advancing the state of the
+// previous block of the segment rather than searching for one from scratch.
+func (a *APIBackend) localCallMany(ctx context.Context, segment callManySegment, args TransactionArgs, step uint64, emit func(*CallManyResult) error) error {
+	var (
+		bc         = a.BlockChain()
+		prevState  *state.StateDB
+		prevHeader *types.Header
+	)
+	for number := segment.from; ; number += step {
+		header := bc.GetHeaderByNumber(number)
+		if header == nil {
+			return fmt.Errorf("block %d not found", number)
+		}
+		var (
+			statedb *state.StateDB
+			err     error
+		)
+		if prevState == nil || bc.HasState(header.Root) || a.b.config.MaxRecreateStateDepth == 0 {
+			statedb, _, err = StateAndHeaderFromHeader(ctx, a.ChainDb(), bc, a.b.config.MaxRecreateStateDepth, header, nil, nil)
+		} else {
+			log.Debug("Advancing state for eth_callMany", "from", prevHeader.Number, "to", number)
+			statedb, err = AdvanceStateUpToBlock(ctx, bc, prevState, header, prevHeader, nil)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get state for block %d: %w", number, err)
+		}
+		prevState, prevHeader = statedb, header
+
+		result, err := ethapi.DoCallAtState(ctx, a, args, statedb.Copy(), header, nil, nil, a.RPCEVMTimeout(), a.RPCGasCap(), core.NewMessageEthcallContext())
+		if err != nil {
+			return fmt.Errorf("block %d: %w", number, err)
+		}
+		callResult := &CallManyResult{
+			BlockNumber: hexutil.Uint64(number),
+			BlockHash:   header.Hash(),
+			Output:      result.Return(),
+			GasUsed:     hexutil.Uint64(result.UsedGas),
+		}
+		if result.Err != nil {
+			callResult.Output = result.Revert()
+			callResult.Error = result.Err.Error()
+		}
+		if err := emit(callResult); err != nil {
+			return err
+		}
+		if segment.to-number < step {
+			break
+		}
+	}
+	return nil
+}
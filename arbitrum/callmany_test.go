@@ -0,0 +1,158 @@
+package arbitrum
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type namedFallbackClient string
+
+func (c namedFallbackClient) CallContext(context.Context, interface{}, string, ...interface{}) error {
+	return nil
+}
+
+func TestSplitCallManyRange(t *testing.T) {
+	var (
+		classic = namedFallbackClient("classic")
+		first   = namedFallbackClient("first")
+		second  = namedFallbackClient("second")
+		archive = &archiveFallbackClientsManager{lastBlockAndClients: []*lastBlockAndClient{
+			{lastBlock: 199, client: first},
+			{lastBlock: 299, client: second},
+		}}
+	)
+	tests := []struct {
+		from, to, step uint64
+		archive        *archiveFallbackClientsManager
+		want           []callManySegment
+	}{
+		// Local blocks only
+		{from: 100, to: 110, step: 1, want: []callManySegment{{100, 110, nil}}},
+		{from: 100, to: 110, step: 3, want: []callManySegment{{100, 109, nil}}},
+		// Classic blocks are redirected up to the genesis
+		{from: 40, to: 60, step: 5, want: []callManySegment{{40, 45, classic}, {50, 60, nil}}},
+		// Archived blocks are split by the last block of each redirect
+		{from: 150, to: 350, step: 10, archive: archive, want: []callManySegment{
+			{150, 190, first}, {200, 290, second}, {300, 350, nil},
+		}},
+		{from: 40, to: 250, step: 100, archive: archive, want: []callManySegment{
+			{40, 40, classic}, {140, 140, first}, {240, 240, second},
+		}},
+		// A step larger than the range yields a single block
+		{from: 120, to: 130, step: 1000, archive: archive, want: []callManySegment{{120, 120, first}}},
+	}
+	for i, tt := range tests {
+		have := splitCallManyRange(tt.from, tt.to, tt.step, 50, classic, tt.archive)
+		if !reflect.DeepEqual(have, tt.want) {
+			t.Errorf("test %d: segments mismatch: have %v, want %v", i, have, tt.want)
+		}
+	}
+}
+
+// revertError is an execution error returned by the classic node.
+type revertError struct{ data string }
+
+func (e *revertError) Error() string          { return "execution reverted" }
+func (e *revertError) ErrorCode() int         { return 3 }
+func (e *revertError) ErrorData() interface{} { return e.data }
+
+// methodNotFoundError is returned by nodes for unknown methods.
+type methodNotFoundError struct{ method string }
+
+func (e *methodNotFoundError) Error() string {
+	return "the method " + e.method + " does not exist/is not available"
+}
+func (e *methodNotFoundError) ErrorCode() int { return -32601 }
+
+// classicClient serves eth_call like the classic node, reverting at block 45.
+// Like the classic node, it doesn't implement eth_callMany.
+type classicClient struct {
+	methods []string
+	down    bool
+}
+
+func (c *classicClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	if c.down {
+		return errors.New("connection refused")
+	}
+	c.methods = append(c.methods, method)
+	if method != "eth_call" {
+		return &methodNotFoundError{method}
+	}
+	number := args[1].(rpc.BlockNumber)
+	if number == 45 {
+		return &revertError{data: "0x08c379a0"}
+	}
+	*result.(*hexutil.Bytes) = hexutil.Bytes{byte(number)}
+	return nil
+}
+
+func TestForwardCalls(t *testing.T) {
+	var (
+		client  = new(classicClient)
+		segment = callManySegment{from: 40, to: 48, client: client}
+		results []*CallManyResult
+	)
+	err := forwardCalls(context.Background(), segment, TransactionArgs{}, 5, func(result *CallManyResult) error {
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to forward classic calls: %v", err)
+	}
+	if !reflect.DeepEqual(client.methods, []string{"eth_call", "eth_call"}) {
+		t.Errorf("forwarded methods mismatch: have %v", client.methods)
+	}
+	want := []*CallManyResult{
+		{BlockNumber: 40, Output: hexutil.Bytes{40}},
+		{BlockNumber: 45, Output: hexutil.Bytes{0x08, 0xc3, 0x79, 0xa0}, Error: "execution reverted"},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("results mismatch: have %v, want %v", results, want)
+	}
+
+	// Failing to reach the classic node fails the range
+	client.down = true
+	err = forwardCalls(context.Background(), segment, TransactionArgs{}, 5, func(*CallManyResult) error { return nil })
+	if err == nil {
+		t.Fatal("expected an error for an unreachable classic node")
+	}
+}
+
+func TestForwardCallManyFallback(t *testing.T) {
+	var (
+		client  = new(classicClient)
+		segment = callManySegment{from: 40, to: 50, client: client}
+		results []*CallManyResult
+	)
+	err := forwardCallMany(context.Background(), segment, TransactionArgs{}, 5, func(result *CallManyResult) error {
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to forward calls: %v", err)
+	}
+	if !reflect.DeepEqual(client.methods, []string{"eth_callMany", "eth_call", "eth_call", "eth_call"}) {
+		t.Errorf("forwarded methods mismatch: have %v", client.methods)
+	}
+	want := []*CallManyResult{
+		{BlockNumber: 40, Output: hexutil.Bytes{40}},
+		{BlockNumber: 45, Output: hexutil.Bytes{0x08, 0xc3, 0x79, 0xa0}, Error: "execution reverted"},
+		{BlockNumber: 50, Output: hexutil.Bytes{50}},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("results mismatch: have %v, want %v", results, want)
+	}
+
+	// Other errors of the archive node fail the range
+	client.down = true
+	err = forwardCallMany(context.Background(), segment, TransactionArgs{}, 5, func(*CallManyResult) error { return nil })
+	if err == nil {
+		t.Fatal("expected an error for an unreachable archive node")
+	}
+}
@@ -26,6 +26,7 @@ type Config struct {
 	LogHistory           uint64 `koanf:"log-history"`            // The maximum number of blocks from head where a log search index is maintained.
 	LogNoHistory         bool   `koanf:"log-no-history"`         // No log search index is maintained.
 	LogExportCheckpoints string `koanf:"log-export-checkpoints"` // export log index checkpoints to file
+	LogCheckpoints       string `koanf:"log-checkpoints"`        // import trusted log index checkpoints from file
 
 	// State scheme represents the scheme used to store states and trie
 	// nodes on top. It can be 'hash', 'path', or none which means use the scheme
@@ -39,6 +40,9 @@ type Config struct {
 	// FeeHistoryMaxBlockCount limits the number of historical blocks a fee history request may cover
 	FeeHistoryMaxBlockCount uint64 `koanf:"feehistory-max-block-count"`
 
+	// CallManyMaxBlockCount limits the number of blocks an eth_callMany request may execute at
+	CallManyMaxBlockCount uint64 `koanf:"callmany-max-block-count"`
+
 	ArbDebug ArbDebugConfig `koanf:"arbdebug"`
 
 	ClassicRedirect        string        `koanf:"classic-redirect"`
@@ -82,8 +86,10 @@ func ConfigAddOptions(prefix string, f *flag.FlagSet) {
 	f.Uint64(prefix+".log-history", DefaultConfig.LogHistory, "maximum number of blocks from head where a log search index is maintained")
 	f.Bool(prefix+".log-no-history", DefaultConfig.LogNoHistory, "no log search index is maintained")
 	f.String(prefix+".log-export-checkpoints", DefaultConfig.LogExportCheckpoints, "export log index checkpoints to file")
+	f.String(prefix+".log-checkpoints", DefaultConfig.LogCheckpoints, "import trusted log index checkpoints from file (same format as exported)")
 	f.String(prefix+".state-scheme", DefaultConfig.StateScheme, "state scheme used to store states and trie nodes on top")
 	f.Uint64(prefix+".feehistory-max-block-count", DefaultConfig.FeeHistoryMaxBlockCount, "max number of blocks a fee history request may cover")
+	f.Uint64(prefix+".callmany-max-block-count", DefaultConfig.CallManyMaxBlockCount, "max number of blocks an eth_callMany request may execute at (0 = no limit)")
 	f.String(prefix+".classic-redirect", DefaultConfig.ClassicRedirect, "url to redirect classic requests, use \"error:[CODE:]MESSAGE\" to return specified error instead of redirecting")
 	f.Duration(prefix+".classic-redirect-timeout", DefaultConfig.ClassicRedirectTimeout, "timeout for forwarded classic requests, where 0 = no timeout")
 	f.Int(prefix+".filter-log-cache-size", DefaultConfig.FilterLogCacheSize, "log filter system maximum number of cached blocks")
@@ -112,6 +118,7 @@ var DefaultConfig = Config{
 	FilterLogCacheSize:      32,
 	FilterTimeout:           5 * time.Minute,
 	FeeHistoryMaxBlockCount: 1024,
+	CallManyMaxBlockCount:   1024,
 	ClassicRedirect:         "",
 	MaxRecreateStateDepth:   UninitializedMaxRecreateStateDepth, // default value should be set for depending on node type (archive / non-archive)
 	AllowMethod:             []string{},
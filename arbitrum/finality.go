@@ -0,0 +1,121 @@
+package arbitrum
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// BatchInfo describes the sequencer batch a block was posted to the parent chain in.
+type BatchInfo struct {
+	Number        hexutil.Uint64  `json:"number"`
+	L1BlockNumber *hexutil.Uint64 `json:"l1BlockNumber,omitempty"` // Parent chain block the batch was posted in
+}
+
+// BatchInfoBackend may be implemented by the SyncProgressBackend to attach batch
+// metadata to safe and finalized head notifications. The sync monitor of the
+// Nitro execution node implements it.
+type BatchInfoBackend interface {
+	BatchInfoForBlock(ctx context.Context, blockNum uint64) (*BatchInfo, error)
+}
+
+// batchInfoTimeout bounds the batch lookup of a single finality notification.
+const batchInfoTimeout = 5 * time.Second
+
+// FinalityAPI streams the blocks becoming safe (posted to the parent chain) and
+// finalized (finalized on the parent chain), as reported by the execution client.
+type FinalityAPI struct {
+	b *APIBackend
+}
+
+func NewFinalityAPI(b *APIBackend) *FinalityAPI {
+	return &FinalityAPI{b}
+}
+
+// SafeHeads sends a notification each time the safe block advances.
+func (api *FinalityAPI) SafeHeads(ctx context.Context) (*rpc.Subscription, error) {
+	return subscribeFinalityHeads(ctx, api, api.b.BlockChain().SubscribeChainSafeEvent, func(ev core.ChainSafeEvent) *types.Header {
+		return ev.Header
+	})
+}
+
+// FinalizedHeads sends a notification each time the finalized block advances.
+func (api *FinalityAPI) FinalizedHeads(ctx context.Context) (*rpc.Subscription, error) {
+	return subscribeFinalityHeads(ctx, api, api.b.BlockChain().SubscribeChainFinalizedEvent, func(ev core.ChainFinalizedEvent) *types.Header {
+		return ev.Header
+	})
+}
+
+// subscribeFinalityHeads creates a subscription notifying the headers of the
+// events of the given feed.
+//
+// The notifications are prepared in a separate goroutine, so that a slow batch
+// lookup never blocks the feed and with it the chain updating its finality.
+// Heads advancing while a notification is prepared replace each other, only
+// the latest of them is notified.
+func subscribeFinalityHeads[T any](ctx context.Context, api *FinalityAPI, subscribe func(chan<- T) event.Subscription, header func(T) *types.Header) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		events := make(chan T, 16)
+		sub := subscribe(events)
+		defer sub.Unsubscribe()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		heads := make(chan *types.Header, 1)
+		go func() {
+			for {
+				select {
+				case head := <-heads:
+					notifier.Notify(rpcSub.ID, api.marshalFinalityHead(ctx, head))
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		for {
+			select {
+			case ev := <-events:
+				select {
+				case <-heads:
+				default:
+				}
+				heads <- header(ev)
+			case <-rpcSub.Err():
+				return
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// marshalFinalityHead converts the header to its RPC representation, including
+// the L1 information encoded in it and, if known, the batch it was posted in.
+func (api *FinalityAPI) marshalFinalityHead(ctx context.Context, header *types.Header) map[string]interface{} {
+	fields := ethapi.RPCMarshalNitroHeader(header)
+	if batches, ok := api.b.sync.(BatchInfoBackend); ok {
+		ctx, cancel := context.WithTimeout(ctx, batchInfoTimeout)
+		defer cancel()
+		batch, err := batches.BatchInfoForBlock(ctx, header.Number.Uint64())
+		if err != nil {
+			log.Debug("Failed to get batch for finality notification", "number", header.Number, "err", err)
+		} else if batch != nil {
+			fields["batch"] = batch
+		}
+	}
+	return fields
+}
@@ -0,0 +1,184 @@
+package arbitrum
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/arbitrum_types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testArbInterface serves the blockchain of the backend.
+type testArbInterface struct {
+	bc *core.BlockChain
+}
+
+func (a testArbInterface) PublishTransaction(context.Context, *types.Transaction, *arbitrum_types.ConditionalOptions) error {
+	return nil
+}
+func (a testArbInterface) BlockChain() *core.BlockChain { return a.bc }
+func (a testArbInterface) ArbNode() interface{}         { return nil }
+
+// batchSyncBackend reports every two blocks as posted in the same batch. Once
+// stalled, the batch lookups block until released.
+type batchSyncBackend struct {
+	stalled atomic.Bool
+	release chan struct{}
+}
+
+func (s *batchSyncBackend) SyncProgressMap(context.Context) map[string]interface{} { return nil }
+
+func (s *batchSyncBackend) BlockMetadataByNumber(context.Context, uint64) (common.BlockMetadata, error) {
+	return nil, nil
+}
+
+func (s *batchSyncBackend) BatchInfoForBlock(ctx context.Context, blockNum uint64) (*BatchInfo, error) {
+	if s.stalled.Load() {
+		select {
+		case <-s.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return &BatchInfo{Number: hexutil.Uint64(blockNum / 2)}, nil
+}
+
+// finalityHead is a safe or finalized head notification.
+type finalityHead struct {
+	Number hexutil.Uint64 `json:"number"`
+	Batch  *BatchInfo     `json:"batch"`
+}
+
+func newFinalityTestClient(t *testing.T, blocks int) (*core.BlockChain, *batchSyncBackend, *rpc.Client) {
+	t.Helper()
+
+	genesis := &core.Genesis{Config: params.TestChainConfig, Alloc: types.GenesisAlloc{}}
+	_, chain, _ := core.GenerateChainWithGenesis(genesis, ethash.NewFaker(), blocks, nil)
+	bc, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), core.DefaultCacheConfigWithScheme(rawdb.HashScheme), nil, genesis, nil, ethash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(bc.Stop)
+	if _, err := bc.InsertChain(chain); err != nil {
+		t.Fatal(err)
+	}
+	sync := &batchSyncBackend{release: make(chan struct{})}
+	backend := &APIBackend{b: &Backend{arb: testArbInterface{bc}}, sync: sync}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", NewFinalityAPI(backend)); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Stop)
+	client := rpc.DialInProc(server)
+	t.Cleanup(client.Close)
+	return bc, sync, client
+}
+
+// advanceUntilNotified advances the head until the subscription reports it,
+// the subscription goroutine may not have subscribed to the feed yet.
+func advanceUntilNotified(t *testing.T, bc *core.BlockChain, set func(*types.Header), heads chan finalityHead) finalityHead {
+	t.Helper()
+
+	for number := uint64(1); number <= bc.CurrentBlock().Number.Uint64(); number++ {
+		set(bc.GetHeaderByNumber(number))
+		select {
+		case head := <-heads:
+			return head
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+	t.Fatal("no finality notification received")
+	return finalityHead{}
+}
+
+func TestFinalityHeads(t *testing.T) {
+	t.Parallel()
+
+	bc, _, client := newFinalityTestClient(t, 16)
+	for _, tt := range []struct {
+		name string
+		set  func(*types.Header)
+	}{
+		{"safeHeads", bc.SetSafe},
+		{"finalizedHeads", bc.SetFinalized},
+	} {
+		heads := make(chan finalityHead, 16)
+		sub, err := client.EthSubscribe(context.Background(), heads, tt.name)
+		if err != nil {
+			t.Fatalf("%s: failed to subscribe: %v", tt.name, err)
+		}
+		head := advanceUntilNotified(t, bc, tt.set, heads)
+		if head.Batch == nil || head.Batch.Number != head.Number/2 {
+			t.Errorf("%s: wrong batch of block %d: %v", tt.name, head.Number, head.Batch)
+		}
+		// Later heads are notified in order
+		next := uint64(head.Number) + 1
+		tt.set(bc.GetHeaderByNumber(next))
+		select {
+		case head := <-heads:
+			if uint64(head.Number) != next {
+				t.Errorf("%s: wrong head notified: have %d, want %d", tt.name, head.Number, next)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: head %d not notified", tt.name, next)
+		}
+		sub.Unsubscribe()
+	}
+}
+
+func TestFinalityHeadsSlowBatchLookup(t *testing.T) {
+	t.Parallel()
+
+	bc, sync, client := newFinalityTestClient(t, 64)
+	heads := make(chan finalityHead, 64)
+	sub, err := client.EthSubscribe(context.Background(), heads, "safeHeads")
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+	first := advanceUntilNotified(t, bc, bc.SetSafe, heads)
+
+	// Advancing the safe head must not wait for the stalled batch lookups,
+	// even beyond the buffer of the subscription.
+	sync.stalled.Store(true)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for number := uint64(first.Number) + 1; number <= 64; number++ {
+			bc.SetSafe(bc.GetHeaderByNumber(number))
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		close(sync.release)
+		t.Fatal("safe head update blocked by the batch lookup")
+	}
+	// Once the lookups resume, the latest head is notified
+	close(sync.release)
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case head := <-heads:
+			if head.Number == 64 {
+				if head.Batch == nil || head.Batch.Number != 32 {
+					t.Errorf("wrong batch of the latest head: %v", head.Batch)
+				}
+				return
+			}
+		case <-timeout:
+			t.Fatal("latest safe head not notified")
+		}
+	}
+}
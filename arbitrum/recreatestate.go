@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/internal/telemetry"
 	"github.com/pkg/errors"
 )
 
@@ -29,6 +30,19 @@ type StateForHeaderFunction func(header *types.Header) (*state.StateDB, StateRel
 // else if maxDepthInL2Gas is -1, the traversal depth is not limited
 // otherwise only targetHeader state is checked and no search is performed
 func FindLastAvailableState(ctx context.Context, bc *core.BlockChain, stateFor StateForHeaderFunction, targetHeader *types.Header, logFunc StateBuildingLogFunction, maxDepthInL2Gas int64) (*state.StateDB, *types.Header, StateReleaseFunc, error) {
+	ctx, span, spanEnd := telemetry.StartSpan(ctx, "arbitrum.FindLastAvailableState",
+		telemetry.Int64Attribute("block.number", targetHeader.Number.Int64()),
+		telemetry.Int64Attribute("max_depth_l2_gas", maxDepthInL2Gas),
+	)
+	state, header, release, err := findLastAvailableState(ctx, bc, stateFor, targetHeader, logFunc, maxDepthInL2Gas)
+	if header != nil {
+		span.SetAttributes(telemetry.Int64Attribute("depth", int64(targetHeader.Number.Uint64()-header.Number.Uint64())))
+	}
+	spanEnd(&err)
+	return state, header, release, err
+}
+
+func findLastAvailableState(ctx context.Context, bc *core.BlockChain, stateFor StateForHeaderFunction, targetHeader *types.Header, logFunc StateBuildingLogFunction, maxDepthInL2Gas int64) (*state.StateDB, *types.Header, StateReleaseFunc, error) {
 	genesis := bc.Config().ArbitrumChainParams.GenesisBlockNum
 	currentHeader := targetHeader
 	var state *state.StateDB
@@ -86,3 +100,26 @@ func AdvanceStateByBlock(ctx context.Context, bc *core.BlockChain, state *state.
 	}
 	return state, block, nil
 }
+
+// AdvanceStateUpToBlock re-executes the blocks following lastAvailableHeader on top of
+// its state, up to and including targetHeader, verifying the recreated block hashes.
+func AdvanceStateUpToBlock(ctx context.Context, bc *core.BlockChain, state *state.StateDB, targetHeader *types.Header, lastAvailableHeader *types.Header, logFunc StateBuildingLogFunction) (*state.StateDB, error) {
+	returnedBlockNumber := targetHeader.Number.Uint64()
+	blockToRecreate := lastAvailableHeader.Number.Uint64() + 1
+	prevHash := lastAvailableHeader.Hash()
+	for ctx.Err() == nil {
+		state, block, err := AdvanceStateByBlock(ctx, bc, state, blockToRecreate, prevHash, logFunc)
+		if err != nil {
+			return nil, err
+		}
+		prevHash = block.Hash()
+		if blockToRecreate >= returnedBlockNumber {
+			if block.Hash() != targetHeader.Hash() {
+				return nil, fmt.Errorf("blockHash doesn't match when recreating number: %d expected: %v got: %v", blockToRecreate, targetHeader.Hash(), block.Hash())
+			}
+			return state, nil
+		}
+		blockToRecreate++
+	}
+	return nil, ctx.Err()
+}
@@ -0,0 +1,65 @@
+package arbitrum
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestAdvanceStateUpToBlock(t *testing.T) {
+	var (
+		key, _  = crypto.GenerateKey()
+		from    = crypto.PubkeyToAddress(key.PublicKey)
+		to      = common.Address{0x01}
+		signer  = types.LatestSigner(params.TestChainConfig)
+		genesis = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{from: {Balance: big.NewInt(params.Ether)}},
+		}
+	)
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, ethash.NewFaker(), 8, func(i int, b *core.BlockGen) {
+		tx := types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: uint64(i), To: &to, Value: big.NewInt(1000), Gas: params.TxGas, GasPrice: b.BaseFee()})
+		b.AddTx(tx)
+	})
+	bc, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), core.DefaultCacheConfigWithScheme(rawdb.HashScheme), nil, genesis, nil, ethash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bc.Stop()
+	if _, err := bc.InsertChain(blocks); err != nil {
+		t.Fatal(err)
+	}
+	base := bc.GetHeaderByNumber(2)
+	statedb, err := state.New(base.Root, bc.StateCache())
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := bc.GetHeaderByNumber(6)
+	statedb, err = AdvanceStateUpToBlock(context.Background(), bc, statedb, target, base, nil)
+	if err != nil {
+		t.Fatalf("failed to advance state: %v", err)
+	}
+	if root := statedb.IntermediateRoot(true); root != target.Root {
+		t.Fatalf("state root mismatch: have %x, want %x", root, target.Root)
+	}
+	if balance := statedb.GetBalance(to).Uint64(); balance != 6000 {
+		t.Fatalf("balance mismatch: have %d, want 6000", balance)
+	}
+	// Advancing to a block off the chain fails.
+	statedb, _ = state.New(base.Root, bc.StateCache())
+	fork := types.CopyHeader(target)
+	fork.Extra = []byte("fork")
+	if _, err := AdvanceStateUpToBlock(context.Background(), bc, statedb, fork, base, nil); err == nil {
+		t.Fatal("advanced state to a non-canonical block")
+	}
+}
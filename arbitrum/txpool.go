@@ -1,17 +1,248 @@
 package arbitrum
 
-// PublicNetAPI offers network related RPC methods
-type PublicTxPoolAPI struct{}
+import (
+	"sort"
+	"sync"
+	"time"
 
-// NewPublicNetAPI creates a new net API instance.
-func NewPublicTxPoolAPI() *PublicTxPoolAPI {
-	return &PublicTxPoolAPI{}
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// trackedTxLifetime is the maximum time a published transaction is tracked
+	// without being included, e.g. if it was dropped by the sequencer.
+	trackedTxLifetime = 10 * time.Minute
+
+	// maxTrackedTxs is the maximum number of published transactions tracked.
+	maxTrackedTxs = 4096
+)
+
+type trackedTx struct {
+	tx   *types.Transaction
+	time time.Time
+}
+
+// txTracker keeps the transactions published by this node, either forwarded to
+// the sequencer or queued by it, until they are observed in a block. Arbitrum
+// has no transaction pool, the tracked transactions serve the txpool namespace
+// instead.
+type txTracker struct {
+	signer types.Signer
+
+	mu     sync.RWMutex
+	txs    map[common.Address]map[uint64]*trackedTx // sender -> nonce -> tx
+	hashes map[common.Hash]common.Address
+}
+
+func newTxTracker(signer types.Signer) *txTracker {
+	return &txTracker{
+		signer: signer,
+		txs:    make(map[common.Address]map[uint64]*trackedTx),
+		hashes: make(map[common.Hash]common.Address),
+	}
+}
+
+// add tracks a transaction accepted for publishing. A tracked transaction of
+// the same sender and nonce is replaced.
+func (t *txTracker) add(tx *types.Transaction) {
+	from, err := types.Sender(t.signer, tx)
+	if err != nil {
+		log.Debug("Not tracking published transaction", "hash", tx.Hash(), "err", err)
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.hashes) >= maxTrackedTxs {
+		t.evictOldest()
+	}
+	byNonce := t.txs[from]
+	if byNonce == nil {
+		byNonce = make(map[uint64]*trackedTx)
+		t.txs[from] = byNonce
+	}
+	if old := byNonce[tx.Nonce()]; old != nil {
+		delete(t.hashes, old.tx.Hash())
+	}
+	byNonce[tx.Nonce()] = &trackedTx{tx: tx, time: time.Now()}
+	t.hashes[tx.Hash()] = from
+}
+
+// evictOldest drops the transaction tracked for the longest time. The caller
+// must hold the lock.
+func (t *txTracker) evictOldest() {
+	var (
+		oldest *trackedTx
+		from   common.Address
+	)
+	for addr, byNonce := range t.txs {
+		for _, tracked := range byNonce {
+			if oldest == nil || tracked.time.Before(oldest.time) {
+				oldest, from = tracked, addr
+			}
+		}
+	}
+	if oldest != nil {
+		t.remove(from, oldest.tx)
+	}
+}
+
+// remove drops a tracked transaction. The caller must hold the lock.
+func (t *txTracker) remove(from common.Address, tx *types.Transaction) {
+	delete(t.hashes, tx.Hash())
+	delete(t.txs[from], tx.Nonce())
+	if len(t.txs[from]) == 0 {
+		delete(t.txs, from)
+	}
+}
+
+// prune drops the transactions whose nonce was used by an included transaction,
+// as well as the ones which weren't included within their lifetime.
+func (t *txTracker) prune(nonceAt func(common.Address) uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	expired := time.Now().Add(-trackedTxLifetime)
+	for from, byNonce := range t.txs {
+		nonce := nonceAt(from)
+		for _, tracked := range byNonce {
+			if tracked.tx.Nonce() < nonce || tracked.time.Before(expired) {
+				t.remove(from, tracked.tx)
+			}
+		}
+	}
+}
+
+// get returns the tracked transaction with the given hash.
+func (t *txTracker) get(hash common.Hash) *types.Transaction {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	from, ok := t.hashes[hash]
+	if !ok {
+		return nil
+	}
+	for _, tracked := range t.txs[from] {
+		if tracked.tx.Hash() == hash {
+			return tracked.tx
+		}
+	}
+	return nil
+}
+
+// split divides the transactions tracked for the given sender into the pending
+// ones, which are executable in nonce order, and the queued ones. The caller
+// must hold the lock.
+func (t *txTracker) split(from common.Address, nonce uint64) ([]*types.Transaction, []*types.Transaction) {
+	byNonce := t.txs[from]
+	txs := make([]*types.Transaction, 0, len(byNonce))
+	for _, tracked := range byNonce {
+		if tracked.tx.Nonce() >= nonce {
+			txs = append(txs, tracked.tx)
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].Nonce() < txs[j].Nonce() })
+
+	var pending int
+	for pending < len(txs) && txs[pending].Nonce() == nonce+uint64(pending) {
+		pending++
+	}
+	return txs[:pending], txs[pending:]
+}
+
+// content returns the pending and queued transactions of all senders.
+func (t *txTracker) content(nonceAt func(common.Address) uint64) (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	pending := make(map[common.Address][]*types.Transaction)
+	queued := make(map[common.Address][]*types.Transaction)
+	for from := range t.txs {
+		p, q := t.split(from, nonceAt(from))
+		if len(p) > 0 {
+			pending[from] = p
+		}
+		if len(q) > 0 {
+			queued[from] = q
+		}
+	}
+	return pending, queued
+}
+
+// contentFrom returns the pending and queued transactions of the given sender.
+func (t *txTracker) contentFrom(from common.Address, nonce uint64) ([]*types.Transaction, []*types.Transaction) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.split(from, nonce)
+}
+
+// stats returns the number of pending and queued transactions.
+func (t *txTracker) stats(nonceAt func(common.Address) uint64) (int, int) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var pending, queued int
+	for from := range t.txs {
+		p, q := t.split(from, nonceAt(from))
+		pending += len(p)
+		queued += len(q)
+	}
+	return pending, queued
+}
+
+// all returns all tracked transactions.
+func (t *txTracker) all() types.Transactions {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	txs := make(types.Transactions, 0, len(t.hashes))
+	for _, byNonce := range t.txs {
+		for _, tracked := range byNonce {
+			txs = append(txs, tracked.tx)
+		}
+	}
+	return txs
+}
+
+// trackTxsLoop prunes the tracked transactions whenever a new block is processed.
+func (b *Backend) trackTxsLoop() {
+	ch := make(chan core.ChainEvent, 10)
+	sub := b.arb.BlockChain().SubscribeChainEvent(ch)
+	if sub == nil {
+		log.Error("arbitrum Backend: failed subscribing to Chain Event")
+		return
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-ch:
+			// Drain queued events, pruning once per batch is sufficient.
+			for len(ch) > 0 {
+				<-ch
+			}
+			b.txTracker.prune(b.stateNonceAt())
+		case <-sub.Err():
+			return
+		case _, more := <-b.chanClose:
+			if !more {
+				return
+			}
+		}
+	}
 }
 
-// Version returns the current ethereum protocol version.
-func (s *PublicTxPoolAPI) Content() map[string]map[string]map[string]*struct{} {
-	return map[string]map[string]map[string]*struct{}{
-		"pending": make(map[string]map[string]*struct{}),
-		"queued":  make(map[string]map[string]*struct{}),
+// stateNonceAt returns a nonce lookup at the current chain head. Senders of
+// unavailable state are reported with nonce 0.
+func (b *Backend) stateNonceAt() func(common.Address) uint64 {
+	statedb, err := b.arb.BlockChain().State()
+	if err != nil {
+		log.Warn("Failed to open head state for tracked transactions", "err", err)
+		return func(common.Address) uint64 { return 0 }
 	}
+	return statedb.GetNonce
 }
@@ -0,0 +1,46 @@
+package arbitrum
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestTxTrackerContent(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	tracker := newTxTracker(signer)
+
+	for _, nonce := range []uint64{3, 4, 6} {
+		tx := types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: nonce, Gas: 21000, GasPrice: big.NewInt(1)})
+		tracker.add(tx)
+	}
+	nonceAt := func(common.Address) uint64 { return 3 }
+
+	pending, queued := tracker.contentFrom(from, 3)
+	if len(pending) != 2 || pending[0].Nonce() != 3 || pending[1].Nonce() != 4 {
+		t.Fatalf("pending mismatch: %v", pending)
+	}
+	if len(queued) != 1 || queued[0].Nonce() != 6 {
+		t.Fatalf("queued mismatch: %v", queued)
+	}
+	if p, q := tracker.stats(nonceAt); p != 2 || q != 1 {
+		t.Fatalf("stats mismatch: have %d/%d, want 2/1", p, q)
+	}
+	if tracker.get(pending[0].Hash()) == nil {
+		t.Fatal("tracked transaction not found by hash")
+	}
+	// Including the transactions up to nonce 4 evicts them.
+	included := pending[0]
+	tracker.prune(func(common.Address) uint64 { return 5 })
+	if tracker.get(included.Hash()) != nil {
+		t.Fatal("included transaction still tracked")
+	}
+	if p, q := tracker.stats(func(common.Address) uint64 { return 5 }); p != 0 || q != 1 {
+		t.Fatalf("stats mismatch after pruning: have %d/%d, want 0/1", p, q)
+	}
+}
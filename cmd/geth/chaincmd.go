@@ -110,6 +110,7 @@ if one is set.  Otherwise it prints the genesis from the datadir.`,
 			utils.LogHistoryFlag,
 			utils.LogNoHistoryFlag,
 			utils.LogExportCheckpointsFlag,
+			utils.LogCheckpointsFlag,
 			utils.StateHistoryFlag,
 		}, utils.DatabaseFlags, debug.Flags),
 		Before: func(ctx *cli.Context) error {
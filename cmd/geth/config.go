@@ -159,6 +159,39 @@ func loadBaseConfig(ctx *cli.Context) gethConfig {
 	return cfg
 }
 
+// persistRPCConfig writes the fields of the node configuration changed at runtime,
+// e.g. the RPC module lists, to the config file. Other settings are kept as
+// loaded from the file, so values set by command line flags don't leak into it.
+// The file is re-encoded like dumpconfig does, which drops its comments.
+func persistRPCConfig(file string, nodeCfg *node.Config, changed []string) error {
+	cfg := gethConfig{
+		Eth:     ethconfig.Defaults,
+		Node:    defaultNodeConfig(),
+		Metrics: metrics.DefaultConfig,
+	}
+	if err := loadConfig(file, &cfg); err != nil {
+		return err
+	}
+	have, want := reflect.ValueOf(&cfg.Node).Elem(), reflect.ValueOf(nodeCfg).Elem()
+	for _, name := range changed {
+		field := have.FieldByName(name)
+		if !field.IsValid() {
+			return fmt.Errorf("unknown node config field %q", name)
+		}
+		field.Set(want.FieldByName(name))
+	}
+	out, err := tomlSettings.Marshal(&cfg)
+	if err != nil {
+		return err
+	}
+	// Replace the file atomically, so a crash can't leave it truncated.
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, out, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
+
 // makeConfigNode loads geth configuration and creates a blank node instance.
 func makeConfigNode(ctx *cli.Context) (*node.Node, gethConfig) {
 	cfg := loadBaseConfig(ctx)
@@ -166,6 +199,11 @@ func makeConfigNode(ctx *cli.Context) (*node.Node, gethConfig) {
 	if err != nil {
 		utils.Fatalf("Failed to create the protocol stack: %v", err)
 	}
+	if file := ctx.String(configFileFlag.Name); file != "" {
+		stack.SetConfigWriter(func(nodeCfg *node.Config, changed []string) error {
+			return persistRPCConfig(file, nodeCfg, changed)
+		})
+	}
 	// Node doesn't by default populate account manager backends
 	if err := setAccountManagerBackends(stack.Config(), stack.AccountManager(), stack.KeyStoreDir()); err != nil {
 		utils.Fatalf("Failed to set account manager backends: %v", err)
@@ -218,6 +256,10 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 	if ctx.IsSet(utils.GraphQLEnabledFlag.Name) {
 		utils.RegisterGraphQLService(stack, backend, filterSystem, &cfg.Node)
 	}
+	// Configure the gRPC gateway if requested.
+	if ctx.IsSet(utils.GRPCEnabledFlag.Name) {
+		utils.RegisterGRPCService(stack, backend, filterSystem, &cfg.Node)
+	}
 	// Add the Ethereum Stats daemon if requested.
 	if cfg.Ethstats.URL != "" {
 		utils.RegisterEthStatsService(stack, backend, cfg.Ethstats.URL)
@@ -0,0 +1,78 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestPersistRPCConfig(t *testing.T) {
+	const config = `[Node]
+DataDir = "/data"
+HTTPModules = ["eth", "net"]
+
+[Node.P2P]
+MaxPeers = 10
+`
+	file := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(file, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// The HTTP modules are overridden by a flag, only the allow-list is changed
+	// through the admin API.
+	cfg := loadTestConfig(t, file)
+	cfg.Node.HTTPModules = []string{"web3"}
+	cfg.Node.HTTPPort = 9545
+	cfg.Node.RPCAllowMethods = []string{"eth_chainId"}
+	if err := persistRPCConfig(file, &cfg.Node, []string{"RPCAllowMethods"}); err != nil {
+		t.Fatal(err)
+	}
+	reloaded := loadTestConfig(t, file)
+	if !slices.Equal(reloaded.Node.RPCAllowMethods, cfg.Node.RPCAllowMethods) {
+		t.Fatalf("wrong persisted allow-list: %v", reloaded.Node.RPCAllowMethods)
+	}
+	if !slices.Equal(reloaded.Node.HTTPModules, []string{"eth", "net"}) || reloaded.Node.HTTPPort == 9545 {
+		t.Fatalf("flag values persisted: %v %d", reloaded.Node.HTTPModules, reloaded.Node.HTTPPort)
+	}
+	if reloaded.Node.DataDir != "/data" || reloaded.Node.P2P.MaxPeers != 10 {
+		t.Fatal("other settings changed")
+	}
+
+	// Clearing a list persists it as empty.
+	cfg.Node.HTTPModules = nil
+	if err := persistRPCConfig(file, &cfg.Node, []string{"HTTPModules"}); err != nil {
+		t.Fatal(err)
+	}
+	if reloaded := loadTestConfig(t, file); reloaded.Node.HTTPModules == nil || len(reloaded.Node.HTTPModules) != 0 {
+		t.Fatalf("wrong persisted HTTP modules: %#v", reloaded.Node.HTTPModules)
+	}
+	if err := persistRPCConfig(file, &cfg.Node, []string{"NoSuchField"}); err == nil {
+		t.Fatal("no error for unknown field")
+	}
+}
+
+func loadTestConfig(t *testing.T, file string) gethConfig {
+	t.Helper()
+	cfg := gethConfig{Node: defaultNodeConfig()}
+	if err := loadConfig(file, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
@@ -18,6 +18,7 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -32,11 +33,13 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/console/prompt"
+	"github.com/ethereum/go-ethereum/core/filtermaps"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/objstore"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
@@ -78,11 +81,13 @@ Remove blockchain and state databases`,
 			dbPutCmd,
 			dbGetSlotsCmd,
 			dbDumpFreezerIndex,
+			dbRecompressFreezerCmd,
 			dbImportCmd,
 			dbExportCmd,
 			dbMetadataCmd,
 			dbCheckStateContentCmd,
 			dbInspectHistoryCmd,
+			dbVerifyLogIndexCmd,
 		},
 	}
 	dbInspectCmd = &cli.Command{
@@ -163,6 +168,16 @@ WARNING: This is a low-level operation which may cause database corruption!`,
 		Flags:       slices.Concat(utils.NetworkFlags, utils.DatabaseFlags),
 		Description: "This command displays information about the freezer index.",
 	}
+	dbRecompressFreezerCmd = &cli.Command{
+		Action:    freezerRecompress,
+		Name:      "freezer-recompress",
+		Usage:     "Recompress a freezer table with zstd",
+		ArgsUsage: "<freezer-type> <table-type>",
+		Flags:     slices.Concat(utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command converts a snappy compressed freezer table to zstd compression,
+using a dictionary trained from the stored items. The node must not be running
+while the table is converted. Use 'geth db inspect' to see the space saved.`,
+	}
 	dbImportCmd = &cli.Command{
 		Action:      importLDBdata,
 		Name:        "import",
@@ -207,6 +222,22 @@ WARNING: This is a low-level operation which may cause database corruption!`,
 		}, utils.NetworkFlags, utils.DatabaseFlags),
 		Description: "This command queries the history of the account or storage slot within the specified block range",
 	}
+	dbVerifyLogIndexCmd = &cli.Command{
+		Action:    verifyLogIndex,
+		Name:      "verify-log-index",
+		Usage:     "Verify the consistency of the log index and optionally repair damaged epochs",
+		ArgsUsage: "<first epoch (optional)> <last epoch (optional)>",
+		Flags: slices.Concat([]cli.Flag{
+			&cli.BoolFlag{
+				Name:  "repair",
+				Usage: "rewrite the damaged epochs with freshly rendered data",
+			},
+		}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command re-renders the filter maps of the selected log index epochs
+from the stored receipts and compares the filter rows and block pointers with the
+ones stored in the database. If no epochs are specified, all indexed epochs are
+verified. With --repair, only the damaged epochs are rewritten.`,
+	}
 )
 
 func removeDB(ctx *cli.Context) error {
@@ -605,6 +636,28 @@ func freezerInspect(ctx *cli.Context) error {
 	return rawdb.InspectFreezerTable(ancient, freezer, table, start, end)
 }
 
+func freezerRecompress(ctx *cli.Context) error {
+	if ctx.NArg() < 2 {
+		return fmt.Errorf("required arguments: %v", ctx.Command.ArgsUsage)
+	}
+	var (
+		freezer = ctx.Args().Get(0)
+		table   = ctx.Args().Get(1)
+	)
+	stack, cfg := makeConfigNode(ctx)
+	ancient := stack.ResolveAncient("chaindata", ctx.String(utils.AncientFlag.Name))
+	stack.Close()
+
+	var remote objstore.Store
+	if cfg.Node.AncientRemote != "" {
+		var err error
+		if remote, err = objstore.Open(cfg.Node.AncientRemote); err != nil {
+			return err
+		}
+	}
+	return rawdb.RecompressFreezerTable(ancient, freezer, table, remote)
+}
+
 func importLDBdata(ctx *cli.Context) error {
 	start := 0
 	switch ctx.NArg() {
@@ -907,3 +960,80 @@ func inspectHistory(ctx *cli.Context) error {
 	}
 	return inspectStorage(triedb, start, end, address, slot, ctx.Bool("raw"))
 }
+
+func verifyLogIndex(ctx *cli.Context) error {
+	if ctx.NArg() > 2 {
+		return fmt.Errorf("max 2 arguments: %v", ctx.Command.ArgsUsage)
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chain, db := utils.MakeChain(ctx, stack, false)
+	defer db.Close()
+	defer chain.Stop()
+
+	// Open the index without checking it against the chain, which might revert
+	// or reset exactly the damaged maps that should be inspected.
+	head := chain.CurrentBlock()
+	view := filtermaps.NewChainView(chain, head.Number.Uint64(), head.Hash())
+	fm := filtermaps.OpenFilterMaps(db, view, filtermaps.DefaultParams, filtermaps.Config{
+		HashScheme: chain.TrieDB().Scheme() == rawdb.HashScheme,
+	})
+	epochs := fm.IndexedEpochs()
+	if epochs.IsEmpty() {
+		return errors.New("log index is not initialized or empty")
+	}
+	first, last := epochs.First(), epochs.Last()
+	if ctx.NArg() >= 1 {
+		n, err := strconv.ParseUint(ctx.Args().Get(0), 10, 32)
+		if err != nil {
+			return fmt.Errorf("failed to parse first epoch: %v", err)
+		}
+		first, last = uint32(n), uint32(n)
+	}
+	if ctx.NArg() >= 2 {
+		n, err := strconv.ParseUint(ctx.Args().Get(1), 10, 32)
+		if err != nil {
+			return fmt.Errorf("failed to parse last epoch: %v", err)
+		}
+		last = uint32(n)
+	}
+	if !epochs.Includes(first) || !epochs.Includes(last) || first > last {
+		return fmt.Errorf("invalid epoch range %d-%d, indexed epochs: %d-%d", first, last, epochs.First(), epochs.Last())
+	}
+	var (
+		repair  = ctx.Bool("repair")
+		damaged int
+		start   = time.Now()
+		data    [][]string
+	)
+	for epoch := first; epoch <= last; epoch++ {
+		report, err := fm.VerifyEpoch(epoch, repair)
+		if err != nil {
+			return fmt.Errorf("failed to verify epoch %d: %v", epoch, err)
+		}
+		if report.Damaged() {
+			damaged++
+			data = append(data, []string{
+				strconv.FormatUint(uint64(epoch), 10),
+				fmt.Sprintf("%d-%d", report.Blocks.First(), report.Blocks.Last()),
+				strconv.Itoa(len(report.BadRows)),
+				strconv.Itoa(len(report.BadLastBlocks)),
+				strconv.Itoa(len(report.BadLvPointers)),
+				strconv.FormatBool(report.Repaired),
+			})
+			log.Warn("Damaged log index epoch", "epoch", epoch, "rows", len(report.BadRows),
+				"lastblocks", len(report.BadLastBlocks), "lvpointers", len(report.BadLvPointers))
+		} else {
+			log.Info("Verified log index epoch", "epoch", epoch, "maps", report.Maps.Count(), "blocks", report.Blocks.Count())
+		}
+	}
+	log.Info("Log index verification finished", "epochs", last-first+1, "damaged", damaged, "elapsed", common.PrettyDuration(time.Since(start)))
+	if len(data) > 0 {
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Epoch", "Blocks", "Bad rows", "Bad last blocks", "Bad lv pointers", "Repaired"})
+		table.AppendBulk(data)
+		table.Render()
+	}
+	return nil
+}
@@ -90,6 +90,7 @@ var (
 		utils.LogHistoryFlag,
 		utils.LogNoHistoryFlag,
 		utils.LogExportCheckpointsFlag,
+		utils.LogCheckpointsFlag,
 		utils.StateHistoryFlag,
 		utils.LightServeFlag,    // deprecated
 		utils.LightIngressFlag,  // deprecated
@@ -168,12 +169,17 @@ var (
 		utils.AuthPortFlag,
 		utils.AuthVirtualHostsFlag,
 		utils.JWTSecretFlag,
+		utils.AuthLongLivedJWTFlag,
 		utils.HTTPVirtualHostsFlag,
 		utils.GraphQLEnabledFlag,
 		utils.GraphQLCORSDomainFlag,
 		utils.GraphQLVirtualHostsFlag,
+		utils.GRPCEnabledFlag,
+		utils.GRPCJWTSecretFlag,
+		utils.GRPCLongLivedJWTFlag,
 		utils.HTTPApiFlag,
 		utils.HTTPPathPrefixFlag,
+		utils.HTTPUnencryptedHTTP2Flag,
 		utils.WSEnabledFlag,
 		utils.WSListenAddrFlag,
 		utils.WSPortFlag,
@@ -189,6 +195,13 @@ var (
 		utils.AllowUnprotectedTxs,
 		utils.BatchRequestLimit,
 		utils.BatchResponseMaxSize,
+		utils.BatchRequestConcurrency,
+		utils.RPCQuotaRateFlag,
+		utils.RPCQuotaBurstFlag,
+		utils.RPCAccessLogSampleRateFlag,
+		utils.RPCResponseCacheSizeFlag,
+		utils.RPCTelemetryEndpointFlag,
+		utils.RPCTelemetrySampleRatioFlag,
 	}
 
 	metricsFlags = []cli.Flag{
@@ -56,6 +56,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/ethdb/remotedb"
+	"github.com/ethereum/go-ethereum/ethgrpc"
 	"github.com/ethereum/go-ethereum/ethstats"
 	"github.com/ethereum/go-ethereum/graphql"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
@@ -111,6 +112,22 @@ var (
 		Usage:    "Root directory for ancient data (default = inside chaindata)",
 		Category: flags.EthCategory,
 	}
+	AncientRemoteFlag = &cli.StringFlag{
+		Name:     "datadir.ancient.remote",
+		Usage:    "Object store for sealed ancient chain segments (s3://bucket/prefix?endpoint=URL or file:///path)",
+		Category: flags.EthCategory,
+	}
+	ReplicaOfFlag = &flags.DirectoryFlag{
+		Name:     "replica.of",
+		Usage:    "Data directory of a primary node whose databases are followed read-only, serving RPC without networking",
+		Category: flags.EthCategory,
+	}
+	ReplicaIntervalFlag = &cli.DurationFlag{
+		Name:     "replica.interval",
+		Usage:    "Interval in which a replica catches up with the databases of its primary",
+		Value:    node.DefaultConfig.ReplicaInterval,
+		Category: flags.EthCategory,
+	}
 	MinFreeDiskSpaceFlag = &flags.DirectoryFlag{
 		Name:     "datadir.minfreedisk",
 		Usage:    "Minimum free disk space in MB, once reached triggers auto shut down (default = --cache.gc converted to MB, 0 = disabled)",
@@ -300,6 +317,12 @@ var (
 		Category: flags.StateCategory,
 		Value:    "",
 	}
+	LogCheckpointsFlag = &cli.StringFlag{
+		Name:     "history.logs.checkpoints",
+		Usage:    "Import trusted log index checkpoints from file in the exported JSON format",
+		Category: flags.StateCategory,
+		Value:    "",
+	}
 	// Beacon client light sync settings
 	BeaconApiFlag = &cli.StringSliceFlag{
 		Name:     "beacon.api",
@@ -600,7 +623,12 @@ var (
 	}
 	JWTSecretFlag = &flags.DirectoryFlag{
 		Name:     "authrpc.jwtsecret",
-		Usage:    "Path to a JWT secret to use for authenticated RPC endpoints",
+		Usage:    "Path to the JWT secrets to use for authenticated RPC endpoints, one per line",
+		Category: flags.APICategory,
+	}
+	AuthLongLivedJWTFlag = &cli.BoolFlag{
+		Name:     "authrpc.longlivedjwt",
+		Usage:    "Accept JWTs with an expiry on the authenticated RPC endpoints until they expire, instead of requiring freshly issued tokens",
 		Category: flags.APICategory,
 	}
 
@@ -676,6 +704,11 @@ var (
 		Value:    "",
 		Category: flags.APICategory,
 	}
+	HTTPUnencryptedHTTP2Flag = &cli.BoolFlag{
+		Name:     "http.h2c",
+		Usage:    "Enable HTTP/2 without TLS on the HTTP-RPC server",
+		Category: flags.APICategory,
+	}
 	GraphQLEnabledFlag = &cli.BoolFlag{
 		Name:     "graphql",
 		Usage:    "Enable GraphQL on the HTTP-RPC server. Note that GraphQL can only be started if an HTTP server is started as well.",
@@ -693,6 +726,21 @@ var (
 		Value:    strings.Join(node.DefaultConfig.GraphQLVirtualHosts, ","),
 		Category: flags.APICategory,
 	}
+	GRPCEnabledFlag = &cli.BoolFlag{
+		Name:     "grpc",
+		Usage:    "Enable the gRPC gateway of the eth API on the HTTP-RPC server. Note that gRPC requires --http and --http.h2c.",
+		Category: flags.APICategory,
+	}
+	GRPCJWTSecretFlag = &flags.DirectoryFlag{
+		Name:     "grpc.jwtsecret",
+		Usage:    "Path to the JWT secrets to use for authenticating gRPC calls, one per line",
+		Category: flags.APICategory,
+	}
+	GRPCLongLivedJWTFlag = &cli.BoolFlag{
+		Name:     "grpc.longlivedjwt",
+		Usage:    "Accept JWTs with an expiry on the gRPC gateway until they expire, instead of requiring freshly issued tokens",
+		Category: flags.APICategory,
+	}
 	WSEnabledFlag = &cli.BoolFlag{
 		Name:     "ws",
 		Usage:    "Enable the WS-RPC server",
@@ -755,6 +803,42 @@ var (
 		Value:    node.DefaultConfig.BatchResponseMaxSize,
 		Category: flags.APICategory,
 	}
+	BatchRequestConcurrency = &cli.IntFlag{
+		Name:     "rpc.batch-request-concurrency",
+		Usage:    "Number of requests in a batch processed in parallel (0 or 1 = sequential)",
+		Category: flags.APICategory,
+	}
+	RPCQuotaRateFlag = &cli.Float64Flag{
+		Name:     "rpc.quota.rate",
+		Usage:    "Cost units credited to each HTTP/WS client per second (0 = no quota)",
+		Category: flags.APICategory,
+	}
+	RPCQuotaBurstFlag = &cli.Float64Flag{
+		Name:     "rpc.quota.burst",
+		Usage:    "Maximum cost units a HTTP/WS client can accumulate",
+		Category: flags.APICategory,
+	}
+	RPCAccessLogSampleRateFlag = &cli.Float64Flag{
+		Name:     "rpc.accesslog.sample-rate",
+		Usage:    "Fraction of HTTP/WS calls written to the RPC access log (0 = disabled, 1 = all calls)",
+		Category: flags.APICategory,
+	}
+	RPCResponseCacheSizeFlag = &cli.IntFlag{
+		Name:     "rpc.cache.size",
+		Usage:    "Megabytes of memory allocated to caching HTTP/WS results of finalized blocks (0 = disabled)",
+		Category: flags.APICategory,
+	}
+	RPCTelemetryEndpointFlag = &cli.StringFlag{
+		Name:     "rpc.telemetry.endpoint",
+		Usage:    "OpenTelemetry trace exporter endpoint: OTLP/HTTP collector URL or file:// path (empty = tracing disabled)",
+		Category: flags.APICategory,
+	}
+	RPCTelemetrySampleRatioFlag = &cli.Float64Flag{
+		Name:     "rpc.telemetry.sample-ratio",
+		Usage:    "Fraction of locally started traces that are recorded",
+		Value:    node.DefaultConfig.Tracing.SampleRatio,
+		Category: flags.APICategory,
+	}
 
 	// Network Settings
 	MaxPeersFlag = &cli.IntFlag{
@@ -977,6 +1061,9 @@ var (
 	DatabaseFlags = []cli.Flag{
 		DataDirFlag,
 		AncientFlag,
+		AncientRemoteFlag,
+		ReplicaOfFlag,
+		ReplicaIntervalFlag,
 		RemoteDBFlag,
 		DBEngineFlag,
 		StateSchemeFlag,
@@ -1181,6 +1268,9 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(HTTPPathPrefixFlag.Name) {
 		cfg.HTTPPathPrefix = ctx.String(HTTPPathPrefixFlag.Name)
 	}
+	if ctx.IsSet(HTTPUnencryptedHTTP2Flag.Name) {
+		cfg.HTTPUnencryptedHTTP2 = ctx.Bool(HTTPUnencryptedHTTP2Flag.Name)
+	}
 	if ctx.IsSet(AllowUnprotectedTxs.Name) {
 		cfg.AllowUnprotectedTxs = ctx.Bool(AllowUnprotectedTxs.Name)
 	}
@@ -1192,6 +1282,34 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(BatchResponseMaxSize.Name) {
 		cfg.BatchResponseMaxSize = ctx.Int(BatchResponseMaxSize.Name)
 	}
+
+	if ctx.IsSet(BatchRequestConcurrency.Name) {
+		cfg.BatchRequestConcurrency = ctx.Int(BatchRequestConcurrency.Name)
+	}
+
+	if ctx.IsSet(RPCQuotaRateFlag.Name) {
+		cfg.RPCQuota.Rate = ctx.Float64(RPCQuotaRateFlag.Name)
+	}
+
+	if ctx.IsSet(RPCQuotaBurstFlag.Name) {
+		cfg.RPCQuota.Burst = ctx.Float64(RPCQuotaBurstFlag.Name)
+	}
+
+	if ctx.IsSet(RPCAccessLogSampleRateFlag.Name) {
+		cfg.RPCAccessLog.SampleRate = ctx.Float64(RPCAccessLogSampleRateFlag.Name)
+	}
+
+	if ctx.IsSet(RPCResponseCacheSizeFlag.Name) {
+		cfg.RPCResponseCacheSize = ctx.Int(RPCResponseCacheSizeFlag.Name)
+	}
+
+	if ctx.IsSet(RPCTelemetryEndpointFlag.Name) {
+		cfg.Tracing.Endpoint = ctx.String(RPCTelemetryEndpointFlag.Name)
+	}
+
+	if ctx.IsSet(RPCTelemetrySampleRatioFlag.Name) {
+		cfg.Tracing.SampleRatio = ctx.Float64(RPCTelemetrySampleRatioFlag.Name)
+	}
 }
 
 // setGraphQL creates the GraphQL listener interface string from the set
@@ -1203,6 +1321,12 @@ func setGraphQL(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(GraphQLVirtualHostsFlag.Name) {
 		cfg.GraphQLVirtualHosts = SplitAndTrim(ctx.String(GraphQLVirtualHostsFlag.Name))
 	}
+	if ctx.IsSet(GRPCJWTSecretFlag.Name) {
+		cfg.GRPCJWTSecret = ctx.String(GRPCJWTSecretFlag.Name)
+	}
+	if ctx.IsSet(GRPCLongLivedJWTFlag.Name) {
+		cfg.GRPCLongLivedJWT = ctx.Bool(GRPCLongLivedJWTFlag.Name)
+	}
 }
 
 // setWS creates the WebSocket RPC listener interface string from the set
@@ -1371,6 +1495,9 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(JWTSecretFlag.Name) {
 		cfg.JWTSecret = ctx.String(JWTSecretFlag.Name)
 	}
+	if ctx.IsSet(AuthLongLivedJWTFlag.Name) {
+		cfg.AuthLongLivedJWT = ctx.Bool(AuthLongLivedJWTFlag.Name)
+	}
 	if ctx.IsSet(EnablePersonal.Name) {
 		log.Warn(fmt.Sprintf("Option --%s is deprecated. The 'personal' RPC namespace has been removed.", EnablePersonal.Name))
 	}
@@ -1405,6 +1532,18 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 		log.Info(fmt.Sprintf("Using %s as db engine", dbEngine))
 		cfg.DBEngine = dbEngine
 	}
+	if ctx.IsSet(AncientRemoteFlag.Name) {
+		cfg.AncientRemote = ctx.String(AncientRemoteFlag.Name)
+	}
+	if ctx.IsSet(ReplicaOfFlag.Name) {
+		cfg.ReplicaOf = ctx.String(ReplicaOfFlag.Name)
+		cfg.ReplicaInterval = ctx.Duration(ReplicaIntervalFlag.Name)
+
+		// A replica never syncs on its own, the chain is advanced by the primary.
+		cfg.P2P.MaxPeers = 0
+		cfg.P2P.NoDiscovery = true
+		cfg.P2P.DiscoveryV5 = false
+	}
 	// deprecation notice for log debug flags (TODO: find a more appropriate place to put these?)
 	if ctx.IsSet(LogBacktraceAtFlag.Name) {
 		log.Warn("log.backtrace flag is deprecated")
@@ -1684,6 +1823,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(LogExportCheckpointsFlag.Name) {
 		cfg.LogExportCheckpoints = ctx.String(LogExportCheckpointsFlag.Name)
 	}
+	if ctx.IsSet(LogCheckpointsFlag.Name) {
+		cfg.LogCheckpoints = ctx.String(LogCheckpointsFlag.Name)
+	}
 	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheTrieFlag.Name) {
 		cfg.TrieCleanCache = ctx.Int(CacheFlag.Name) * ctx.Int(CacheTrieFlag.Name) / 100
 	}
@@ -2004,6 +2146,20 @@ func RegisterGraphQLService(stack *node.Node, backend ethapi.Backend, filterSyst
 	}
 }
 
+// RegisterGRPCService adds the gRPC gateway of the eth API to the node.
+func RegisterGRPCService(stack *node.Node, backend ethapi.Backend, filterSystem *filters.FilterSystem, cfg *node.Config) {
+	var secrets [][]byte
+	if cfg.GRPCJWTSecret != "" {
+		var err error
+		if secrets, err = node.ObtainJWTSecrets(cfg.GRPCJWTSecret); err != nil {
+			Fatalf("Failed to load the gRPC JWT secrets: %v", err)
+		}
+	}
+	if _, err := ethgrpc.New(stack, backend, filterSystem, secrets); err != nil {
+		Fatalf("Failed to register the gRPC service: %v", err)
+	}
+}
+
 // RegisterFilterAPI adds the eth log filtering RPC API to the node.
 func RegisterFilterAPI(stack *node.Node, backend ethapi.Backend, ethcfg *ethconfig.Config) *filters.FilterSystem {
 	filterSystem := filters.NewFilterSystem(backend, filters.Config{
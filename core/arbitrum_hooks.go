@@ -30,6 +30,25 @@ import (
 // Installs an Arbitrum TxProcessor, enabling ArbOS for this state transition (see vm/evm_arbitrum.go)
 var ReadyEVMForL2 func(evm *vm.EVM, msg *Message)
 
+// L2Hooks is implemented by consensus engines installing the transaction processing
+// hooks of their own chain. The EVMs of such a chain use it instead of ReadyEVMForL2,
+// allowing chains with different hooks to run in the same process.
+type L2Hooks interface {
+	ReadyEVMForL2(evm *vm.EVM, msg *Message)
+}
+
+// evmL2Hooks passes the messages of the EVM to the hooks of the consensus engine.
+type evmL2Hooks struct {
+	hooks L2Hooks
+}
+
+func (h evmL2Hooks) ReadyEVMForL2(evm *vm.EVM, msg vm.L2Message) {
+	h.hooks.ReadyEVMForL2(evm, msg.(*Message))
+}
+
+// L2Message implements vm.L2Message.
+func (m *Message) L2Message() {}
+
 // Allows ArbOS to swap out or return early from an RPC message to support the NodeInterface virtual contract
 var InterceptRPCMessage = func(
 	msg *Message,
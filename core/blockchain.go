@@ -280,6 +280,8 @@ type BlockChain struct {
 	rmLogsFeed       event.Feed
 	chainFeed        event.Feed
 	chainHeadFeed    event.Feed
+	chainSafeFeed    event.Feed
+	chainFinalFeed   event.Feed
 	logsFeed         event.Feed
 	blockProcFeed    event.Feed
 	blockProcCounter int32
@@ -777,10 +779,13 @@ func (bc *BlockChain) SetHeadWithTimestamp(timestamp uint64) error {
 
 // SetFinalized sets the finalized block.
 func (bc *BlockChain) SetFinalized(header *types.Header) {
-	bc.currentFinalBlock.Store(header)
+	prev := bc.currentFinalBlock.Swap(header)
 	if header != nil {
 		rawdb.WriteFinalizedBlockHash(bc.db, header.Hash())
 		headFinalizedBlockGauge.Update(int64(header.Number.Uint64()))
+		if prev == nil || prev.Hash() != header.Hash() {
+			bc.chainFinalFeed.Send(ChainFinalizedEvent{Header: header})
+		}
 	} else {
 		rawdb.WriteFinalizedBlockHash(bc.db, common.Hash{})
 		headFinalizedBlockGauge.Update(0)
@@ -789,14 +794,122 @@ func (bc *BlockChain) SetFinalized(header *types.Header) {
 
 // SetSafe sets the safe block.
 func (bc *BlockChain) SetSafe(header *types.Header) {
-	bc.currentSafeBlock.Store(header)
+	prev := bc.currentSafeBlock.Swap(header)
 	if header != nil {
 		headSafeBlockGauge.Update(int64(header.Number.Uint64()))
+		if prev == nil || prev.Hash() != header.Hash() {
+			bc.chainSafeFeed.Send(ChainSafeEvent{Header: header})
+		}
 	} else {
 		headSafeBlockGauge.Update(0)
 	}
 }
 
+// ReloadHead re-reads the chain head markers from the database, adopting the
+// chain progress made by another process writing into the same database. It is
+// used by nodes following the database of a primary node in replica mode. The
+// chain and log events of all blocks adopted since the last reload are posted.
+func (bc *BlockChain) ReloadHead() error {
+	if !bc.chainmu.TryLock() {
+		return errChainStopped
+	}
+	defer bc.chainmu.Unlock()
+
+	hash := rawdb.ReadHeadBlockHash(bc.db)
+	if hash == (common.Hash{}) {
+		return errors.New("head block marker missing")
+	}
+	head := bc.GetHeaderByHash(hash)
+	if head == nil {
+		return fmt.Errorf("head header missing: %x", hash)
+	}
+	oldHead := bc.CurrentBlock()
+	changed := head.Hash() != oldHead.Hash()
+	if changed {
+		// Cached transaction lookups might refer to reorged blocks.
+		bc.txLookupCache.Purge()
+
+		bc.currentBlock.Store(head)
+		headBlockGauge.Update(int64(head.Number.Uint64()))
+	}
+	header := head
+	if hash := rawdb.ReadHeadHeaderHash(bc.db); hash != (common.Hash{}) {
+		if h := bc.GetHeaderByHash(hash); h != nil {
+			header = h
+		}
+	}
+	bc.hc.SetCurrentHeader(header)
+
+	if hash := rawdb.ReadHeadFastBlockHash(bc.db); hash != (common.Hash{}) {
+		if h := bc.GetHeaderByHash(hash); h != nil {
+			bc.currentSnapBlock.Store(h)
+			headFastBlockGauge.Update(int64(h.Number.Uint64()))
+		}
+	}
+	// The safe block is not persisted, it remains unknown to the replica.
+	if hash := rawdb.ReadFinalizedBlockHash(bc.db); hash != (common.Hash{}) {
+		if h := bc.GetHeaderByHash(hash); h != nil {
+			headFinalizedBlockGauge.Update(int64(h.Number.Uint64()))
+			if prev := bc.currentFinalBlock.Swap(h); prev == nil || prev.Hash() != h.Hash() {
+				bc.chainFinalFeed.Send(ChainFinalizedEvent{Header: h})
+			}
+		}
+	}
+	if changed {
+		bc.sendReloadEvents(oldHead, head)
+	}
+	return nil
+}
+
+// sendReloadEvents posts the events of the chain progress adopted by ReloadHead,
+// as if the blocks had been inserted locally: the logs of the blocks dropped from
+// the canonical chain are sent as removed, followed by a chain event along with
+// the logs of every new canonical block, and finally the chain head event.
+func (bc *BlockChain) sendReloadEvents(oldHead, newHead *types.Header) {
+	var (
+		dropped []*types.Header
+		added   []*types.Header
+		old     = oldHead
+		cur     = newHead
+	)
+	for old != nil && cur != nil && old.Hash() != cur.Hash() {
+		if old.Number.Uint64() >= cur.Number.Uint64() {
+			dropped = append(dropped, old)
+			old = bc.GetHeader(old.ParentHash, old.Number.Uint64()-1)
+		} else {
+			added = append(added, cur)
+			cur = bc.GetHeader(cur.ParentHash, cur.Number.Uint64()-1)
+		}
+	}
+	if old == nil || cur == nil {
+		// The common ancestor is unavailable, only report the new head
+		log.Warn("Failed to find common ancestor of reloaded head", "old", oldHead.Number, "oldhash", oldHead.Hash(), "new", newHead.Number, "newhash", newHead.Hash())
+		dropped, added = nil, []*types.Header{newHead}
+	}
+	var removed []*types.Log
+	for i := len(dropped) - 1; i >= 0; i-- {
+		if block := bc.GetBlock(dropped[i].Hash(), dropped[i].Number.Uint64()); block != nil {
+			removed = append(removed, bc.collectLogs(block, true)...)
+		}
+		if len(removed) > 512 {
+			bc.rmLogsFeed.Send(RemovedLogsEvent{removed})
+			removed = nil
+		}
+	}
+	if len(removed) > 0 {
+		bc.rmLogsFeed.Send(RemovedLogsEvent{removed})
+	}
+	for i := len(added) - 1; i >= 0; i-- {
+		bc.chainFeed.Send(ChainEvent{Header: added[i]})
+		if block := bc.GetBlock(added[i].Hash(), added[i].Number.Uint64()); block != nil {
+			if logs := bc.collectLogs(block, false); len(logs) > 0 {
+				bc.logsFeed.Send(logs)
+			}
+		}
+	}
+	bc.chainHeadFeed.Send(ChainHeadEvent{Header: newHead})
+}
+
 // rewindHashHead implements the logic of rewindHead in the context of hash scheme.
 func (bc *BlockChain) rewindHashHead(head *types.Header, root common.Hash, rewindGasLimit uint64) (*types.Header, uint64, bool) {
 	var (
@@ -444,6 +444,16 @@ func (bc *BlockChain) SubscribeChainHeadEvent(ch chan<- ChainHeadEvent) event.Su
 	return bc.scope.Track(bc.chainHeadFeed.Subscribe(ch))
 }
 
+// SubscribeChainSafeEvent registers a subscription of ChainSafeEvent.
+func (bc *BlockChain) SubscribeChainSafeEvent(ch chan<- ChainSafeEvent) event.Subscription {
+	return bc.scope.Track(bc.chainSafeFeed.Subscribe(ch))
+}
+
+// SubscribeChainFinalizedEvent registers a subscription of ChainFinalizedEvent.
+func (bc *BlockChain) SubscribeChainFinalizedEvent(ch chan<- ChainFinalizedEvent) event.Subscription {
+	return bc.scope.Track(bc.chainFinalFeed.Subscribe(ch))
+}
+
 // SubscribeLogsEvent registers a subscription of []*types.Log.
 func (bc *BlockChain) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription {
 	return bc.scope.Track(bc.logsFeed.Subscribe(ch))
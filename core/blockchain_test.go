@@ -4390,3 +4390,171 @@ func testInsertChainWithCutoff(t *testing.T, cutoff uint64, ancientLimit uint64,
 		}
 	}
 }
+
+// Tests that a chain sharing its database with another chain adopts the head
+// written by the other one when reloading, and posts the events of all blocks
+// it skipped.
+func TestReloadHead(t *testing.T) {
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = &Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   types.GenesisAlloc{addr: {Balance: big.NewInt(10000000000000000)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(gspec.Config)
+		engine = ethash.NewFaker()
+	)
+	// Every block contains a transaction emitting a log.
+	genDb, blocks, _ := GenerateChainWithGenesis(gspec, engine, 8, func(i int, gen *BlockGen) {
+		tx, err := types.SignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    gen.TxNonce(addr),
+			GasPrice: gen.header.BaseFee,
+			Gas:      uint64(1000000),
+			Data:     logCode,
+		})
+		if err != nil {
+			t.Fatalf("failed to create tx: %v", err)
+		}
+		gen.AddTx(tx)
+	})
+	// The fork replaces the last four blocks by six empty ones.
+	fork, _ := GenerateChain(gspec.Config, blocks[3], engine, genDb, 6, func(i int, gen *BlockGen) {
+		gen.SetCoinbase(common.Address{1})
+	})
+
+	diskdb, _ := rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), "", "", false)
+	defer diskdb.Close()
+
+	primary, err := NewBlockChain(diskdb, DefaultCacheConfigWithScheme(rawdb.HashScheme), nil, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create primary chain: %v", err)
+	}
+	defer primary.Stop()
+
+	follower, err := NewBlockChain(diskdb, DefaultCacheConfigWithScheme(rawdb.HashScheme), nil, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create following chain: %v", err)
+	}
+	defer follower.Stop()
+
+	var (
+		heads    = make(chan ChainHeadEvent, 10)
+		events   = make(chan ChainEvent, 10)
+		final    = make(chan ChainFinalizedEvent, 10)
+		logsCh   = make(chan []*types.Log, 10)
+		rmLogsCh = make(chan RemovedLogsEvent, 10)
+	)
+	defer follower.SubscribeChainHeadEvent(heads).Unsubscribe()
+	defer follower.SubscribeChainEvent(events).Unsubscribe()
+	defer follower.SubscribeChainFinalizedEvent(final).Unsubscribe()
+	defer follower.SubscribeLogsEvent(logsCh).Unsubscribe()
+	defer follower.SubscribeRemovedLogsEvent(rmLogsCh).Unsubscribe()
+
+	// checkEvents checks that a chain event was sent for each of the given
+	// blocks in order, followed by a single head event.
+	checkEvents := func(blocks []*types.Block) {
+		t.Helper()
+		for _, block := range blocks {
+			select {
+			case ev := <-events:
+				if ev.Header.Hash() != block.Hash() {
+					t.Fatalf("chain event mismatch: have #%d %x, want #%d %x", ev.Header.Number, ev.Header.Hash(), block.Number(), block.Hash())
+				}
+			default:
+				t.Fatalf("no chain event sent for block #%d", block.Number())
+			}
+		}
+		if len(events) > 0 {
+			t.Fatalf("%d extra chain events sent", len(events))
+		}
+		head := blocks[len(blocks)-1]
+		if len(heads) != 1 {
+			t.Fatalf("head events mismatch: have %d, want 1", len(heads))
+		}
+		if ev := <-heads; ev.Header.Hash() != head.Hash() {
+			t.Fatalf("head event mismatch: have %x, want %x", ev.Header.Hash(), head.Hash())
+		}
+		if have := follower.CurrentBlock().Hash(); have != head.Hash() {
+			t.Fatalf("head mismatch: have %x, want %x", have, head.Hash())
+		}
+		if have := follower.CurrentHeader().Hash(); have != head.Hash() {
+			t.Fatalf("header mismatch: have %x, want %x", have, head.Hash())
+		}
+	}
+
+	if _, err := primary.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	primary.SetFinalized(blocks[2].Header())
+	if follower.CurrentBlock().Number.Uint64() != 0 {
+		t.Fatalf("follower advanced before reloading: %d", follower.CurrentBlock().Number)
+	}
+	if err := follower.ReloadHead(); err != nil {
+		t.Fatalf("failed to reload head: %v", err)
+	}
+	checkEvents(blocks)
+	checkLogEvents(t, logsCh, rmLogsCh, len(blocks), 0)
+
+	select {
+	case ev := <-final:
+		if ev.Header.Hash() != blocks[2].Hash() {
+			t.Fatalf("finalized event mismatch: have %x, want %x", ev.Header.Hash(), blocks[2].Hash())
+		}
+	default:
+		t.Fatal("no finalized event sent")
+	}
+	if follower.CurrentSafeBlock() != nil {
+		t.Fatal("safe block set from the finalized block")
+	}
+
+	// Reloading an unchanged head is silent.
+	if err := follower.ReloadHead(); err != nil {
+		t.Fatalf("failed to reload head: %v", err)
+	}
+	if len(heads) > 0 || len(events) > 0 || len(final) > 0 {
+		t.Fatal("events sent for unchanged head")
+	}
+
+	// Reloading after a reorg removes the logs of the dropped blocks.
+	if _, err := primary.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert fork: %v", err)
+	}
+	if err := follower.ReloadHead(); err != nil {
+		t.Fatalf("failed to reload head: %v", err)
+	}
+	checkEvents(fork)
+	checkLogEvents(t, logsCh, rmLogsCh, 0, len(blocks)-4)
+}
+
+func TestSafeFinalizedEvents(t *testing.T) {
+	_, _, chain, err := newCanonical(ethash.NewFaker(), 4, true, rawdb.HashScheme)
+	if err != nil {
+		t.Fatalf("failed to create pristine chain: %v", err)
+	}
+	defer chain.Stop()
+
+	var (
+		safe   = make(chan ChainSafeEvent, 4)
+		final  = make(chan ChainFinalizedEvent, 4)
+		block1 = chain.GetHeaderByNumber(1)
+		block2 = chain.GetHeaderByNumber(2)
+	)
+	defer chain.SubscribeChainSafeEvent(safe).Unsubscribe()
+	defer chain.SubscribeChainFinalizedEvent(final).Unsubscribe()
+
+	// Only advancing to a new block is announced.
+	chain.SetSafe(block2)
+	chain.SetSafe(block2)
+	chain.SetSafe(nil)
+	chain.SetFinalized(block1)
+	chain.SetFinalized(block1)
+
+	if len(safe) != 1 || (<-safe).Header.Hash() != block2.Hash() {
+		t.Error("unexpected safe events")
+	}
+	if len(final) != 1 || (<-final).Header.Hash() != block1.Hash() {
+		t.Error("unexpected finalized events")
+	}
+}
@@ -33,3 +33,13 @@ type ChainEvent struct {
 type ChainHeadEvent struct {
 	Header *types.Header
 }
+
+// ChainSafeEvent is posted when the safe block advances to a new block.
+type ChainSafeEvent struct {
+	Header *types.Header
+}
+
+// ChainFinalizedEvent is posted when the finalized block advances to a new block.
+type ChainFinalizedEvent struct {
+	Header *types.Header
+}
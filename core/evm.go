@@ -71,6 +71,12 @@ func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common
 		difficultyHash := common.BigToHash(header.Difficulty)
 		random = &difficultyHash
 	}
+	var l2Hooks vm.L2Hooks
+	if chain != nil {
+		if hooks, ok := chain.Engine().(L2Hooks); ok {
+			l2Hooks = evmL2Hooks{hooks}
+		}
+	}
 	return vm.BlockContext{
 		CanTransfer:  CanTransfer,
 		Transfer:     Transfer,
@@ -84,6 +90,7 @@ func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common
 		GasLimit:     header.GasLimit,
 		Random:       random,
 		ArbOSVersion: arbOsVersion,
+		L2Hooks:      l2Hooks,
 	}
 }
 
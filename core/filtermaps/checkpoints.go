@@ -19,6 +19,8 @@ package filtermaps
 import (
 	_ "embed"
 	"encoding/json"
+	"fmt"
+	"os"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -65,3 +67,39 @@ func decodeCheckpoints(encoded []byte) (result checkpointList) {
 	}
 	return
 }
+
+// readCheckpointFile loads a list of checkpoints from an external JSON file in
+// the same format as generated by the checkpoint exporter.
+func readCheckpointFile(fileName string) (checkpointList, error) {
+	encoded, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	var result checkpointList
+	if err := json.Unmarshal(encoded, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// validate checks the internal consistency of a checkpoint list. Block numbers
+// and log value pointers should be strictly increasing and no checkpoint block
+// should start after the end of the epoch it belongs to.
+func (cl checkpointList) validate(params *Params) error {
+	logValuesPerEpoch := uint64(1) << (params.logValuesPerMap + params.logMapsPerEpoch)
+	for epoch, cp := range cl {
+		if cp.BlockId == (common.Hash{}) {
+			return fmt.Errorf("missing block id in checkpoint of epoch %d", epoch)
+		}
+		if cp.FirstIndex > uint64(epoch+1)*logValuesPerEpoch {
+			return fmt.Errorf("log value pointer %d of checkpoint block %d is outside epoch %d", cp.FirstIndex, cp.BlockNumber, epoch)
+		}
+		if epoch > 0 {
+			prev := cl[epoch-1]
+			if cp.BlockNumber <= prev.BlockNumber || cp.FirstIndex <= prev.FirstIndex {
+				return fmt.Errorf("checkpoint of epoch %d is not strictly after the previous one", epoch)
+			}
+		}
+	}
+	return nil
+}
@@ -73,11 +73,13 @@ type FilterMaps struct {
 	disabled   bool
 	disabledCh chan struct{} // closed by indexer if disabled
 
-	closeCh        chan struct{}
-	closeWg        sync.WaitGroup
-	history        uint64
-	hashScheme     bool // use hashdb-safe delete range method
-	exportFileName string
+	closeCh            chan struct{}
+	closeWg            sync.WaitGroup
+	history            uint64
+	hashScheme         bool // use hashdb-safe delete range method
+	exportFileName     string
+	checkpoints        []checkpointList // known checkpoint sets, including the trusted external one
+	trustedCheckpoints bool             // first checkpoint set is loaded from an external file
 	Params
 
 	db ethdb.KeyValueStore
@@ -219,6 +221,11 @@ type Config struct {
 	// If set, the given file will be updated with checkpoint information.
 	ExportFileName string
 
+	// This option specifies an external checkpoint JSON file in the format
+	// produced by the exporter. If the checkpoints match the canonical chain,
+	// the indexer can start from the latest one instead of the history cutoff.
+	CheckpointFile string
+
 	// expect trie nodes of hash based state scheme in the filtermaps key range;
 	// use safe iterator based implementation of DeleteRange that skips them
 	HashScheme bool
@@ -226,6 +233,29 @@ type Config struct {
 
 // NewFilterMaps creates a new FilterMaps and starts the indexer.
 func NewFilterMaps(db ethdb.KeyValueStore, initView *ChainView, historyCutoff, finalBlock uint64, params Params, config Config) *FilterMaps {
+	f := newFilterMaps(db, initView, historyCutoff, finalBlock, params, config)
+	f.checkRevertRange() // revert maps that are inconsistent with the current chain view
+
+	if f.indexedRange.hasIndexedBlocks() {
+		log.Info("Initialized log indexer",
+			"first block", f.indexedRange.blocks.First(), "last block", f.indexedRange.blocks.Last(),
+			"first map", f.indexedRange.maps.First(), "last map", f.indexedRange.maps.Last(),
+			"head indexed", f.indexedRange.headIndexed)
+	}
+	return f
+}
+
+// OpenFilterMaps opens the existing log index for offline inspection. Unlike
+// NewFilterMaps it does not check the index against the chain view, so it never
+// reverts or resets any part of the database. The returned instance should not
+// be started.
+func OpenFilterMaps(db ethdb.KeyValueStore, view *ChainView, params Params, config Config) *FilterMaps {
+	return newFilterMaps(db, view, 0, 0, params, config)
+}
+
+// newFilterMaps creates a FilterMaps instance from the stored index range
+// without modifying the database.
+func newFilterMaps(db ethdb.KeyValueStore, initView *ChainView, historyCutoff, finalBlock uint64, params Params, config Config) *FilterMaps {
 	rs, initialized, err := rawdb.ReadFilterMapsRange(db)
 	if err != nil || rs.Version != databaseVersion {
 		rs, initialized = rawdb.FilterMapsRange{}, false
@@ -267,13 +297,17 @@ func NewFilterMaps(db ethdb.KeyValueStore, initView *ChainView, historyCutoff, f
 		baseRowsCache:   lru.NewCache[uint64, [][]uint32](cachedBaseRows),
 		renderSnapshots: lru.NewCache[uint64, *renderedMap](cachedRenderSnapshots),
 	}
-	f.checkRevertRange() // revert maps that are inconsistent with the current chain view
-
-	if f.indexedRange.hasIndexedBlocks() {
-		log.Info("Initialized log indexer",
-			"first block", f.indexedRange.blocks.First(), "last block", f.indexedRange.blocks.Last(),
-			"first map", f.indexedRange.maps.First(), "last map", f.indexedRange.maps.Last(),
-			"head indexed", f.indexedRange.headIndexed)
+	f.checkpoints = checkpoints
+	if config.CheckpointFile != "" {
+		if cl, err := readCheckpointFile(config.CheckpointFile); err != nil {
+			log.Error("Error reading log index checkpoint file", "name", config.CheckpointFile, "error", err)
+		} else if err := cl.validate(&f.Params); err != nil {
+			log.Error("Invalid log index checkpoint file", "name", config.CheckpointFile, "error", err)
+		} else {
+			log.Info("Loaded log index checkpoints", "name", config.CheckpointFile, "epochs", len(cl))
+			f.checkpoints = append([]checkpointList{cl}, checkpoints...)
+			f.trustedCheckpoints = true
+		}
 	}
 	return f
 }
@@ -377,7 +411,12 @@ func (f *FilterMaps) init() error {
 	defer f.indexLock.Unlock()
 
 	var bestIdx, bestLen int
-	for idx, checkpointList := range checkpoints {
+	for idx, checkpointList := range f.checkpoints {
+		if idx == 0 && f.trustedCheckpoints {
+			// trusted checkpoints are fully verified against the target view
+			bestLen = f.verifyCheckpoints()
+			continue
+		}
 		// binary search for the last matching epoch head
 		min, max := 0, len(checkpointList)
 		for min < max {
@@ -395,14 +434,14 @@ func (f *FilterMaps) init() error {
 	}
 	var initBlockNumber uint64
 	if bestLen > 0 {
-		initBlockNumber = checkpoints[bestIdx][bestLen-1].BlockNumber
+		initBlockNumber = f.checkpoints[bestIdx][bestLen-1].BlockNumber
 	}
 	if initBlockNumber < f.historyCutoff {
 		return errors.New("cannot start indexing before history cutoff point")
 	}
 	batch := f.db.NewBatch()
 	for epoch := range bestLen {
-		cp := checkpoints[bestIdx][epoch]
+		cp := f.checkpoints[bestIdx][epoch]
 		f.storeLastBlockOfMap(batch, (uint32(epoch+1)<<f.logMapsPerEpoch)-1, cp.BlockNumber, cp.BlockId)
 		f.storeBlockLvPointer(batch, cp.BlockNumber, cp.FirstIndex)
 	}
@@ -410,7 +449,7 @@ func (f *FilterMaps) init() error {
 		initialized: true,
 	}
 	if bestLen > 0 {
-		cp := checkpoints[bestIdx][bestLen-1]
+		cp := f.checkpoints[bestIdx][bestLen-1]
 		fmr.blocks = common.NewRange(cp.BlockNumber+1, 0)
 		fmr.maps = common.NewRange(uint32(bestLen)<<f.logMapsPerEpoch, 0)
 	}
@@ -418,6 +457,26 @@ func (f *FilterMaps) init() error {
 	return batch.Write()
 }
 
+// verifyCheckpoints checks the trusted external checkpoints that are covered by
+// the current target view against the canonical chain and returns the length of
+// the longest consistent prefix. Checkpoints after the first mismatch are never
+// used for initialization.
+func (f *FilterMaps) verifyCheckpoints() int {
+	cl := f.checkpoints[0]
+	for epoch, cp := range cl {
+		if cp.BlockNumber > f.targetView.HeadNumber() {
+			log.Info("Verified trusted log index checkpoints", "matched", epoch, "total", len(cl))
+			return epoch
+		}
+		if f.targetView.BlockId(cp.BlockNumber) != cp.BlockId {
+			log.Warn("Trusted log index checkpoint does not match canonical chain", "epoch", epoch, "number", cp.BlockNumber, "id", cp.BlockId)
+			return epoch
+		}
+	}
+	log.Info("Verified trusted log index checkpoints", "matched", len(cl), "total", len(cl))
+	return len(cl)
+}
+
 // removeBloomBits removes old bloom bits data from the database.
 func (f *FilterMaps) removeBloomBits() {
 	f.safeDeleteWithLogs(rawdb.DeleteBloomBitsDb, "Removing old bloom bits database", f.isShuttingDown)
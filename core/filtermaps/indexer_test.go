@@ -21,8 +21,12 @@ import (
 	crand "crypto/rand"
 	"crypto/sha256"
 	"encoding/binary"
+	"encoding/json"
 	"math/big"
 	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
 	"sync"
 	"testing"
 
@@ -337,6 +341,87 @@ func TestIndexerCompareDb(t *testing.T) {
 	ts.checkDbHash("no index")
 }
 
+func TestIndexerTrustedCheckpoints(t *testing.T) {
+	ts := newTestSetup(t)
+	defer ts.close()
+
+	ts.chain.addBlocks(1000, 10, 3, 4, true)
+	ts.setHistory(0, false)
+	ts.fm.WaitIdle()
+	refHash := ts.matcherViewHash()
+
+	// collect the epoch checkpoints of the fully rendered index
+	var cl checkpointList
+	epochCount := ts.fm.indexedRange.maps.AfterLast() >> ts.params.logMapsPerEpoch
+	for epoch := uint32(0); epoch < epochCount; epoch++ {
+		number, id, err := ts.fm.getLastBlockOfMap((epoch+1)<<ts.params.logMapsPerEpoch - 1)
+		if err != nil {
+			t.Fatalf("Error fetching last block of epoch %d: %v", epoch, err)
+		}
+		lvPtr, err := ts.fm.getBlockLvPointer(number)
+		if err != nil {
+			t.Fatalf("Error fetching log value pointer of block %d: %v", number, err)
+		}
+		cl = append(cl, epochCheckpoint{BlockNumber: number, BlockId: id, FirstIndex: lvPtr})
+	}
+	if len(cl) < 2 {
+		t.Fatalf("Not enough epochs rendered for checkpoint test (got %d)", len(cl))
+	}
+	if err := cl.validate(&ts.params); err != nil {
+		t.Fatalf("Exported checkpoints failed validation: %v", err)
+	}
+	writeCheckpoints := func(cl checkpointList) {
+		enc, err := json.Marshal(cl)
+		if err != nil {
+			t.Fatalf("Error encoding checkpoints: %v", err)
+		}
+		ts.checkpointFile = filepath.Join(t.TempDir(), "checkpoints.json")
+		if err := os.WriteFile(ts.checkpointFile, enc, 0644); err != nil {
+			t.Fatalf("Error writing checkpoint file: %v", err)
+		}
+	}
+
+	// reindex from the trusted checkpoints; the index should start at the last
+	// checkpoint and end up identical after tail rendering
+	writeCheckpoints(cl)
+	ts.setHistory(0, true)
+	ts.fm.WaitIdle()
+	ts.setHistory(0, false)
+	if !ts.fm.trustedCheckpoints {
+		t.Fatalf("Trusted checkpoints not loaded")
+	}
+	ts.fm.WaitIdle()
+	if hash := ts.matcherViewHash(); hash != refHash {
+		t.Fatalf("Log index initialized from trusted checkpoints does not match reference")
+	}
+
+	// checkpoints that do not match the canonical chain should be ignored
+	bad := slices.Clone(cl)
+	bad[0].BlockId = common.Hash{1}
+	writeCheckpoints(bad)
+	ts.setHistory(0, true)
+	ts.fm.WaitIdle()
+	ts.setHistory(0, false)
+	ts.fm.WaitIdle()
+	if hash := ts.matcherViewHash(); hash != refHash {
+		t.Fatalf("Log index initialized with mismatching checkpoints does not match reference")
+	}
+
+	// inconsistent checkpoint files should be rejected
+	bad = slices.Clone(cl)
+	bad[1].FirstIndex = bad[0].FirstIndex
+	if err := bad.validate(&ts.params); err == nil {
+		t.Fatalf("Non-increasing checkpoints passed validation")
+	}
+	writeCheckpoints(bad)
+	ts.setHistory(0, true)
+	ts.fm.WaitIdle()
+	ts.setHistory(0, false)
+	if ts.fm.trustedCheckpoints {
+		t.Fatalf("Invalid checkpoint file loaded")
+	}
+}
+
 type testSetup struct {
 	t                    *testing.T
 	fm                   *FilterMaps
@@ -345,6 +430,7 @@ type testSetup struct {
 	params               Params
 	dbHashes             map[string]common.Hash
 	testDisableSnapshots bool
+	checkpointFile       string
 }
 
 func newTestSetup(t *testing.T) *testSetup {
@@ -367,8 +453,9 @@ func (ts *testSetup) setHistory(history uint64, noHistory bool) {
 	head := ts.chain.CurrentBlock()
 	view := NewChainView(ts.chain, head.Number.Uint64(), head.Hash())
 	config := Config{
-		History:  history,
-		Disabled: noHistory,
+		History:        history,
+		Disabled:       noHistory,
+		CheckpointFile: ts.checkpointFile,
 	}
 	ts.fm = NewFilterMaps(ts.db, view, 0, 0, ts.params, config)
 	ts.fm.testDisableSnapshots = ts.testDisableSnapshots
@@ -54,6 +54,7 @@ type mapRenderer struct {
 	finishedMaps map[uint32]*renderedMap
 	finished     common.Range[uint32]
 	iterator     *logIterator
+	noSnapshots  bool // do not create render snapshots (used for verification)
 }
 
 // renderedMap represents a single filter map that is being rendered in memory.
@@ -361,7 +362,7 @@ func (r *mapRenderer) renderCurrentMap(stopCb func() bool) (bool, error) {
 				blocksProcessed++
 				r.currentMap.blockLvPtrs = append(r.currentMap.blockLvPtrs, r.iterator.lvIndex)
 			}
-			if !r.f.testDisableSnapshots && r.renderBefore >= r.f.indexedRange.maps.AfterLast() &&
+			if !r.f.testDisableSnapshots && !r.noSnapshots && r.renderBefore >= r.f.indexedRange.maps.AfterLast() &&
 				(r.iterator.delimiter || r.iterator.finished) {
 				r.makeSnapshot()
 			}
@@ -0,0 +1,195 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package filtermaps
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// RowLocation identifies a single row of a filter map.
+type RowLocation struct {
+	MapIndex, RowIndex uint32
+}
+
+// EpochReport is the result of verifying a single epoch of the log index
+// against a freshly rendered version of the same maps.
+type EpochReport struct {
+	Epoch  uint32
+	Maps   common.Range[uint32] // verified map range
+	Blocks common.Range[uint64] // verified block pointer range
+
+	BadRows       []RowLocation // filter rows that differ from the rendered ones
+	BadLastBlocks []uint32      // maps with a missing or wrong last block pointer
+	BadLvPointers []uint64      // blocks with a missing or wrong log value pointer
+	Repaired      bool          // damaged epoch has been rewritten
+}
+
+// Damaged returns true if any mismatch has been found in the epoch.
+func (r *EpochReport) Damaged() bool {
+	return len(r.BadRows) > 0 || len(r.BadLastBlocks) > 0 || len(r.BadLvPointers) > 0
+}
+
+// IndexedEpochs returns the range of epochs that have at least one rendered
+// map in the log index.
+func (f *FilterMaps) IndexedEpochs() common.Range[uint32] {
+	f.indexLock.RLock()
+	defer f.indexLock.RUnlock()
+
+	if !f.indexedRange.initialized || f.indexedRange.maps.IsEmpty() {
+		return common.Range[uint32]{}
+	}
+	first := f.indexedRange.maps.First() >> f.logMapsPerEpoch
+	afterLast := (f.indexedRange.maps.AfterLast() + f.mapsPerEpoch - 1) >> f.logMapsPerEpoch
+	return common.NewRange(first, afterLast-first)
+}
+
+// VerifyEpoch re-renders the indexed maps of the given epoch from the chain
+// receipts and compares the resulting filter rows, last block of map pointers
+// and block log value pointers with the ones stored in the database. If repair
+// is true and any mismatch is found, the rendered data of the entire epoch is
+// written to the database.
+// Rendering starts from the last block pointer of the map before the epoch
+// which is assumed to be correct; verifying the previous epoch also checks it.
+//
+// Note that this function should only be called while the indexer is not
+// running.
+func (f *FilterMaps) VerifyEpoch(epoch uint32, repair bool) (*EpochReport, error) {
+	if !f.indexedRange.initialized {
+		return nil, errors.New("log index not initialized")
+	}
+	epochMaps := common.NewRange(epoch<<f.logMapsPerEpoch, f.mapsPerEpoch)
+	report := &EpochReport{
+		Epoch: epoch,
+		Maps:  epochMaps.Intersection(f.indexedRange.maps),
+	}
+	if report.Maps.IsEmpty() {
+		return nil, fmt.Errorf("epoch %d is not indexed", epoch)
+	}
+	var startBlock, startLvPtr uint64
+	if firstMap := report.Maps.First(); firstMap > 0 {
+		var err error
+		if startBlock, _, err = f.getLastBlockOfMap(firstMap - 1); err != nil {
+			return nil, fmt.Errorf("failed to retrieve last block of map %d before verified range: %v", firstMap-1, err)
+		}
+		if startLvPtr, err = f.getBlockLvPointer(startBlock); err != nil {
+			return nil, fmt.Errorf("failed to retrieve log value pointer of verified range start block %d: %v", startBlock, err)
+		}
+	}
+	maps, err := f.renderVerifiedMaps(report.Maps, startBlock, startLvPtr)
+	if err != nil {
+		return nil, err
+	}
+	// compare filter rows and last block pointers
+	for mapIndex := range report.Maps.Iter() {
+		rendered := maps[mapIndex]
+		for rowIndex := uint32(0); rowIndex < f.mapHeight; rowIndex++ {
+			row, err := f.getFilterMapRow(mapIndex, rowIndex, false)
+			if err != nil || !row.Equal(rendered.filterMap[rowIndex]) {
+				report.BadRows = append(report.BadRows, RowLocation{MapIndex: mapIndex, RowIndex: rowIndex})
+			}
+		}
+		number, id, err := f.getLastBlockOfMap(mapIndex)
+		if err != nil || number != rendered.lastBlock || id != rendered.lastBlockId {
+			report.BadLastBlocks = append(report.BadLastBlocks, mapIndex)
+		}
+	}
+	// compare block log value pointers
+	blockNumber := maps[report.Maps.First()].firstBlock()
+	report.Blocks = common.NewRange(blockNumber, 0)
+	for mapIndex := range report.Maps.Iter() {
+		for _, lvPtr := range maps[mapIndex].blockLvPtrs {
+			if stored, err := f.getBlockLvPointer(blockNumber); err != nil || stored != lvPtr {
+				report.BadLvPointers = append(report.BadLvPointers, blockNumber)
+			}
+			blockNumber++
+		}
+	}
+	report.Blocks.SetAfterLast(blockNumber)
+	if repair && report.Damaged() {
+		if err := f.writeVerifiedMaps(report.Maps, maps); err != nil {
+			return report, err
+		}
+		report.Repaired = true
+		log.Info("Repaired log index epoch", "epoch", epoch, "rows", len(report.BadRows),
+			"lastblocks", len(report.BadLastBlocks), "lvpointers", len(report.BadLvPointers))
+	}
+	return report, nil
+}
+
+// renderVerifiedMaps renders the given range of maps in memory, starting from
+// the specified map boundary.
+func (f *FilterMaps) renderVerifiedMaps(mapRange common.Range[uint32], startBlock, startLvPtr uint64) (map[uint32]*renderedMap, error) {
+	r, err := f.renderMapsFromMapBoundary(mapRange.First(), mapRange.AfterLast(), startBlock, startLvPtr)
+	if err != nil {
+		return nil, err
+	}
+	r.noSnapshots = true
+	maps := make(map[uint32]*renderedMap)
+	for {
+		if _, err := r.renderCurrentMap(func() bool { return false }); err != nil {
+			return nil, err
+		}
+		maps[r.currentMap.mapIndex] = r.currentMap
+		if r.currentMap.mapIndex+1 == mapRange.AfterLast() {
+			return maps, nil
+		}
+		if r.iterator.finished {
+			return nil, fmt.Errorf("log iterator finished at map %d before end of verified range %d", r.currentMap.mapIndex, mapRange.Last())
+		}
+		r.currentMap = &renderedMap{
+			filterMap: f.emptyFilterMap(),
+			mapIndex:  r.currentMap.mapIndex + 1,
+		}
+	}
+}
+
+// writeVerifiedMaps overwrites the given range of maps and the associated
+// block pointers with the freshly rendered data.
+func (f *FilterMaps) writeVerifiedMaps(mapRange common.Range[uint32], maps map[uint32]*renderedMap) error {
+	f.indexLock.Lock()
+	defer f.indexLock.Unlock()
+
+	batch := f.db.NewBatch()
+	for rowIndex := uint32(0); rowIndex < f.mapHeight; rowIndex++ {
+		var (
+			mapIndices []uint32
+			rows       []FilterRow
+		)
+		for mapIndex := range mapRange.Iter() {
+			mapIndices = append(mapIndices, mapIndex)
+			rows = append(rows, maps[mapIndex].filterMap[rowIndex])
+		}
+		if err := f.storeFilterMapRows(batch, mapIndices, rowIndex, rows); err != nil {
+			return fmt.Errorf("failed to store filter maps %v row %d: %v", mapIndices, rowIndex, err)
+		}
+	}
+	blockNumber := maps[mapRange.First()].firstBlock()
+	for mapIndex := range mapRange.Iter() {
+		rendered := maps[mapIndex]
+		f.filterMapCache.Remove(mapIndex)
+		f.storeLastBlockOfMap(batch, mapIndex, rendered.lastBlock, rendered.lastBlockId)
+		for _, lvPtr := range rendered.blockLvPtrs {
+			f.storeBlockLvPointer(batch, blockNumber, lvPtr)
+			blockNumber++
+		}
+	}
+	return batch.Write()
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package filtermaps
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+func TestVerifyEpoch(t *testing.T) {
+	ts := newTestSetup(t)
+	defer ts.close()
+
+	ts.chain.addBlocks(1000, 10, 3, 4, true)
+	ts.setHistory(0, false)
+	ts.fm.WaitIdle()
+	ts.fm.Stop()
+	ts.fm = nil
+	ts.storeDbHash("original")
+
+	newOfflineFm := func() *FilterMaps {
+		head := ts.chain.CurrentBlock()
+		view := NewChainView(ts.chain, head.Number.Uint64(), head.Hash())
+		return OpenFilterMaps(ts.db, view, ts.params, Config{})
+	}
+	verifyAll := func(fm *FilterMaps, repair bool) (damaged []*EpochReport) {
+		epochs := fm.IndexedEpochs()
+		if epochs.Count() < 3 {
+			t.Fatalf("Not enough indexed epochs (got %d)", epochs.Count())
+		}
+		for epoch := range epochs.Iter() {
+			report, err := fm.VerifyEpoch(epoch, repair)
+			if err != nil {
+				t.Fatalf("Error verifying epoch %d: %v", epoch, err)
+			}
+			if report.Damaged() {
+				damaged = append(damaged, report)
+			}
+		}
+		return damaged
+	}
+
+	fm := newOfflineFm()
+	if damaged := verifyAll(fm, false); len(damaged) != 0 {
+		t.Fatalf("Intact log index reported as damaged (epochs: %d)", len(damaged))
+	}
+
+	// damage a filter row, a last block pointer and a block pointer in
+	// different epochs
+	var (
+		badRowMap  = uint32(1<<ts.params.logMapsPerEpoch) + 3
+		badLastMap = uint32(2<<ts.params.logMapsPerEpoch) + 5
+		badLvBlock = fm.indexedRange.blocks.Last() / 2
+	)
+	// the pointer of a map's last block is the starting point of rendering the
+	// next map and is assumed to be correct, so damage a block inside a map
+	var badLvMap uint32
+	for ; ; badLvBlock++ {
+		lvPtr, err := fm.getBlockLvPointer(badLvBlock)
+		if err != nil {
+			t.Fatalf("Error fetching log value pointer of block %d: %v", badLvBlock, err)
+		}
+		badLvMap = uint32(lvPtr >> ts.params.logValuesPerMap)
+		if last, _, err := fm.getLastBlockOfMap(badLvMap); err == nil && last != badLvBlock {
+			break
+		}
+	}
+	rawdb.WriteFilterMapExtRow(ts.db, fm.mapRowIndex(badRowMap, 1), []uint32{1, 2, 3}, ts.params.logMapWidth)
+	rawdb.WriteFilterMapLastBlock(ts.db, badLastMap, 1, common.Hash{1})
+	rawdb.WriteBlockLvPointer(ts.db, badLvBlock, 12345678)
+
+	fm = newOfflineFm()
+	damaged := verifyAll(fm, false)
+	expEpochs := map[uint32]bool{
+		badRowMap >> ts.params.logMapsPerEpoch:  true,
+		badLastMap >> ts.params.logMapsPerEpoch: true,
+		badLvMap >> ts.params.logMapsPerEpoch:   true,
+	}
+	if len(damaged) != len(expEpochs) {
+		t.Fatalf("Wrong number of damaged epochs (got %d, expected %d)", len(damaged), len(expEpochs))
+	}
+	for _, report := range damaged {
+		if !expEpochs[report.Epoch] {
+			t.Fatalf("Unexpected damaged epoch %d", report.Epoch)
+		}
+		if report.Repaired {
+			t.Fatalf("Epoch %d repaired without repair flag", report.Epoch)
+		}
+	}
+
+	// repair damaged epochs and check that the database is restored
+	if damaged := verifyAll(fm, true); len(damaged) != len(expEpochs) {
+		t.Fatalf("Wrong number of repaired epochs (got %d, expected %d)", len(damaged), len(expEpochs))
+	}
+	if damaged := verifyAll(newOfflineFm(), false); len(damaged) != 0 {
+		t.Fatalf("Repaired log index reported as damaged (epochs: %d)", len(damaged))
+	}
+	ts.checkDbHash("original")
+
+	// damage the last block pointer of the head map; opening the index for
+	// verification should not revert or reset it
+	lastMap := fm.indexedRange.maps.Last()
+	rawdb.WriteFilterMapLastBlock(ts.db, lastMap, 1, common.Hash{1})
+	fm = newOfflineFm()
+	if fm.indexedRange.maps.Last() != lastMap {
+		t.Fatalf("Log index range changed while opening damaged index (last map %d, expected %d)", fm.indexedRange.maps.Last(), lastMap)
+	}
+	if damaged := verifyAll(fm, true); len(damaged) != 1 {
+		t.Fatalf("Wrong number of repaired epochs (got %d, expected 1)", len(damaged))
+	}
+	ts.checkDbHash("original")
+}
@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/objstore"
 )
 
 // The list of table names of chain freezer.
@@ -50,8 +51,14 @@ var chainFreezerTableConfigs = map[string]freezerTableConfig{
 
 // freezerTableConfig contains the settings for a freezer table.
 type freezerTableConfig struct {
-	noSnappy bool // disables item compression
-	prunable bool // true for tables that can be pruned by TruncateTail
+	noSnappy bool           // disables item compression
+	prunable bool           // true for tables that can be pruned by TruncateTail
+	remote   objstore.Store // offloads sealed data files to a remote store if set
+
+	// secondary is set for read-only tables which are written concurrently
+	// by another process. Items beyond the flush offset and dangling data are
+	// accepted instead of being reported as corruption.
+	secondary bool
 }
 
 const (
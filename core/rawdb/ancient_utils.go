@@ -17,16 +17,22 @@
 package rawdb
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/objstore"
+	"github.com/ethereum/go-ethereum/log"
 )
 
 type tableSize struct {
-	name string
-	size common.StorageSize
+	name  string
+	size  common.StorageSize
+	zstd  bool               // whether the table is zstd compressed
+	saved common.StorageSize // estimated space saved by zstd compared to snappy
 }
 
 // freezerInfo contains the basic information of the freezer.
@@ -51,14 +57,21 @@ func (info *freezerInfo) size() common.StorageSize {
 	return total
 }
 
-func inspect(name string, order map[string]freezerTableConfig, reader ethdb.AncientReader) (freezerInfo, error) {
+func inspect(name string, order map[string]freezerTableConfig, reader ethdb.AncientReader, path string) (freezerInfo, error) {
 	info := freezerInfo{name: name}
-	for t := range order {
+	for t, config := range order {
 		size, err := reader.AncientSize(t)
 		if err != nil {
 			return freezerInfo{}, err
 		}
-		info.sizes = append(info.sizes, tableSize{name: t, size: common.StorageSize(size)})
+		ts := tableSize{name: t, size: common.StorageSize(size)}
+		if path != "" && !config.noSnappy && common.FileExist(filepath.Join(path, fmt.Sprintf("%s.zidx", t))) {
+			ts.zstd = true
+			if ts.saved, err = inspectSavings(path, t, config); err != nil {
+				log.Warn("Failed to estimate zstd savings", "table", t, "err", err)
+			}
+		}
+		info.sizes = append(info.sizes, ts)
 	}
 	// Retrieve the number of last stored item
 	ancients, err := reader.Ancients()
@@ -76,13 +89,35 @@ func inspect(name string, order map[string]freezerTableConfig, reader ethdb.Anci
 	return info, nil
 }
 
+// inspectSavings estimates the space saved by a zstd compressed table. The
+// sampled items of offloaded data files are read from the remote store.
+func inspectSavings(path, name string, config freezerTableConfig) (common.StorageSize, error) {
+	table, err := newFreezerTable(path, name, config, true)
+	if err != nil {
+		return 0, err
+	}
+	defer table.Close()
+
+	return table.zstdSavings()
+}
+
 // inspectFreezers inspects all freezers registered in the system.
 func inspectFreezers(db ethdb.Database) ([]freezerInfo, error) {
 	var infos []freezerInfo
 	for _, freezer := range freezers {
 		switch freezer {
 		case ChainFreezerName:
-			info, err := inspect(ChainFreezerName, chainFreezerTableConfigs, db)
+			// The chain freezer might be in-memory, in which case there
+			// is nothing to inspect on disk.
+			var path string
+			if datadir, err := db.AncientDatadir(); err == nil && datadir != "" {
+				path = resolveChainFreezerDir(datadir)
+			}
+			var remote objstore.Store
+			if f, ok := db.(interface{ remoteStore() objstore.Store }); ok {
+				remote = f.remoteStore()
+			}
+			info, err := inspect(ChainFreezerName, chainFreezerTables(remote), db, path)
 			if err != nil {
 				return nil, err
 			}
@@ -99,7 +134,7 @@ func inspectFreezers(db ethdb.Database) ([]freezerInfo, error) {
 			}
 			defer f.Close()
 
-			info, err := inspect(freezer, stateFreezerTableConfigs, f)
+			info, err := inspect(freezer, stateFreezerTableConfigs, f, filepath.Join(datadir, freezer))
 			if err != nil {
 				return nil, err
 			}
@@ -117,6 +152,56 @@ func inspectFreezers(db ethdb.Database) ([]freezerInfo, error) {
 // be opened. Start and end specify the range for dumping out indexes.
 // Note this function can only be used for debugging purposes.
 func InspectFreezerTable(ancient string, freezerName string, tableName string, start, end int64) error {
+	path, config, err := resolveFreezerTable(ancient, freezerName, tableName)
+	if err != nil {
+		return err
+	}
+	table, err := newFreezerTable(path, tableName, config, true)
+	if err != nil {
+		return err
+	}
+	table.dumpIndexStdout(start, end)
+	return nil
+}
+
+// RecompressFreezerTable converts a snappy compressed freezer table to zstd
+// compression with a dictionary trained from the stored items. The passed
+// ancient indicates the path of root ancient directory where the freezer can
+// be opened. The remote store holding the offloaded chain segments, if any,
+// must be passed as well. The freezer must not be in use during the conversion.
+func RecompressFreezerTable(ancient string, freezerName string, tableName string, remote objstore.Store) error {
+	path, config, err := resolveFreezerTable(ancient, freezerName, tableName)
+	if err != nil {
+		return err
+	}
+	if freezerName == ChainFreezerName {
+		config.remote = remote // state histories are never offloaded
+	}
+	lock := NewFileLock(filepath.Join(path, "FLOCK"))
+	if locked, err := lock.TryLock(); err != nil {
+		return err
+	} else if !locked {
+		return errors.New("freezer is in use")
+	}
+	defer lock.Unlock()
+
+	start := time.Now()
+	oldSize, newSize, err := recompressTable(path, tableName, config)
+	if err != nil {
+		return err
+	}
+	if oldSize == 0 && newSize == 0 {
+		log.Info("Freezer table is already zstd compressed", "freezer", freezerName, "table", tableName)
+		return nil
+	}
+	log.Info("Recompressed freezer table", "freezer", freezerName, "table", tableName,
+		"old", common.StorageSize(oldSize), "new", common.StorageSize(newSize), "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// resolveFreezerTable returns the directory and the configuration of the
+// given freezer table.
+func resolveFreezerTable(ancient string, freezerName string, tableName string) (string, freezerTableConfig, error) {
 	var (
 		path   string
 		tables map[string]freezerTableConfig
@@ -127,20 +212,15 @@ func InspectFreezerTable(ancient string, freezerName string, tableName string, s
 	case MerkleStateFreezerName, VerkleStateFreezerName:
 		path, tables = filepath.Join(ancient, freezerName), stateFreezerTableConfigs
 	default:
-		return fmt.Errorf("unknown freezer, supported ones: %v", freezers)
+		return "", freezerTableConfig{}, fmt.Errorf("unknown freezer, supported ones: %v", freezers)
 	}
-	noSnappy, exist := tables[tableName]
+	config, exist := tables[tableName]
 	if !exist {
 		var names []string
 		for name := range tables {
 			names = append(names, name)
 		}
-		return fmt.Errorf("unknown table, supported ones: %v", names)
+		return "", freezerTableConfig{}, fmt.Errorf("unknown table, supported ones: %v", names)
 	}
-	table, err := newFreezerTable(path, tableName, noSnappy, true)
-	if err != nil {
-		return err
-	}
-	table.dumpIndexStdout(start, end)
-	return nil
+	return path, config, nil
 }
@@ -24,6 +24,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/objstore"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
@@ -43,7 +44,8 @@ const (
 // feature. The background thread will keep moving ancient chain segments from
 // key-value database to flat files for saving space on live database.
 type chainFreezer struct {
-	ethdb.AncientStore // Ancient store for storing cold chain segment
+	ethdb.AncientStore                // Ancient store for storing cold chain segment
+	remote             objstore.Store // Remote store of the offloaded data files, nil if disabled
 
 	quit    chan struct{}
 	wg      sync.WaitGroup
@@ -56,7 +58,9 @@ type chainFreezer struct {
 //     state freezer (e.g. dev mode).
 //   - if non-empty directory is given, initializes the regular file-based
 //     state freezer.
-func newChainFreezer(datadir string, namespace string, readonly bool) (*chainFreezer, error) {
+//   - if a remote store is given, the sealed data files of the file-based
+//     freezer are offloaded to it.
+func newChainFreezer(datadir string, namespace string, readonly bool, remote objstore.Store) (*chainFreezer, error) {
 	var (
 		err     error
 		freezer ethdb.AncientStore
@@ -64,18 +68,53 @@ func newChainFreezer(datadir string, namespace string, readonly bool) (*chainFre
 	if datadir == "" {
 		freezer = NewMemoryFreezer(readonly, chainFreezerTableConfigs)
 	} else {
-		freezer, err = NewFreezer(datadir, namespace, readonly, freezerTableSize, chainFreezerTableConfigs)
+		freezer, err = NewFreezer(datadir, namespace, readonly, freezerTableSize, chainFreezerTables(remote))
 	}
 	if err != nil {
 		return nil, err
 	}
 	return &chainFreezer{
 		AncientStore: freezer,
+		remote:       remote,
 		quit:         make(chan struct{}),
 		trigger:      make(chan chan struct{}),
 	}, nil
 }
 
+// chainFreezerTables returns the configuration of the chain freezer tables,
+// offloading their sealed data files to the given remote store if non-nil.
+func chainFreezerTables(remote objstore.Store) map[string]freezerTableConfig {
+	if remote == nil {
+		return chainFreezerTableConfigs
+	}
+	tables := make(map[string]freezerTableConfig, len(chainFreezerTableConfigs))
+	for name, config := range chainFreezerTableConfigs {
+		config.remote = remote
+		tables[name] = config
+	}
+	return tables
+}
+
+// newSecondaryChainFreezer opens the chain freezer of another process as a
+// read-only secondary instance, see newFreezer.
+func newSecondaryChainFreezer(datadir string, namespace string) (*chainFreezer, error) {
+	freezer, err := newFreezer(datadir, namespace, true, true, freezerTableSize, chainFreezerTableConfigs)
+	if err != nil {
+		return nil, err
+	}
+	return &chainFreezer{
+		AncientStore: freezer,
+		quit:         make(chan struct{}),
+		trigger:      make(chan chan struct{}),
+	}, nil
+}
+
+// remoteStore returns the remote store of the offloaded data files, nil if
+// offloading is disabled.
+func (f *chainFreezer) remoteStore() objstore.Store {
+	return f.remote
+}
+
 // Close closes the chain freezer instance and terminates the background thread.
 func (f *chainFreezer) Close() error {
 	select {
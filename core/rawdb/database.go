@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/ethdb/objstore"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/olekukonko/tablewriter"
 )
@@ -229,6 +230,19 @@ func resolveChainFreezerDir(ancient string) string {
 // storage. The passed ancient indicates the path of root ancient directory
 // where the chain freezer can be opened.
 func NewDatabaseWithFreezer(db ethdb.KeyValueStore, ancient string, namespace string, readonly bool) (ethdb.Database, error) {
+	return NewDatabaseWithRemoteFreezer(db, ancient, namespace, readonly, nil)
+}
+
+// NewDatabaseWithRemoteFreezer creates a high level database on top of a given
+// key-value data store with a freezer moving immutable chain segments into cold
+// storage. If remote is non-nil, the sealed data files of the chain freezer are
+// offloaded to the given object store and only cached locally on demand.
+//
+// The state history freezer is always kept locally. State histories only cover
+// a short window of recent blocks that is continuously pruned from the tail,
+// and the freezer is wiped by resets, so offloading them would mostly produce
+// short-lived remote objects that have to be tracked and deleted again.
+func NewDatabaseWithRemoteFreezer(db ethdb.KeyValueStore, ancient string, namespace string, readonly bool, remote objstore.Store) (ethdb.Database, error) {
 	// Create the idle freezer instance. If the given ancient directory is empty,
 	// in-memory chain freezer is used (e.g. dev mode); otherwise the regular
 	// file-based freezer is created.
@@ -236,7 +250,7 @@ func NewDatabaseWithFreezer(db ethdb.KeyValueStore, ancient string, namespace st
 	if chainFreezerDir != "" {
 		chainFreezerDir = resolveChainFreezerDir(chainFreezerDir)
 	}
-	frdb, err := newChainFreezer(chainFreezerDir, namespace, readonly)
+	frdb, err := newChainFreezer(chainFreezerDir, namespace, readonly, remote)
 	if err != nil {
 		printChainMetadata(db)
 		return nil, err
@@ -334,6 +348,26 @@ func NewDatabaseWithFreezer(db ethdb.KeyValueStore, ancient string, namespace st
 	}, nil
 }
 
+// NewSecondaryDatabaseWithFreezer creates a read-only high level database on
+// top of the key-value store and the chain freezer of another process, which
+// keeps writing them. The freezer is opened without acquiring its lock and
+// reflects its content at the time of opening. As the two stores are updated
+// independently by the primary, their cross validation is skipped; the
+// key-value store should be opened before the freezer, so that no chain
+// segment is missed while being moved into the freezer.
+func NewSecondaryDatabaseWithFreezer(db ethdb.KeyValueStore, ancient string, namespace string) (ethdb.Database, error) {
+	frdb, err := newSecondaryChainFreezer(resolveChainFreezerDir(ancient), namespace)
+	if err != nil {
+		return nil, err
+	}
+	return &freezerdb{
+		ancientRoot:   ancient,
+		KeyValueStore: db,
+		chainFreezer:  frdb,
+		readOnly:      true,
+	}, nil
+}
+
 // NewMemoryDatabase creates an ephemeral in-memory key-value database without a
 // freezer moving immutable chain segments into cold storage.
 func NewMemoryDatabase() ethdb.Database {
@@ -573,9 +607,13 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 	}
 	for _, ancient := range ancients {
 		for _, table := range ancient.sizes {
+			category := strings.Title(table.name)
+			if table.zstd {
+				category = fmt.Sprintf("%s (zstd, ~%v saved)", category, table.saved)
+			}
 			stats = append(stats, []string{
 				fmt.Sprintf("Ancient store (%s)", strings.Title(ancient.name)),
-				strings.Title(table.name),
+				category,
 				table.size.String(),
 				fmt.Sprintf("%d", ancient.count()),
 			})
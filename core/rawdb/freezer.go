@@ -67,6 +67,7 @@ type Freezer struct {
 	writeBatch *freezerBatch
 
 	readonly     bool
+	secondary    bool                     // Read-only view of a freezer written by another process
 	tables       map[string]*freezerTable // Data tables for storing everything
 	instanceLock FileLock                 // File-system lock to prevent double opens
 	closeOnce    sync.Once
@@ -78,6 +79,16 @@ type Freezer struct {
 // The 'tables' argument defines the data tables. If the value of a map
 // entry is true, snappy compression is disabled for the table.
 func NewFreezer(datadir string, namespace string, readonly bool, maxTableSize uint32, tables map[string]freezerTableConfig) (*Freezer, error) {
+	return newFreezer(datadir, namespace, readonly, false, maxTableSize, tables)
+}
+
+// newFreezer creates a freezer instance. A secondary freezer is a read-only
+// view of a freezer which is written concurrently by another process: no lock
+// is acquired and the in-flight writes of the primary are tolerated.
+func newFreezer(datadir string, namespace string, readonly bool, secondary bool, maxTableSize uint32, tables map[string]freezerTableConfig) (*Freezer, error) {
+	if secondary && !readonly {
+		return nil, errors.New("secondary freezer must be read-only")
+	}
 	// Create the initial freezer object
 	var (
 		readMeter  = metrics.NewRegisteredMeter(namespace+"ancient/read", nil)
@@ -106,6 +117,11 @@ func NewFreezer(datadir string, namespace string, readonly bool, maxTableSize ui
 	if readonly {
 		tryLock = lock.TryRLock
 	}
+	if secondary {
+		// The lock is held by the primary instance
+		lock = nopFileLock{}
+		tryLock = lock.TryLock
+	}
 	if locked, err := tryLock(); err != nil {
 		return nil, err
 	} else if !locked {
@@ -115,12 +131,14 @@ func NewFreezer(datadir string, namespace string, readonly bool, maxTableSize ui
 	freezer := &Freezer{
 		datadir:      datadir,
 		readonly:     readonly,
+		secondary:    secondary,
 		tables:       make(map[string]*freezerTable),
 		instanceLock: lock,
 	}
 
 	// Create the tables.
 	for name, config := range tables {
+		config.secondary = secondary
 		table, err := newTable(datadir, name, readMeter, writeMeter, sizeGauge, maxTableSize, config, readonly)
 		if err != nil {
 			for _, table := range freezer.tables {
@@ -151,7 +169,11 @@ func NewFreezer(datadir string, namespace string, readonly bool, maxTableSize ui
 	// Create the write batch.
 	freezer.writeBatch = newFreezerBatch(freezer)
 
-	log.Info("Opened ancient database", "database", datadir, "readonly", readonly)
+	if secondary {
+		log.Debug("Opened secondary ancient database", "database", datadir, "items", freezer.frozen.Load())
+	} else {
+		log.Info("Opened ancient database", "database", datadir, "readonly", readonly)
+	}
 	return freezer, nil
 }
 
@@ -354,9 +376,13 @@ func (f *Freezer) validate() error {
 		break
 	}
 	for kind, table := range f.tables {
-		// all tables have to have the same head
+		// all tables have to have the same head, except in secondary mode
+		// where the primary might be in the middle of appending items.
 		if head != table.items.Load() {
-			return fmt.Errorf("freezer table %s has a differing head: %d != %d", kind, table.items.Load(), head)
+			if !f.secondary {
+				return fmt.Errorf("freezer table %s has a differing head: %d != %d", kind, table.items.Load(), head)
+			}
+			head = min(head, table.items.Load())
 		}
 		if !table.config.prunable {
 			// non-prunable tables have to start at 0
@@ -370,7 +396,10 @@ func (f *Freezer) validate() error {
 				prunedTail = &tmp
 			}
 			if *prunedTail != table.itemHidden.Load() {
-				return fmt.Errorf("freezer table %s has differing tail: %d != %d", kind, table.itemHidden.Load(), *prunedTail)
+				if !f.secondary {
+					return fmt.Errorf("freezer table %s has differing tail: %d != %d", kind, table.itemHidden.Load(), *prunedTail)
+				}
+				*prunedTail = max(*prunedTail, table.itemHidden.Load())
 			}
 		}
 	}
@@ -419,3 +448,11 @@ func (f *Freezer) repair() error {
 	f.tail.Store(prunedTail)
 	return nil
 }
+
+// nopFileLock is the instance lock of secondary freezers, which leave the
+// locking to the primary instance.
+type nopFileLock struct{}
+
+func (nopFileLock) Unlock() error           { return nil }
+func (nopFileLock) TryLock() (bool, error)  { return true, nil }
+func (nopFileLock) TryRLock() (bool, error) { return true, nil }
@@ -86,6 +86,7 @@ type freezerTableBatch struct {
 	t *freezerTable
 
 	sb          *snappyBuffer
+	zb          []byte // reusable buffer for zstd compression
 	encBuffer   writeBuffer
 	dataBuffer  []byte
 	indexBuffer []byte
@@ -96,7 +97,7 @@ type freezerTableBatch struct {
 // newBatch creates a new batch for the freezer table.
 func (t *freezerTable) newBatch() *freezerTableBatch {
 	batch := &freezerTableBatch{t: t}
-	if !t.config.noSnappy {
+	if !t.config.noSnappy && t.zstd == nil {
 		batch.sb = new(snappyBuffer)
 	}
 	batch.reset()
@@ -124,11 +125,7 @@ func (batch *freezerTableBatch) Append(item uint64, data interface{}) error {
 	if err := rlp.Encode(&batch.encBuffer, data); err != nil {
 		return err
 	}
-	encItem := batch.encBuffer.data
-	if batch.sb != nil {
-		encItem = batch.sb.compress(encItem)
-	}
-	return batch.appendItem(encItem)
+	return batch.appendItem(batch.compress(batch.encBuffer.data))
 }
 
 // AppendRaw injects a binary blob at the end of the freezer table. The item number is a
@@ -139,11 +136,21 @@ func (batch *freezerTableBatch) AppendRaw(item uint64, blob []byte) error {
 		return fmt.Errorf("%w: have %d want %d", errOutOrderInsertion, item, batch.curItem)
 	}
 
-	encItem := blob
-	if batch.sb != nil {
-		encItem = batch.sb.compress(blob)
+	return batch.appendItem(batch.compress(blob))
+}
+
+// compress compresses the item according to the table settings. The returned
+// slice is only valid until the next call.
+func (batch *freezerTableBatch) compress(data []byte) []byte {
+	switch {
+	case batch.t.zstd != nil:
+		batch.zb = batch.t.zstd.compress(batch.zb[:0], data)
+		return batch.zb
+	case batch.sb != nil:
+		return batch.sb.compress(data)
+	default:
+		return data
 	}
-	return batch.appendItem(encItem)
 }
 
 func (batch *freezerTableBatch) appendItem(data []byte) error {
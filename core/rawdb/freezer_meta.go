@@ -29,6 +29,7 @@ import (
 const (
 	freezerTableV1 = 1              // Initial version of metadata struct
 	freezerTableV2 = 2              // Add field: 'flushOffset'
+	freezerTableV3 = 3              // Add fields: 'zstd', 'dictionary'
 	freezerVersion = freezerTableV2 // The current used version
 )
 
@@ -65,6 +66,17 @@ type freezerTableMeta struct {
 	// The offset could be moved forward by applying sync operation, or be moved
 	// backward in cases of head/tail truncation, etc.
 	flushOffset int64
+
+	// zstd indicates that the items are compressed with zstd instead of snappy.
+	// The table is only ever switched to zstd by an offline recompression, the
+	// v3 format is used for these tables only, leaving the others readable by
+	// older releases.
+	zstd bool
+
+	// dictionary is the zstd dictionary used for compressing the items, empty
+	// if no dictionary is used. It never changes after the recompression, its
+	// identifier is embedded in every compressed item.
+	dictionary []byte
 }
 
 // decodeV1 attempts to decode the metadata structure in v1 format. If fails or
@@ -123,6 +135,41 @@ func decodeV2(file *os.File) *freezerTableMeta {
 	}
 }
 
+// decodeV3 attempts to decode the metadata structure in v3 format. If fails or
+// the result is incompatible, nil is returned.
+func decodeV3(file *os.File) *freezerTableMeta {
+	_, err := file.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil
+	}
+	type obj struct {
+		Version    uint16
+		Tail       uint64
+		Offset     uint64
+		Zstd       bool
+		Dictionary []byte
+	}
+	var o obj
+	if err := rlp.Decode(file, &o); err != nil {
+		return nil
+	}
+	if o.Version != freezerTableV3 {
+		return nil
+	}
+	if o.Offset > math.MaxInt64 {
+		log.Error("Invalid flushOffset %d in freezer metadata", o.Offset, "file", file.Name())
+		return nil
+	}
+	return &freezerTableMeta{
+		file:        file,
+		version:     freezerTableV3,
+		virtualTail: o.Tail,
+		flushOffset: int64(o.Offset),
+		zstd:        o.Zstd,
+		dictionary:  o.Dictionary,
+	}
+}
+
 // newMetadata initializes the metadata object, either by loading it from the file
 // or by constructing a new one from scratch.
 func newMetadata(file *os.File) (*freezerTableMeta, error) {
@@ -142,6 +189,9 @@ func newMetadata(file *os.File) (*freezerTableMeta, error) {
 		}
 		return m, nil
 	}
+	if m := decodeV3(file); m != nil {
+		return m, nil
+	}
 	if m := decodeV2(file); m != nil {
 		return m, nil
 	}
@@ -170,16 +220,34 @@ func (m *freezerTableMeta) write(sync bool) error {
 		Tail    uint64
 		Offset  uint64
 	}
-	var o obj
-	o.Version = freezerVersion // forcibly use the current version
-	o.Tail = m.virtualTail
-	o.Offset = uint64(m.flushOffset)
-
+	type objV3 struct {
+		Version    uint16
+		Tail       uint64
+		Offset     uint64
+		Zstd       bool
+		Dictionary []byte
+	}
+	var enc interface{}
+	if m.zstd {
+		enc = &objV3{
+			Version:    freezerTableV3,
+			Tail:       m.virtualTail,
+			Offset:     uint64(m.flushOffset),
+			Zstd:       true,
+			Dictionary: m.dictionary,
+		}
+	} else {
+		enc = &obj{
+			Version: freezerVersion, // forcibly use the current version
+			Tail:    m.virtualTail,
+			Offset:  uint64(m.flushOffset),
+		}
+	}
 	_, err := m.file.Seek(0, io.SeekStart)
 	if err != nil {
 		return err
 	}
-	if err := rlp.Encode(m.file, &o); err != nil {
+	if err := rlp.Encode(m.file, enc); err != nil {
 		return err
 	}
 	if !sync {
@@ -17,6 +17,7 @@
 package rawdb
 
 import (
+	"bytes"
 	"os"
 	"testing"
 
@@ -124,3 +125,36 @@ func TestInvalidMetadata(t *testing.T) {
 		t.Fatal("Unexpected success")
 	}
 }
+
+func TestReadWriteZstdTableMeta(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "*")
+	if err != nil {
+		t.Fatalf("Failed to create file %v", err)
+	}
+	defer f.Close()
+
+	meta := &freezerTableMeta{
+		file:        f,
+		version:     freezerTableV3,
+		virtualTail: 100,
+		flushOffset: 600,
+		zstd:        true,
+		dictionary:  []byte{0x37, 0xa4, 0x30, 0xec, 0x01, 0x02},
+	}
+	if err := meta.write(false); err != nil {
+		t.Fatalf("Failed to write metadata %v", err)
+	}
+	meta, err = newMetadata(f)
+	if err != nil {
+		t.Fatalf("Failed to reload metadata %v", err)
+	}
+	if meta.version != freezerTableV3 || !meta.zstd {
+		t.Fatalf("Unexpected version field")
+	}
+	if meta.virtualTail != 100 || meta.flushOffset != 600 {
+		t.Fatalf("Unexpected tail or offset field")
+	}
+	if !bytes.Equal(meta.dictionary, []byte{0x37, 0xa4, 0x30, 0xec, 0x01, 0x02}) {
+		t.Fatalf("Unexpected dictionary %x", meta.dictionary)
+	}
+}
@@ -0,0 +1,387 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/ethdb/objstore"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// remoteCacheSegments is the number of offloaded data files kept in the local
+// cache of a single freezer table.
+const remoteCacheSegments = 4
+
+var (
+	remoteUploadMeter   = metrics.NewRegisteredMeter("ancient/remote/upload", nil)
+	remoteDownloadMeter = metrics.NewRegisteredMeter("ancient/remote/download", nil)
+	remoteCacheHitMeter = metrics.NewRegisteredMeter("ancient/remote/cachehit", nil)
+)
+
+// remoteSegments tracks the sealed data files of a freezer table which have
+// been offloaded to a remote object store. Offloaded files are downloaded on
+// demand into a local cache directory retaining the most recently used ones.
+//
+// Only sealed data files are ever offloaded; the head file is always local.
+type remoteSegments struct {
+	store    objstore.Store
+	prefix   string              // object key prefix of the table's data files
+	fileName func(uint32) string // data file name of the given file number
+	cacheDir string
+	readonly bool
+	logger   log.Logger
+
+	lock      sync.Mutex
+	offloaded map[uint32]struct{}
+	cache     lru.BasicLRU[uint32, *os.File]
+	fetching  map[uint32]chan struct{} // data files being downloaded into the cache
+
+	// The sealed data files waiting for upload. The generation is bumped
+	// whenever the table head is truncated, invalidating the pending uploads
+	// of files which might have been rewritten.
+	queue   []remoteUpload
+	gen     atomic.Uint64
+	running bool
+	closed  bool
+	uploads sync.WaitGroup
+}
+
+// remoteUpload is a data file scheduled for upload.
+type remoteUpload struct {
+	num uint32
+	gen uint64
+}
+
+// newRemoteSegments creates the tracker of offloaded data files and loads the
+// list of existing remote objects belonging to the table.
+func newRemoteSegments(store objstore.Store, datadir, name string, fileName func(uint32) string, readonly bool, logger log.Logger) (*remoteSegments, error) {
+	r := &remoteSegments{
+		store:     store,
+		prefix:    filepath.Base(datadir) + "/",
+		fileName:  fileName,
+		cacheDir:  filepath.Join(datadir, "remote-cache"),
+		readonly:  readonly,
+		logger:    logger,
+		offloaded: make(map[uint32]struct{}),
+		fetching:  make(map[uint32]chan struct{}),
+		cache:     lru.NewBasicLRU[uint32, *os.File](remoteCacheSegments),
+	}
+	if err := os.MkdirAll(r.cacheDir, 0755); err != nil {
+		return nil, err
+	}
+	keys, err := store.List(r.prefix + name + ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote data files: %v", err)
+	}
+	for _, key := range keys {
+		var num uint32
+		base := path.Base(key)
+		if _, err := fmt.Sscanf(strings.TrimPrefix(base, name+"."), "%d.", &num); err != nil || fileName(num) != base {
+			continue // object of another table sharing the prefix
+		}
+		r.offloaded[num] = struct{}{}
+	}
+	return r, nil
+}
+
+func (r *remoteSegments) key(num uint32) string {
+	return r.prefix + r.fileName(num)
+}
+
+// isOffloaded returns true if the given data file is stored remotely.
+func (r *remoteSegments) isOffloaded(num uint32) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	_, ok := r.offloaded[num]
+	return ok
+}
+
+// readAt reads from an offloaded data file, fetching it into the local cache
+// if necessary. Downloads happen outside the lock so that reads of cached files
+// are not blocked; concurrent reads of the same missing file share a single
+// download. It is called without holding the table lock.
+func (r *remoteSegments) readAt(num uint32, buf []byte, offset int64) error {
+	for {
+		r.lock.Lock()
+		if r.closed {
+			r.lock.Unlock()
+			return errClosed
+		}
+		if _, ok := r.offloaded[num]; !ok {
+			r.lock.Unlock()
+			return fmt.Errorf("missing data file %d", num)
+		}
+		if f, ok := r.cache.Get(num); ok {
+			remoteCacheHitMeter.Mark(1)
+			_, err := f.ReadAt(buf, offset)
+			r.lock.Unlock()
+			return err
+		}
+		if done, ok := r.fetching[num]; ok {
+			// Another reader is downloading the file, wait and retry
+			r.lock.Unlock()
+			<-done
+			continue
+		}
+		done := make(chan struct{})
+		r.fetching[num] = done
+		r.lock.Unlock()
+
+		cachePath := filepath.Join(r.cacheDir, r.fileName(num))
+		err := r.download(num, cachePath)
+
+		r.lock.Lock()
+		delete(r.fetching, num)
+		close(done)
+		if err == nil {
+			err = r.cacheFile(num, cachePath)
+		}
+		r.lock.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// cacheFile opens a downloaded data file and adds it to the cache, evicting
+// the least recently used files if necessary. The caller must hold the lock.
+func (r *remoteSegments) cacheFile(num uint32, cachePath string) error {
+	if r.closed {
+		os.Remove(cachePath)
+		return errClosed
+	}
+	if _, ok := r.offloaded[num]; !ok {
+		// The remote copy has been removed during the download
+		os.Remove(cachePath)
+		return fmt.Errorf("missing data file %d", num)
+	}
+	f, err := openFreezerFileForReadOnly(cachePath)
+	if err != nil {
+		return err
+	}
+	for r.cache.Len() >= remoteCacheSegments {
+		oldNum, old, _ := r.cache.RemoveOldest()
+		old.Close()
+		os.Remove(filepath.Join(r.cacheDir, r.fileName(oldNum)))
+	}
+	r.cache.Add(num, f)
+	return nil
+}
+
+// download writes the content of an offloaded data file to the given path.
+func (r *remoteSegments) download(num uint32, dest string) error {
+	src, err := r.store.Get(r.key(num))
+	if err != nil {
+		return fmt.Errorf("failed to fetch remote data file %d: %w", num, err)
+	}
+	defer src.Close()
+
+	f, err := os.CreateTemp(filepath.Dir(dest), "*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	n, err := io.Copy(f, src)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to download remote data file %d: %v", num, err)
+	}
+	remoteDownloadMeter.Mark(n)
+	return os.Rename(f.Name(), dest)
+}
+
+// upload stores the given local data file in the remote store.
+func (r *remoteSegments) upload(num uint32, src string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err := r.store.Put(r.key(num), f, stat.Size()); err != nil {
+		return err
+	}
+	remoteUploadMeter.Mark(stat.Size())
+
+	r.lock.Lock()
+	r.offloaded[num] = struct{}{}
+	r.lock.Unlock()
+	return nil
+}
+
+// restore moves an offloaded data file back to the given local path and
+// removes the remote copy. This is needed when the table is truncated back
+// into an offloaded file which becomes the writable head again.
+func (r *remoteSegments) restore(num uint32, dest string) error {
+	if r.readonly {
+		return errReadOnly
+	}
+	if err := r.download(num, dest); err != nil {
+		return err
+	}
+	return r.remove(num)
+}
+
+// remove deletes the remote copy of the given data file along with the
+// cached local copy.
+func (r *remoteSegments) remove(num uint32) error {
+	if r.readonly {
+		return errReadOnly
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if f, ok := r.cache.Peek(num); ok {
+		r.cache.Remove(num)
+		f.Close()
+		os.Remove(filepath.Join(r.cacheDir, r.fileName(num)))
+	}
+	if _, ok := r.offloaded[num]; !ok {
+		return nil
+	}
+	delete(r.offloaded, num)
+	return r.store.Delete(r.key(num))
+}
+
+// removeIf deletes all offloaded data files matching the given filter.
+func (r *remoteSegments) removeIf(filter func(num uint32) bool) {
+	if r.readonly {
+		return
+	}
+	r.lock.Lock()
+	var nums []uint32
+	for num := range r.offloaded {
+		if filter(num) {
+			nums = append(nums, num)
+		}
+	}
+	r.lock.Unlock()
+
+	for _, num := range nums {
+		if err := r.remove(num); err != nil {
+			r.logger.Warn("Failed to remove remote data file", "num", num, "err", err)
+		}
+	}
+}
+
+// close stops the background uploads and releases the cached files. Files
+// which have not been uploaded yet are kept locally and scheduled again when
+// the table is reopened.
+func (r *remoteSegments) close() {
+	r.lock.Lock()
+	r.closed = true
+	r.lock.Unlock()
+
+	r.uploads.Wait()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, num := range r.cache.Keys() {
+		f, _ := r.cache.Peek(num)
+		f.Close()
+	}
+	r.cache.Purge()
+}
+
+// offload schedules a sealed data file for upload. Uploads are processed one
+// by one in the background and the local copy is replaced by the remote one
+// once its upload is finished. The caller must hold the write lock or be in
+// an init-context.
+func (t *freezerTable) offload(num uint32) {
+	r := t.remote
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.queue = append(r.queue, remoteUpload{num: num, gen: r.gen.Load()})
+	if !r.running {
+		r.running = true
+		r.uploads.Add(1)
+		go t.offloadLoop()
+	}
+}
+
+// offloadLoop uploads the queued data files until the queue is empty or the
+// table is closed.
+func (t *freezerTable) offloadLoop() {
+	r := t.remote
+	defer r.uploads.Done()
+
+	for {
+		r.lock.Lock()
+		if len(r.queue) == 0 || r.closed {
+			r.running = false
+			r.lock.Unlock()
+			return
+		}
+		task := r.queue[0]
+		r.queue = r.queue[1:]
+		r.lock.Unlock()
+
+		// Skip the files which have been truncated since being scheduled,
+		// they will be scheduled again once sealed.
+		if task.gen != r.gen.Load() {
+			continue
+		}
+		local := filepath.Join(t.path, t.dataFileName(task.num))
+		if err := r.upload(task.num, local); err != nil {
+			t.logger.Warn("Failed to offload freezer data file", "num", task.num, "err", err)
+			continue
+		}
+		t.lock.Lock()
+		if task.gen == r.gen.Load() && task.num >= t.tailId && task.num < t.headId {
+			t.releaseFile(task.num)
+			if err := os.Remove(local); err != nil && !errors.Is(err, os.ErrNotExist) {
+				t.logger.Warn("Failed to remove offloaded freezer data file", "num", task.num, "err", err)
+			}
+			t.logger.Debug("Offloaded freezer data file", "num", task.num)
+		}
+		t.lock.Unlock()
+	}
+}
+
+// offloadSealed schedules all sealed data files which are still stored
+// locally for upload.
+func (t *freezerTable) offloadSealed() {
+	for num := t.tailId; num < t.headId; num++ {
+		if _, ok := t.files[num]; ok {
+			t.offload(num)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/objstore"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func newRemoteTestTable(t *testing.T, dir string, store objstore.Store, readonly bool) *freezerTable {
+	t.Helper()
+
+	rm, wm, sg := metrics.NewMeter(), metrics.NewMeter(), metrics.NewGauge()
+	f, err := newTable(dir, "test", rm, wm, sg, 50, freezerTableConfig{noSnappy: true, remote: store}, readonly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// waitOffloaded waits until the pending uploads of the table are finished.
+func waitOffloaded(f *freezerTable) {
+	f.remote.uploads.Wait()
+}
+
+func TestFreezerRemoteOffload(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	store, err := objstore.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	// Write 20 items of 15 bytes, three items per data file
+	f := newRemoteTestTable(t, dir, store, false)
+	writeChunks(t, f, 20, 15)
+	waitOffloaded(f)
+
+	localFile := func(num uint32) bool {
+		return common.FileExist(filepath.Join(dir, f.dataFileName(num)))
+	}
+	for num := uint32(0); num < f.headId; num++ {
+		require.Falsef(t, localFile(num), "sealed data file %d is stored locally", num)
+		require.Truef(t, f.remote.isOffloaded(num), "sealed data file %d is not offloaded", num)
+	}
+	require.True(t, localFile(f.headId), "head data file is not stored locally")
+	require.False(t, f.remote.isOffloaded(f.headId), "head data file is offloaded")
+
+	all := make(map[uint64][]byte)
+	for i := 0; i < 20; i++ {
+		all[uint64(i)] = getChunk(15, i)
+	}
+	checkRetrieve(t, f, all)
+
+	// Reading more offloaded files than the cache size should evict files
+	require.LessOrEqual(t, f.remote.cache.Len(), remoteCacheSegments)
+
+	// Truncate the head back into an offloaded file, it should be restored
+	require.NoError(t, f.truncateHead(7))
+	require.Equal(t, uint32(2), f.headId)
+	require.True(t, localFile(2))
+	require.False(t, f.remote.isOffloaded(2))
+	checkRetrieve(t, f, map[uint64][]byte{0: getChunk(15, 0), 6: getChunk(15, 6)})
+	checkRetrieveError(t, f, map[uint64]error{7: errOutOfBounds})
+
+	// Truncate the tail, the remote data files should be deleted
+	require.NoError(t, f.truncateTail(6))
+	keys, err := store.List("")
+	require.NoError(t, err)
+	require.Empty(t, keys)
+	require.NoError(t, f.Close())
+
+	// Refill the table and check the data after reopening it
+	f = newRemoteTestTable(t, dir, store, false)
+	batch := f.newBatch()
+	for i := 7; i < 20; i++ {
+		require.NoError(t, batch.AppendRaw(uint64(i), getChunk(15, i)))
+	}
+	require.NoError(t, batch.commit())
+	require.NoError(t, f.Close())
+
+	f = newRemoteTestTable(t, dir, store, true)
+	defer f.Close()
+	for i := 0; i < 6; i++ {
+		delete(all, uint64(i))
+	}
+	checkRetrieve(t, f, all)
+	checkRetrieveError(t, f, map[uint64]error{5: errOutOfBounds})
+}
+
+func TestFreezerRemoteOffloadOnOpen(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	store, err := objstore.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	// Write a regular local table first
+	rm, wm, sg := metrics.NewMeter(), metrics.NewMeter(), metrics.NewGauge()
+	f, err := newTable(dir, "test", rm, wm, sg, 50, freezerTableConfig{noSnappy: true}, false)
+	require.NoError(t, err)
+	writeChunks(t, f, 10, 15)
+	require.NoError(t, f.Close())
+
+	// Reopen it with a remote store, the existing sealed files should move
+	f = newRemoteTestTable(t, dir, store, false)
+	defer f.Close()
+	waitOffloaded(f)
+
+	keys, err := store.List("")
+	require.NoError(t, err)
+	require.Len(t, keys, int(f.headId))
+
+	items := make(map[uint64][]byte)
+	for i := 0; i < 10; i++ {
+		items[uint64(i)] = getChunk(15, i)
+	}
+	checkRetrieve(t, f, items)
+}
+
+func TestFreezerRemoteConcurrentRead(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	store, err := objstore.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	f := newRemoteTestTable(t, dir, store, false)
+	defer f.Close()
+	writeChunks(t, f, 30, 15)
+	waitOffloaded(f)
+
+	// Read all items from several goroutines, downloading and evicting the
+	// offloaded files concurrently
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 30; i++ {
+				item := (i + g*7) % 30
+				blob, err := f.Retrieve(uint64(item))
+				if err != nil {
+					t.Errorf("failed to retrieve item %d: %v", item, err)
+					return
+				}
+				if !bytes.Equal(blob, getChunk(15, item)) {
+					t.Errorf("item %d mismatch", item)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	require.Empty(t, f.remote.fetching)
+}
+
+// slowStore is a remote store whose downloads block until released.
+type slowStore struct {
+	objstore.Store
+	fetching chan struct{}
+	release  chan struct{}
+}
+
+func (s *slowStore) Get(key string) (io.ReadCloser, error) {
+	select {
+	case s.fetching <- struct{}{}:
+	default:
+	}
+	<-s.release
+	return s.Store.Get(key)
+}
+
+func TestFreezerRemoteSlowDownload(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files, err := objstore.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	store := &slowStore{Store: files, fetching: make(chan struct{}, 1), release: make(chan struct{})}
+
+	f := newRemoteTestTable(t, dir, store, false)
+	defer f.Close()
+	writeChunks(t, f, 10, 15)
+	waitOffloaded(f)
+
+	// Start reading an offloaded item, its download is held back
+	result := make(chan error, 1)
+	go func() {
+		blob, err := f.Retrieve(0)
+		if err == nil && !bytes.Equal(blob, getChunk(15, 0)) {
+			err = errors.New("item mismatch")
+		}
+		result <- err
+	}()
+	<-store.fetching
+
+	// Appending into a new data file must not wait for the download
+	appended := make(chan error, 1)
+	go func() {
+		batch := f.newBatch()
+		for i := 10; i < 16; i++ {
+			if err := batch.AppendRaw(uint64(i), getChunk(15, i)); err != nil {
+				appended <- err
+				return
+			}
+		}
+		appended <- batch.commit()
+	}()
+	select {
+	case err := <-appended:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		close(store.release)
+		t.Fatal("append blocked by remote download")
+	}
+	close(store.release)
+	require.NoError(t, <-result)
+	checkRetrieve(t, f, map[uint64][]byte{10: getChunk(15, 10), 15: getChunk(15, 15)})
+}
@@ -114,6 +114,8 @@ type freezerTable struct {
 
 	metadata *freezerTableMeta // metadata of the table
 	lastSync time.Time         // Timestamp when the last sync was performed
+	remote   *remoteSegments   // Sealed data files offloaded to a remote store, nil if disabled
+	zstd     *zstdCodec        // Codec of zstd compressed tables, nil if snappy or raw
 
 	headBytes  int64          // Number of bytes written to the head file
 	readMeter  *metrics.Meter // Meter for measuring the effective amount of data read
@@ -133,51 +135,55 @@ func newFreezerTable(path, name string, config freezerTableConfig, readonly bool
 // non-existent. Both files are truncated to the shortest common length to ensure
 // they don't go out of sync.
 func newTable(path string, name string, readMeter, writeMeter *metrics.Meter, sizeGauge *metrics.Gauge, maxFilesize uint32, config freezerTableConfig, readonly bool) (*freezerTable, error) {
-	// Ensure the containing directory exists and open the indexEntry file
+	// Ensure the containing directory exists and open the metadata file
 	if err := os.MkdirAll(path, 0755); err != nil {
 		return nil, err
 	}
-	var idxName string
-	if config.noSnappy {
-		idxName = fmt.Sprintf("%s.ridx", name) // raw index file
-	} else {
-		idxName = fmt.Sprintf("%s.cidx", name) // compressed index file
-	}
-	var (
-		err   error
-		index *os.File
-		meta  *os.File
-	)
+	opener := openFreezerFileForAppend
 	if readonly {
 		// Will fail if table index file or meta file is not existent
-		index, err = openFreezerFileForReadOnly(filepath.Join(path, idxName))
-		if err != nil {
-			return nil, err
-		}
-		meta, err = openFreezerFileForReadOnly(filepath.Join(path, fmt.Sprintf("%s.meta", name)))
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		index, err = openFreezerFileForAppend(filepath.Join(path, idxName))
-		if err != nil {
-			return nil, err
-		}
-		meta, err = openFreezerFileForAppend(filepath.Join(path, fmt.Sprintf("%s.meta", name)))
-		if err != nil {
-			return nil, err
-		}
+		opener = openFreezerFileForReadOnly
+	}
+	meta, err := opener(filepath.Join(path, fmt.Sprintf("%s.meta", name)))
+	if err != nil {
+		return nil, err
 	}
 	// Load metadata from the file. The tag will be true if legacy metadata
 	// is detected.
 	metadata, err := newMetadata(meta)
 	if err != nil {
+		meta.Close()
+		return nil, err
+	}
+	// Open the indexEntry file, the compression of the table is determined
+	// by the metadata.
+	var idxName string
+	switch {
+	case config.noSnappy:
+		idxName = fmt.Sprintf("%s.ridx", name) // raw index file
+	case metadata.zstd:
+		idxName = fmt.Sprintf("%s.zidx", name) // zstd compressed index file
+	default:
+		idxName = fmt.Sprintf("%s.cidx", name) // compressed index file
+	}
+	index, err := opener(filepath.Join(path, idxName))
+	if err != nil {
+		meta.Close()
 		return nil, err
 	}
+	var codec *zstdCodec
+	if metadata.zstd && !config.noSnappy {
+		if codec, err = openZstdCodec(metadata.dictionary); err != nil {
+			meta.Close()
+			index.Close()
+			return nil, err
+		}
+	}
 	// Create the table and repair any past inconsistency
 	tab := &freezerTable{
 		index:       index,
 		metadata:    metadata,
+		zstd:        codec,
 		lastSync:    time.Now(),
 		files:       make(map[uint32]*os.File),
 		readMeter:   readMeter,
@@ -190,6 +196,13 @@ func newTable(path string, name string, readMeter, writeMeter *metrics.Meter, si
 		readonly:    readonly,
 		maxFileSize: maxFilesize,
 	}
+	if config.remote != nil {
+		tab.remote, err = newRemoteSegments(config.remote, path, name, tab.dataFileName, readonly, tab.logger)
+		if err != nil {
+			tab.Close()
+			return nil, err
+		}
+	}
 	if err := tab.repair(); err != nil {
 		tab.Close()
 		return nil, err
@@ -202,6 +215,10 @@ func newTable(path string, name string, readMeter, writeMeter *metrics.Meter, si
 	}
 	tab.sizeGauge.Inc(int64(size))
 
+	// Offload the sealed data files left over from the previous run
+	if tab.remote != nil && !readonly {
+		tab.offloadSealed()
+	}
 	return tab, nil
 }
 
@@ -221,8 +238,9 @@ func (t *freezerTable) repair() error {
 			return err
 		}
 	}
-	// Ensure the index is a multiple of indexEntrySize bytes
-	if overflow := stat.Size() % indexEntrySize; overflow != 0 {
+	// Ensure the index is a multiple of indexEntrySize bytes. In secondary
+	// mode, the partially written entry of the primary is simply ignored.
+	if overflow := stat.Size() % indexEntrySize; overflow != 0 && !t.config.secondary {
 		if t.readonly {
 			return fmt.Errorf("index file(path: %s, name: %s) size is not a multiple of %d", t.path, t.name, indexEntrySize)
 		}
@@ -239,6 +257,9 @@ func (t *freezerTable) repair() error {
 		return err
 	}
 	offsetsSize := stat.Size()
+	if t.config.secondary {
+		offsetsSize -= offsetsSize % indexEntrySize
+	}
 
 	// Open the head file
 	var (
@@ -263,7 +284,10 @@ func (t *freezerTable) repair() error {
 	// Adjust the number of hidden items if it is less than the number of items
 	// being removed.
 	if t.itemOffset.Load() > t.metadata.virtualTail {
-		if err := t.metadata.setVirtualTail(t.itemOffset.Load(), true); err != nil {
+		if t.config.secondary {
+			// The metadata is updated by the primary
+			t.metadata.virtualTail = t.itemOffset.Load()
+		} else if err := t.metadata.setVirtualTail(t.itemOffset.Load(), true); err != nil {
 			return err
 		}
 	}
@@ -292,6 +316,35 @@ func (t *freezerTable) repair() error {
 	// Keep truncating both files until they come in sync
 	contentExp = int64(lastIndex.offset)
 	for contentExp != contentSize {
+		if t.config.secondary {
+			// Data beyond the last index entry belongs to an item being
+			// appended by the primary.
+			if contentExp < contentSize {
+				break
+			}
+			// Hide the index entries pointing beyond the stored data
+			offsetsSize -= indexEntrySize
+			var newLastIndex indexEntry
+			if offsetsSize == indexEntrySize {
+				newLastIndex = indexEntry{filenum: t.tailId, offset: 0}
+			} else {
+				t.index.ReadAt(buffer, offsetsSize-indexEntrySize)
+				newLastIndex.unmarshalBinary(buffer)
+			}
+			if newLastIndex.filenum != lastIndex.filenum {
+				t.releaseFile(lastIndex.filenum)
+				if t.head, err = t.openFile(newLastIndex.filenum, openFreezerFileForReadOnly); err != nil {
+					return err
+				}
+				if stat, err = t.head.Stat(); err != nil {
+					return err
+				}
+				contentSize = stat.Size()
+			}
+			lastIndex = newLastIndex
+			contentExp = int64(lastIndex.offset)
+			continue
+		}
 		if t.readonly {
 			return fmt.Errorf("freezer table(path: %s, name: %s, num: %d) is corrupted", t.path, t.name, lastIndex.filenum)
 		}
@@ -367,7 +420,7 @@ func (t *freezerTable) repair() error {
 	}
 	// Update the item and byte counters and return
 	t.items.Store(t.itemOffset.Load() + uint64(offsetsSize/indexEntrySize-1)) // last indexEntry points to the end of the data file
-	t.headBytes = contentSize
+	t.headBytes = contentExp
 	t.headId = lastIndex.filenum
 
 	// Delete the leftover files because of head deletion
@@ -389,6 +442,11 @@ func (t *freezerTable) repair() error {
 }
 
 func (t *freezerTable) repairIndex() error {
+	// The index of a secondary table is owned by the primary, the entries
+	// beyond the flush offset are in-flight writes rather than garbage.
+	if t.config.secondary {
+		return nil
+	}
 	stat, err := t.index.Stat()
 	if err != nil {
 		return err
@@ -583,6 +641,9 @@ func (t *freezerTable) preopen() (err error) {
 
 	// Open all except head in RDONLY
 	for i := t.tailId; i < t.headId; i++ {
+		if t.remote != nil && t.remote.isOffloaded(i) && !common.FileExist(filepath.Join(t.path, t.dataFileName(i))) {
+			continue // read from the remote store on demand
+		}
 		if _, err = t.openFile(i, openFreezerFileForReadOnly); err != nil {
 			return err
 		}
@@ -654,12 +715,22 @@ func (t *freezerTable) truncateHead(items uint64) error {
 	}
 	// We might need to truncate back to older files
 	if expected.filenum != t.headId {
+		// Abort the pending uploads, the files might be rewritten
+		if t.remote != nil {
+			t.remote.gen.Add(1)
+		}
 		// If already open for reading, force-reopen for writing
 		t.releaseFile(expected.filenum)
 		newHead, err := t.openFile(expected.filenum, openFreezerFileForAppend)
 		if err != nil {
 			return err
 		}
+		// Drop the remote copy of the new head, it is going to be modified
+		if t.remote != nil {
+			if err := t.remote.remove(expected.filenum); err != nil {
+				return err
+			}
+		}
 		// Release any files _after the current head -- both the previous head
 		// and any files which may have been opened for reading
 		t.releaseFilesAfter(expected.filenum, true)
@@ -839,6 +910,10 @@ func (t *freezerTable) truncateTail(items uint64) error {
 // This operation must be completed before shutdown to prevent the loss of
 // recent writes.
 func (t *freezerTable) Close() error {
+	// Stop the background uploads first, they need the lock to finish
+	if t.remote != nil {
+		t.remote.close()
+	}
 	t.lock.Lock()
 	defer t.lock.Unlock()
 
@@ -856,6 +931,9 @@ func (t *freezerTable) Close() error {
 	for _, f := range t.files {
 		doClose(f)
 	}
+	if t.zstd != nil {
+		t.zstd.close()
+	}
 	t.index = nil
 	t.head = nil
 	t.metadata.file = nil
@@ -866,17 +944,29 @@ func (t *freezerTable) Close() error {
 	return nil
 }
 
-// openFile assumes that the write-lock is held by the caller
+// dataFileName returns the name of the data file with the given number.
+func (t *freezerTable) dataFileName(num uint32) string {
+	if t.config.noSnappy {
+		return fmt.Sprintf("%s.%04d.rdat", t.name, num)
+	}
+	if t.zstd != nil {
+		return fmt.Sprintf("%s.%04d.zdat", t.name, num)
+	}
+	return fmt.Sprintf("%s.%04d.cdat", t.name, num)
+}
+
+// openFile assumes that the write-lock is held by the caller. Data files which
+// have been offloaded to the remote store are moved back to local disk.
 func (t *freezerTable) openFile(num uint32, opener func(string) (*os.File, error)) (f *os.File, err error) {
 	var exist bool
 	if f, exist = t.files[num]; !exist {
-		var name string
-		if t.config.noSnappy {
-			name = fmt.Sprintf("%s.%04d.rdat", t.name, num)
-		} else {
-			name = fmt.Sprintf("%s.%04d.cdat", t.name, num)
+		name := filepath.Join(t.path, t.dataFileName(num))
+		if t.remote != nil && t.remote.isOffloaded(num) && !common.FileExist(name) {
+			if err := t.remote.restore(num, name); err != nil {
+				return nil, err
+			}
 		}
-		f, err = opener(filepath.Join(t.path, name))
+		f, err = opener(name)
 		if err != nil {
 			return nil, err
 		}
@@ -905,6 +995,9 @@ func (t *freezerTable) releaseFilesAfter(num uint32, remove bool) {
 			}
 		}
 	}
+	if remove && t.remote != nil {
+		t.remote.removeIf(func(fnum uint32) bool { return fnum > num })
+	}
 }
 
 // releaseFilesBefore closes all open files with a lower number, and optionally also deletes the files
@@ -918,6 +1011,9 @@ func (t *freezerTable) releaseFilesBefore(num uint32, remove bool) {
 			}
 		}
 	}
+	if remove && t.remote != nil {
+		t.remote.removeIf(func(fnum uint32) bool { return fnum < num })
+	}
 }
 
 // getIndices returns the index entries for the given from-item, covering 'count' items.
@@ -986,6 +1082,18 @@ func (t *freezerTable) RetrieveItems(start, count, maxBytes uint64) ([][]byte, e
 	for i, diskSize := range sizes {
 		item := diskData[offset : offset+diskSize]
 		offset += diskSize
+		if t.zstd != nil {
+			data, err := t.zstd.decompress(item)
+			if err != nil {
+				return nil, err
+			}
+			if i > 0 && maxBytes != 0 && uint64(outputSize+len(data)) > maxBytes {
+				break
+			}
+			output = append(output, data)
+			outputSize += len(data)
+			continue
+		}
 		decompressedSize := diskSize
 		if !t.config.noSnappy {
 			decompressedSize, _ = snappy.DecodedLen(item)
@@ -1013,12 +1121,50 @@ func (t *freezerTable) RetrieveItems(start, count, maxBytes uint64) ([][]byte, e
 // data if maxBytes is 0. It returns the (potentially compressed) data, and
 // the sizes.
 func (t *freezerTable) retrieveItems(start, count, maxBytes uint64) ([]byte, []int, error) {
+	output, sizes, remoteReads, err := t.readItems(start, count, maxBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Offloaded data files might have to be downloaded first, which is done
+	// without holding the table lock to not stall the writers.
+	for _, read := range remoteReads {
+		buf := output[read.pos : read.pos+read.length]
+		if err := t.remote.readAt(read.filenum, buf, int64(read.start)); err != nil {
+			if !t.has(start) {
+				return nil, nil, errOutOfBounds // truncated meanwhile
+			}
+			t.lock.RLock()
+			_, restored := t.files[read.filenum]
+			t.lock.RUnlock()
+			if restored {
+				// The data file has been moved back to local disk meanwhile
+				return t.retrieveItems(start, count, maxBytes)
+			}
+			return nil, nil, fmt.Errorf("%w, fileid: %d, start: %d, length: %d", err, read.filenum, read.start, read.length)
+		}
+	}
+	return output, sizes, nil
+}
+
+// remoteRead is a read from an offloaded data file into the output buffer at
+// the given position.
+type remoteRead struct {
+	filenum uint32
+	start   uint32
+	length  int
+	pos     int
+}
+
+// readItems reads the requested items from the local data files. The reads
+// from offloaded data files are returned instead of being done, leaving their
+// part of the output buffer unfilled.
+func (t *freezerTable) readItems(start, count, maxBytes uint64) ([]byte, []int, []remoteRead, error) {
 	t.lock.RLock()
 	defer t.lock.RUnlock()
 
 	// Ensure the table and the item are accessible
 	if t.index == nil || t.head == nil || t.metadata.file == nil {
-		return nil, nil, errClosed
+		return nil, nil, nil, errClosed
 	}
 	var (
 		items  = t.items.Load()      // the total items(head + 1)
@@ -1027,7 +1173,7 @@ func (t *freezerTable) retrieveItems(start, count, maxBytes uint64) ([]byte, []i
 	// Ensure the start is written, not deleted from the tail, and that the
 	// caller actually wants something
 	if items <= start || hidden > start || count == 0 {
-		return nil, nil, errOutOfBounds
+		return nil, nil, nil, errOutOfBounds
 	}
 	if start+count > items {
 		count = items - start
@@ -1038,12 +1184,17 @@ func (t *freezerTable) retrieveItems(start, count, maxBytes uint64) ([]byte, []i
 	} else {
 		output = make([]byte, 0, 1024) // initial buffer cap
 	}
+	var remoteReads []remoteRead
 	// readData is a helper method to read a single data item from disk.
 	readData := func(fileId, start uint32, length int) error {
 		output = grow(output, length)
 		dataFile, exist := t.files[fileId]
 		if !exist {
-			return fmt.Errorf("missing data file %d", fileId)
+			if t.remote == nil {
+				return fmt.Errorf("missing data file %d", fileId)
+			}
+			remoteReads = append(remoteReads, remoteRead{fileId, start, length, len(output) - length})
+			return nil
 		}
 		if _, err := dataFile.ReadAt(output[len(output)-length:], int64(start)); err != nil {
 			return fmt.Errorf("%w, fileid: %d, start: %d, length: %d", err, fileId, start, length)
@@ -1053,7 +1204,7 @@ func (t *freezerTable) retrieveItems(start, count, maxBytes uint64) ([]byte, []i
 	// Read all the indexes in one go
 	indices, err := t.getIndices(start, count)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	var (
 		sizes      []int               // The sizes for each element
@@ -1072,7 +1223,7 @@ func (t *freezerTable) retrieveItems(start, count, maxBytes uint64) ([]byte, []i
 			// If we have unread data in the first file, we need to do that read now.
 			if unreadSize > 0 {
 				if err := readData(firstIndex.filenum, readStart, unreadSize); err != nil {
-					return nil, nil, err
+					return nil, nil, nil, err
 				}
 				unreadSize = 0
 			}
@@ -1083,7 +1234,7 @@ func (t *freezerTable) retrieveItems(start, count, maxBytes uint64) ([]byte, []i
 			// read this last item, but we need to do the deferred reads now.
 			if unreadSize > 0 {
 				if err := readData(secondIndex.filenum, readStart, unreadSize); err != nil {
-					return nil, nil, err
+					return nil, nil, nil, err
 				}
 			}
 			break
@@ -1095,7 +1246,7 @@ func (t *freezerTable) retrieveItems(start, count, maxBytes uint64) ([]byte, []i
 		if i == len(indices)-2 || (uint64(totalSize) > maxBytes && maxBytes != 0) {
 			// Last item, need to do the read now
 			if err := readData(secondIndex.filenum, readStart, unreadSize); err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 			break
 		}
@@ -1103,7 +1254,7 @@ func (t *freezerTable) retrieveItems(start, count, maxBytes uint64) ([]byte, []i
 
 	// Update metrics.
 	t.readMeter.Mark(int64(totalSize))
-	return output, sizes, nil
+	return output, sizes, remoteReads, nil
 }
 
 // has returns an indicator whether the specified number data is still accessible
@@ -1148,6 +1299,12 @@ func (t *freezerTable) advanceHead() error {
 	// We open the next file in truncated mode -- if this file already
 	// exists, we need to start over from scratch on it.
 	nextID := t.headId + 1
+	if t.remote != nil {
+		// Drop any stale remote copy left over from a head truncation
+		if err := t.remote.remove(nextID); err != nil {
+			return err
+		}
+	}
 	newHead, err := t.openFile(nextID, openFreezerFileTruncated)
 	if err != nil {
 		return err
@@ -1164,6 +1321,10 @@ func (t *freezerTable) advanceHead() error {
 	t.head = newHead
 	t.headBytes = 0
 	t.headId = nextID
+
+	if t.remote != nil {
+		t.offload(nextID - 1)
+	}
 	return nil
 }
 
@@ -333,6 +333,60 @@ func TestFreezerConcurrentReadonly(t *testing.T) {
 	}
 }
 
+func TestFreezerSecondary(t *testing.T) {
+	t.Parallel()
+
+	tables := map[string]freezerTableConfig{"a": {noSnappy: true}, "b": {noSnappy: true}}
+	dir := t.TempDir()
+
+	// Open the primary and keep it open, with unsynced writes
+	f, err := NewFreezer(dir, "", false, 2049, tables)
+	if err != nil {
+		t.Fatal("can't open freezer", err)
+	}
+	defer f.Close()
+
+	var item = make([]byte, 1024)
+	_, err = f.ModifyAncients(func(op ethdb.AncientWriteOp) error {
+		for i := uint64(0); i < 5; i++ {
+			require.NoError(t, op.AppendRaw("a", i, item))
+			require.NoError(t, op.AppendRaw("b", i, item))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Simulate an in-flight append: table a has an extra item, table b has
+	// dangling data and a partially written index entry.
+	aBatch := f.tables["a"].newBatch()
+	require.NoError(t, aBatch.AppendRaw(5, item))
+	require.NoError(t, aBatch.commit())
+	_, err = f.tables["b"].head.Write(item)
+	require.NoError(t, err)
+	_, err = f.tables["b"].index.Write([]byte{0, 0, 0})
+	require.NoError(t, err)
+
+	// The regular readonly mode is blocked by the lock of the primary
+	if _, err := NewFreezer(dir, "", true, 2049, tables); err == nil {
+		t.Fatal("readonly freezer opened while locked by the primary")
+	}
+	sf, err := newFreezer(dir, "", true, true, 2049, tables)
+	if err != nil {
+		t.Fatal("can't open secondary freezer", err)
+	}
+	defer sf.Close()
+
+	checkAncientCount(t, sf, "b", 5)
+	for i := uint64(0); i < 5; i++ {
+		blob, err := sf.Ancient("a", i)
+		require.NoError(t, err)
+		require.Equal(t, item, blob)
+	}
+	if _, err := sf.ModifyAncients(func(op ethdb.AncientWriteOp) error { return nil }); err == nil {
+		t.Fatal("secondary freezer accepted writes")
+	}
+}
+
 func newFreezerForTesting(t *testing.T, tables map[string]freezerTableConfig) (*Freezer, string) {
 	t.Helper()
 
@@ -0,0 +1,359 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/objstore"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/dict"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// zstdDictionarySize is the maximum size of the dictionary trained for a
+	// zstd compressed freezer table.
+	zstdDictionarySize = 112 * 1024
+
+	// zstdDictionarySamples is the number of items sampled for training the
+	// dictionary of a zstd compressed freezer table.
+	zstdDictionarySamples = 4096
+
+	// zstdSavingsSamples is the number of items sampled for estimating the
+	// space saved by a zstd compressed freezer table.
+	zstdSavingsSamples = 1024
+)
+
+// zstdCodec compresses and decompresses the items of a freezer table with
+// zstd, optionally using a trained dictionary. It is safe for concurrent use.
+type zstdCodec struct {
+	enc *zstd.Encoder
+	dec *zstd.Decoder
+}
+
+// newZstdCodec creates a codec using the given dictionary, which may be empty.
+func newZstdCodec(dictionary []byte) (*zstdCodec, error) {
+	var (
+		eopts = []zstd.EOption{zstd.WithEncoderConcurrency(1)}
+		dopts = []zstd.DOption{zstd.WithDecoderConcurrency(0)}
+	)
+	if len(dictionary) > 0 {
+		eopts = append(eopts, zstd.WithEncoderDict(dictionary))
+		dopts = append(dopts, zstd.WithDecoderDicts(dictionary))
+	}
+	enc, err := zstd.NewWriter(nil, eopts...)
+	if err != nil {
+		return nil, err
+	}
+	dec, err := zstd.NewReader(nil, dopts...)
+	if err != nil {
+		enc.Close()
+		return nil, err
+	}
+	return &zstdCodec{enc: enc, dec: dec}, nil
+}
+
+// openZstdCodec creates the codec of a zstd compressed table, validating the
+// dictionary loaded from the table metadata.
+func openZstdCodec(dictionary []byte) (*zstdCodec, error) {
+	if len(dictionary) > 0 {
+		if _, err := zstd.InspectDictionary(dictionary); err != nil {
+			return nil, fmt.Errorf("invalid zstd dictionary: %v", err)
+		}
+	}
+	return newZstdCodec(dictionary)
+}
+
+// compress appends the compressed data to dst.
+func (c *zstdCodec) compress(dst []byte, data []byte) []byte {
+	return c.enc.EncodeAll(data, dst)
+}
+
+// decompress returns the decompressed data.
+func (c *zstdCodec) decompress(data []byte) ([]byte, error) {
+	return c.dec.DecodeAll(data, nil)
+}
+
+// close releases the resources held by the codec.
+func (c *zstdCodec) close() {
+	c.enc.Close()
+	c.dec.Close()
+}
+
+// trainZstdDictionary trains a zstd dictionary on the given sample items. The
+// id is derived from the samples and embedded into every frame, ensuring that
+// items are never decompressed with a different dictionary. Nil is returned if
+// the samples are not suitable for training, in which case the table should
+// be compressed without a dictionary.
+func trainZstdDictionary(samples [][]byte, size int) []byte {
+	// The ids below 2^15 are reserved by the zstd format, the ones above 2^31
+	// are reserved as well.
+	crc := crc32.NewIEEE()
+	for _, sample := range samples {
+		crc.Write(sample)
+	}
+	id := 1<<15 + crc.Sum32()%(1<<31-1<<15)
+
+	dictionary, err := dict.BuildZstdDict(samples, dict.Options{
+		MaxDictSize: size,
+		HashBytes:   6,
+		ZstdDictID:  id,
+	})
+	if err != nil {
+		log.Warn("Failed to train zstd dictionary", "err", err)
+		return nil
+	}
+	return dictionary
+}
+
+// sampleItems retrieves up to n items evenly spread over the visible range
+// of the table.
+func (t *freezerTable) sampleItems(n uint64) ([][]byte, error) {
+	var (
+		first = t.itemHidden.Load()
+		items = t.items.Load()
+	)
+	if first >= items {
+		return nil, nil
+	}
+	step := (items - first + n - 1) / n
+	var samples [][]byte
+	for i := first; i < items; i += step {
+		blob, err := t.Retrieve(i)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, blob)
+	}
+	return samples, nil
+}
+
+// zstdSavings estimates the disk space saved by zstd compression compared to
+// storing the same items snappy compressed. The estimation is extrapolated
+// from the items sampled evenly over the table.
+func (t *freezerTable) zstdSavings() (common.StorageSize, error) {
+	if t.zstd == nil {
+		return 0, errors.New("table is not zstd compressed")
+	}
+	var (
+		first = t.itemHidden.Load()
+		items = t.items.Load()
+	)
+	if first >= items {
+		return 0, nil
+	}
+	step := (items - first + zstdSavingsSamples - 1) / zstdSavingsSamples
+
+	var stored, snapped, sampled uint64
+	for i := first; i < items; i += step {
+		raw, sizes, err := t.retrieveItems(i, 1, 0)
+		if err != nil {
+			return 0, err
+		}
+		blob, err := t.zstd.decompress(raw[:sizes[0]])
+		if err != nil {
+			return 0, err
+		}
+		stored += uint64(sizes[0])
+		snapped += uint64(len(snappy.Encode(nil, blob)))
+		sampled++
+	}
+	saved := (float64(snapped) - float64(stored)) * float64(items-first) / float64(sampled)
+	return common.StorageSize(saved), nil
+}
+
+// recompressTable converts a snappy compressed freezer table into a zstd
+// compressed one, using a dictionary trained from the items of the table. The
+// new table is assembled in a temporary directory and committed by replacing
+// the metadata file, after which the leftover snappy files are deleted. The
+// old and new sizes of the table are returned.
+//
+// Items hidden by a tail truncation are dropped during the conversion. If the
+// table offloads its sealed data files to a remote store, the snappy files are
+// read from and deleted in the remote store; the new zstd files are written
+// locally and offloaded again the next time the table is opened.
+func recompressTable(path, name string, config freezerTableConfig) (uint64, uint64, error) {
+	if config.noSnappy {
+		return 0, 0, fmt.Errorf("table %s is not compressed", name)
+	}
+	src, err := newFreezerTable(path, name, config, true)
+	if err != nil {
+		return 0, 0, err
+	}
+	if src.zstd != nil {
+		// The table has already been converted, only clean up the files
+		// left over from an interrupted run.
+		src.Close()
+		return 0, 0, removeSnappyFiles(path, name, config.remote)
+	}
+	defer src.Close()
+
+	oldSize, err := src.size()
+	if err != nil {
+		return 0, 0, err
+	}
+	var (
+		first = src.itemHidden.Load()
+		items = src.items.Load()
+	)
+	// Train the dictionary of the table
+	samples, err := src.sampleItems(zstdDictionarySamples)
+	if err != nil {
+		return 0, 0, err
+	}
+	dictionary := trainZstdDictionary(samples, zstdDictionarySize)
+
+	// Initialize the new table starting at the first visible item
+	tmp := filepath.Join(path, name+".recompress")
+	if err := os.RemoveAll(tmp); err != nil {
+		return 0, 0, err
+	}
+	if err := os.MkdirAll(tmp, 0755); err != nil {
+		return 0, 0, err
+	}
+	meta, err := openFreezerFileForAppend(filepath.Join(tmp, fmt.Sprintf("%s.meta", name)))
+	if err != nil {
+		return 0, 0, err
+	}
+	m := &freezerTableMeta{
+		file:        meta,
+		version:     freezerTableV3,
+		virtualTail: first,
+		flushOffset: indexEntrySize,
+		zstd:        true,
+		dictionary:  dictionary,
+	}
+	err = m.write(true)
+	meta.Close()
+	if err != nil {
+		return 0, 0, err
+	}
+	tail := indexEntry{filenum: 0, offset: uint32(first)}
+	if err := os.WriteFile(filepath.Join(tmp, fmt.Sprintf("%s.zidx", name)), tail.append(nil), 0644); err != nil {
+		return 0, 0, err
+	}
+	dstConfig := config
+	dstConfig.remote = nil
+	dst, err := newTable(tmp, name, metrics.NewInactiveMeter(), metrics.NewInactiveMeter(), metrics.NewGauge(), src.maxFileSize, dstConfig, false)
+	if err != nil {
+		return 0, 0, err
+	}
+	// Copy all the visible items into the new table
+	var (
+		start  = time.Now()
+		logged = time.Now()
+		batch  = dst.newBatch()
+	)
+	for next := first; next < items; {
+		blobs, err := src.RetrieveItems(next, items-next, freezerBatchBufferLimit)
+		if err != nil {
+			dst.Close()
+			return 0, 0, err
+		}
+		for _, blob := range blobs {
+			if err := batch.AppendRaw(next, blob); err != nil {
+				dst.Close()
+				return 0, 0, err
+			}
+			next++
+		}
+		if err := batch.commit(); err != nil {
+			dst.Close()
+			return 0, 0, err
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Recompressing freezer table", "table", name, "items", next-first, "total", items-first, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	newSize, err := dst.size()
+	if err == nil {
+		err = dst.Sync()
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	src.Close()
+
+	// Move the new table into place. The data and index files don't clash
+	// with the snappy ones, the replacement of the metadata commits the new
+	// table.
+	entries, err := os.ReadDir(tmp)
+	if err != nil {
+		return 0, 0, err
+	}
+	metaName := fmt.Sprintf("%s.meta", name)
+	for _, entry := range entries {
+		if entry.Name() == metaName {
+			continue
+		}
+		if err := os.Rename(filepath.Join(tmp, entry.Name()), filepath.Join(path, entry.Name())); err != nil {
+			return 0, 0, err
+		}
+	}
+	if err := os.Rename(filepath.Join(tmp, metaName), filepath.Join(path, metaName)); err != nil {
+		return 0, 0, err
+	}
+	if err := os.RemoveAll(tmp); err != nil {
+		return 0, 0, err
+	}
+	return oldSize, newSize, removeSnappyFiles(path, name, config.remote)
+}
+
+// removeSnappyFiles deletes the index and data files of the snappy compressed
+// variant of the given table, including the data files offloaded to the remote
+// store and their cached copies.
+func removeSnappyFiles(path, name string, remote objstore.Store) error {
+	files, err := filepath.Glob(filepath.Join(path, fmt.Sprintf("%s.*.cdat", name)))
+	if err != nil {
+		return err
+	}
+	cached, err := filepath.Glob(filepath.Join(path, "remote-cache", fmt.Sprintf("%s.*.cdat", name)))
+	if err != nil {
+		return err
+	}
+	files = append(files, cached...)
+	files = append(files, filepath.Join(path, fmt.Sprintf("%s.cidx", name)))
+	for _, file := range files {
+		if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if remote == nil {
+		return nil
+	}
+	// Reuse the remote tracker of the table for listing the snappy files
+	snappyName := func(num uint32) string { return fmt.Sprintf("%s.%04d.cdat", name, num) }
+	r, err := newRemoteSegments(remote, path, name, snappyName, false, log.New("database", path, "table", name))
+	if err != nil {
+		return err
+	}
+	r.removeIf(func(uint32) bool { return true })
+	return nil
+}
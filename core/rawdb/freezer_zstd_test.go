@@ -0,0 +1,169 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/objstore"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+// receiptLikeItem generates an item sharing a lot of structure with the other
+// items, but not repetitive enough to be compressed well on its own.
+func receiptLikeItem(i int) []byte {
+	var item []byte
+	for j := 0; j < 4; j++ {
+		item = append(item, []byte("logs:Transfer(address,address,uint256)")...)
+		item = append(item, crypto.Keccak256([]byte(fmt.Sprintf("topic-%d", j)))...)
+		item = binary.BigEndian.AppendUint64(item, uint64(i*4+j))
+		item = append(item, make([]byte, 24)...)
+	}
+	return item
+}
+
+func TestFreezerRecompress(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dir    = t.TempDir()
+		config = freezerTableConfig{prunable: true}
+		items  = 500
+	)
+	rm, wm, sg := metrics.NewMeter(), metrics.NewMeter(), metrics.NewGauge()
+	f, err := newTable(dir, "test", rm, wm, sg, 8*1024, config, false)
+	require.NoError(t, err)
+	batch := f.newBatch()
+	for i := 0; i < items; i++ {
+		require.NoError(t, batch.AppendRaw(uint64(i), receiptLikeItem(i)))
+	}
+	require.NoError(t, batch.commit())
+	require.NoError(t, f.truncateTail(50))
+	require.NoError(t, f.Close())
+
+	// Convert the table and check the space usage
+	oldSize, newSize, err := recompressTable(dir, "test", config)
+	require.NoError(t, err)
+	require.Less(t, newSize, oldSize)
+
+	files, err := filepath.Glob(filepath.Join(dir, "test.*"))
+	require.NoError(t, err)
+	for _, file := range files {
+		require.NotContains(t, []string{".cdat", ".cidx"}, filepath.Ext(file), "leftover snappy file %s", file)
+	}
+	require.False(t, common.FileExist(filepath.Join(dir, "test.recompress")))
+
+	// Reopen the converted table and check the content
+	f, err = newTable(dir, "test", rm, wm, sg, 8*1024, config, false)
+	require.NoError(t, err)
+	require.NotNil(t, f.zstd)
+	require.NotEmpty(t, f.metadata.dictionary)
+
+	want := make(map[uint64][]byte)
+	for i := 50; i < items; i++ {
+		want[uint64(i)] = receiptLikeItem(i)
+	}
+	checkRetrieve(t, f, want)
+	checkRetrieveError(t, f, map[uint64]error{49: errOutOfBounds, uint64(items): errOutOfBounds})
+
+	saved, err := f.zstdSavings()
+	require.NoError(t, err)
+	require.Positive(t, saved)
+
+	// Check that the table remains writable
+	require.NoError(t, f.truncateHead(uint64(items-10)))
+	batch = f.newBatch()
+	for i := items - 10; i < items+10; i++ {
+		require.NoError(t, batch.AppendRaw(uint64(i), receiptLikeItem(i)))
+		want[uint64(i)] = receiptLikeItem(i)
+	}
+	require.NoError(t, batch.commit())
+	require.NoError(t, f.Close())
+
+	// Recompressing again should be a noop
+	oldSize, newSize, err = recompressTable(dir, "test", config)
+	require.NoError(t, err)
+	require.Zero(t, oldSize+newSize)
+
+	f, err = newTable(dir, "test", rm, wm, sg, 8*1024, config, true)
+	require.NoError(t, err)
+	defer f.Close()
+	checkRetrieve(t, f, want)
+}
+
+func TestFreezerRecompressRemote(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dir   = t.TempDir()
+		items = 200
+	)
+	store, err := objstore.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	config := freezerTableConfig{remote: store}
+
+	rm, wm, sg := metrics.NewMeter(), metrics.NewMeter(), metrics.NewGauge()
+	f, err := newTable(dir, "test", rm, wm, sg, 8*1024, config, false)
+	require.NoError(t, err)
+	batch := f.newBatch()
+	for i := 0; i < items; i++ {
+		require.NoError(t, batch.AppendRaw(uint64(i), receiptLikeItem(i)))
+	}
+	require.NoError(t, batch.commit())
+	waitOffloaded(f)
+	require.NoError(t, f.Close())
+
+	// Convert the table reading the offloaded snappy files
+	_, _, err = recompressTable(dir, "test", config)
+	require.NoError(t, err)
+	keys, err := store.List("")
+	require.NoError(t, err)
+	require.Empty(t, keys, "leftover remote snappy files")
+
+	// Reopen the table and fill it further, the sealed zstd files should be
+	// offloaded
+	f, err = newTable(dir, "test", rm, wm, sg, 256, config, false)
+	require.NoError(t, err)
+	batch = f.newBatch()
+	for i := items; i < 2*items; i++ {
+		require.NoError(t, batch.AppendRaw(uint64(i), receiptLikeItem(i)))
+	}
+	require.NoError(t, batch.commit())
+	waitOffloaded(f)
+	keys, err = store.List("")
+	require.NoError(t, err)
+	require.NotZero(t, f.headId)
+	require.Len(t, keys, int(f.headId))
+
+	want := make(map[uint64][]byte)
+	for i := 0; i < 2*items; i++ {
+		want[uint64(i)] = receiptLikeItem(i)
+	}
+	checkRetrieve(t, f, want)
+	require.NoError(t, f.Close())
+
+	// The savings of the offloaded table should be estimated from the remote files
+	saved, err := inspectSavings(dir, "test", config)
+	require.NoError(t, err)
+	require.Positive(t, saved)
+}
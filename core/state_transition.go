@@ -374,7 +374,9 @@ type stateTransition struct {
 
 // newStateTransition initialises and returns a new state transition object.
 func newStateTransition(evm *vm.EVM, msg *Message, gp *GasPool) *stateTransition {
-	if ReadyEVMForL2 != nil {
+	if evm.Context.L2Hooks != nil {
+		evm.Context.L2Hooks.ReadyEVMForL2(evm, msg)
+	} else if ReadyEVMForL2 != nil {
 		ReadyEVMForL2(evm, msg)
 	}
 
@@ -69,8 +69,10 @@ type BlockContext struct {
 	Random      *common.Hash   // Provides information for PREVRANDAO
 
 	// Arbitrum information
-	ArbOSVersion   uint64
-	BaseFeeInBlock *big.Int // Copy of BaseFee to be used in arbitrum's geth hooks and precompiles when BaseFee is lowered to 0 when vm runs with NoBaseFee flag and 0 gas price. Is nil when BaseFee isn't lowered to 0
+	ArbOSVersion          uint64
+	L1BlockNumberOverride *uint64  // Replaces the L1 block number reported by the processing hook if set (RPC block overrides)
+	BaseFeeInBlock        *big.Int // Copy of BaseFee to be used in arbitrum's geth hooks and precompiles when BaseFee is lowered to 0 when vm runs with NoBaseFee flag and 0 gas price. Is nil when BaseFee isn't lowered to 0
+	L2Hooks               L2Hooks  // Transaction processing hooks of the chain replacing the process wide ones if set
 }
 
 // TxContext provides the EVM with information about a transaction.
@@ -37,6 +37,15 @@ func (evm *EVM) DecrementDepth() {
 	evm.depth -= 1
 }
 
+// l1BlockNumber returns the L1 block number visible to the NUMBER and BLOCKHASH
+// opcodes, preferring an override in the block context over the processing hook.
+func (evm *EVM) l1BlockNumber() (uint64, error) {
+	if evm.Context.L1BlockNumberOverride != nil {
+		return *evm.Context.L1BlockNumberOverride, nil
+	}
+	return evm.ProcessingHook.L1BlockNumber(evm.Context)
+}
+
 type TxProcessingHook interface {
 	StartTxHook() (bool, uint64, error, []byte) // return 4-tuple rather than *struct to avoid an import cycle
 	GasChargingHook(gasRemaining *uint64) (common.Address, error)
@@ -56,6 +65,19 @@ type TxProcessingHook interface {
 	IsCalldataPricingIncreaseEnabled() bool
 }
 
+// L2Message is the message of a transaction about to be executed by an EVM,
+// implemented by core.Message.
+type L2Message interface {
+	L2Message()
+}
+
+// L2Hooks installs the transaction processing hooks of a chain on the EVMs
+// executing its blocks, replacing the process wide ones. Consensus engines
+// provide them by implementing core.L2Hooks.
+type L2Hooks interface {
+	ReadyEVMForL2(evm *EVM, msg L2Message)
+}
+
 type DefaultTxProcessor struct {
 	evm *EVM
 }
@@ -441,7 +441,7 @@ func opBlockhash(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) (
 		return nil, nil
 	}
 
-	upper, err := interpreter.evm.ProcessingHook.L1BlockNumber(interpreter.evm.Context)
+	upper, err := interpreter.evm.l1BlockNumber()
 	if err != nil {
 		return nil, err
 	}
@@ -480,7 +480,7 @@ func opTimestamp(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) (
 }
 
 func opNumber(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
-	bnum, err := interpreter.evm.ProcessingHook.L1BlockNumber(interpreter.evm.Context)
+	bnum, err := interpreter.evm.l1BlockNumber()
 	if err != nil {
 		return nil, err
 	}
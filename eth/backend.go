@@ -46,6 +46,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/replica"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/shutdowncheck"
@@ -87,6 +88,12 @@ type Ethereum struct {
 
 	filterMaps      *filtermaps.FilterMaps
 	closeFilterMaps chan chan struct{}
+	logIndexDb      ethdb.Database // Local log index database in replica mode, nil otherwise
+
+	replica      bool                      // Set if the chain database follows a primary node
+	replicaCh    chan replica.CatchUpEvent // Notifies about the replica catching up with the primary
+	replicaSub   event.Subscription        // Subscription for the replica catch-up events
+	closeReplica chan chan struct{}        // Terminates the loop following the primary
 
 	APIBackend *EthAPIBackend
 
@@ -149,7 +156,11 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if err != nil {
 		return nil, err
 	}
-	engine, err := ethconfig.CreateConsensusEngine(chainConfig, chainDb)
+	createEngine := ethconfig.CreateConsensusEngine
+	if config.ConsensusEngine != nil {
+		createEngine = config.ConsensusEngine
+	}
+	engine, err := createEngine(chainConfig, chainDb)
 	if err != nil {
 		return nil, err
 	}
@@ -171,6 +182,8 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		p2pServer:       stack.Server(),
 		discmix:         enode.NewFairMix(0),
 		shutdownTracker: shutdowncheck.NewShutdownTracker(chainDb),
+		replica:         stack.Config().ReplicaOf != "",
+		closeReplica:    make(chan chan struct{}),
 	}
 	bcVersion := rawdb.ReadDatabaseVersion(chainDb)
 	var dbVer = "<nil>"
@@ -223,6 +236,19 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		}
 		vmConfig.Tracer = t
 	}
+	// A replica only serves the data written by its primary. The snapshot tree
+	// and the transaction indexer would have to be maintained in sync with the
+	// primary, so they are disabled and the primary's indices are used instead.
+	// The log index is rendered locally into a separate database, as the
+	// replica can not write into the primary's one.
+	txIndexerConfig := &config.TransactionHistory
+	if eth.replica {
+		log.Info("Serving chain data of primary node in replica mode")
+		eth.replicaCh = make(chan replica.CatchUpEvent, 1)
+		eth.replicaSub = stack.SubscribeReplicaCatchUp(eth.replicaCh)
+		cacheConfig.SnapshotLimit = 0
+		txIndexerConfig = nil
+	}
 	// Override the chain config with provided settings.
 	var overrides core.ChainOverrides
 	if config.OverridePrague != nil {
@@ -231,7 +257,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if config.OverrideVerkle != nil {
 		overrides.OverrideVerkle = config.OverrideVerkle
 	}
-	eth.blockchain, err = core.NewBlockChain(chainDb, cacheConfig, nil, config.Genesis, &overrides, eth.engine, vmConfig, &config.TransactionHistory)
+	eth.blockchain, err = core.NewBlockChain(chainDb, cacheConfig, nil, config.Genesis, &overrides, eth.engine, vmConfig, txIndexerConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -241,15 +267,24 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		History:        config.LogHistory,
 		Disabled:       config.LogNoHistory,
 		ExportFileName: config.LogExportCheckpoints,
+		CheckpointFile: config.LogCheckpoints,
 		HashScheme:     scheme == rawdb.HashScheme,
 	}
+	logIndexDb := ethdb.KeyValueStore(chainDb)
+	if eth.replica {
+		if eth.logIndexDb, err = stack.OpenDatabase("logindex", 0, 0, "eth/db/logindex/", false); err != nil {
+			return nil, err
+		}
+		logIndexDb = eth.logIndexDb
+		fmConfig.HashScheme = false // no trie nodes in the dedicated database
+	}
 	chainView := eth.newChainView(eth.blockchain.CurrentBlock())
 	historyCutoff, _ := eth.blockchain.HistoryPruningCutoff()
 	var finalBlock uint64
 	if fb := eth.blockchain.CurrentFinalBlock(); fb != nil {
 		finalBlock = fb.Number.Uint64()
 	}
-	eth.filterMaps = filtermaps.NewFilterMaps(chainDb, chainView, historyCutoff, finalBlock, filtermaps.DefaultParams, fmConfig)
+	eth.filterMaps = filtermaps.NewFilterMaps(logIndexDb, chainView, historyCutoff, finalBlock, filtermaps.DefaultParams, fmConfig)
 	eth.closeFilterMaps = make(chan chan struct{})
 
 	// TxPool
@@ -311,6 +346,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 
 	// Register the backend on the node
 	stack.RegisterAPIs(eth.APIs())
+	stack.SetResponseCachePolicy(ethapi.NewCachePolicy(eth.APIBackend))
 	stack.RegisterProtocols(eth.Protocols())
 	stack.RegisterLifecycle(eth)
 
@@ -412,9 +448,31 @@ func (s *Ethereum) Start() error {
 	// start log indexer
 	s.filterMaps.Start()
 	go s.updateFilterMapsHeads()
+
+	if s.replica {
+		go s.followPrimary()
+	}
 	return nil
 }
 
+// followPrimary adopts the chain head of the primary node whenever the replica
+// database caught up with it.
+func (s *Ethereum) followPrimary() {
+	defer s.replicaSub.Unsubscribe()
+
+	for {
+		select {
+		case <-s.replicaCh:
+			if err := s.blockchain.ReloadHead(); err != nil {
+				log.Warn("Failed to reload chain head of primary", "err", err)
+			}
+		case ch := <-s.closeReplica:
+			close(ch)
+			return
+		}
+	}
+}
+
 func (s *Ethereum) newChainView(head *types.Header) *filtermaps.ChainView {
 	if head == nil {
 		return nil
@@ -517,7 +575,15 @@ func (s *Ethereum) Stop() error {
 	ch := make(chan struct{})
 	s.closeFilterMaps <- ch
 	<-ch
+	if s.replica {
+		ch := make(chan struct{})
+		s.closeReplica <- ch
+		<-ch
+	}
 	s.filterMaps.Stop()
+	if s.logIndexDb != nil {
+		s.logIndexDb.Close()
+	}
 	s.txPool.Close()
 	s.blockchain.Stop()
 	s.engine.Close()
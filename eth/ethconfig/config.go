@@ -103,6 +103,7 @@ type Config struct {
 	LogHistory           uint64 `toml:",omitempty"` // The maximum number of blocks from head where a log search index is maintained.
 	LogNoHistory         bool   `toml:",omitempty"` // No log search index is maintained.
 	LogExportCheckpoints string // export log index checkpoints to file
+	LogCheckpoints       string // import trusted log index checkpoints from file
 	StateHistory         uint64 `toml:",omitempty"` // The maximum number of blocks from head whose state histories are reserved.
 
 	// State scheme represents the scheme used to store ethereum states and trie
@@ -162,6 +163,10 @@ type Config struct {
 
 	// OverrideVerkle (TODO: remove after the fork)
 	OverrideVerkle *uint64 `toml:",omitempty"`
+
+	// ConsensusEngine creates the consensus engine of the chain instead of
+	// CreateConsensusEngine if set.
+	ConsensusEngine func(config *params.ChainConfig, db ethdb.Database) (consensus.Engine, error) `toml:"-"`
 }
 
 // CreateConsensusEngine creates a consensus engine for the given chain config.
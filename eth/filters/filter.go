@@ -54,6 +54,16 @@ func (sys *FilterSystem) NewRangeFilter(begin, end int64, addresses []common.Add
 	return filter
 }
 
+// CheckPruned returns a PrunedHistoryError if a range filter starting at the
+// given block would search pruned history.
+func (sys *FilterSystem) CheckPruned(begin int64) error {
+	// Block numbers below 0 are special cases.
+	if begin > 0 && begin < int64(sys.backend.HistoryPruningCutoff()) {
+		return &history.PrunedHistoryError{}
+	}
+	return nil
+}
+
 // NewBlockFilter creates a new filter which directly inspects the contents of
 // a block to figure out whether it is interesting or not.
 func (sys *FilterSystem) NewBlockFilter(block common.Hash, addresses []common.Address, topics [][]common.Hash) *Filter {
@@ -0,0 +1,275 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxReceiptsReorgDepth is the maximum number of blocks the receipts subscription
+// walks back to find the common ancestor of the old and new chain head.
+const maxReceiptsReorgDepth = 128
+
+// maxReceiptsQueue is the maximum number of chain heads queued by the receipts
+// subscription while the receipts of the previous ones are looked up.
+const maxReceiptsQueue = 256
+
+var errReorgTooDeep = errors.New("reorg too deep")
+
+// ReceiptFilterCriteria selects the receipts sent by the receipts subscription.
+// Empty fields match all receipts.
+type ReceiptFilterCriteria struct {
+	From  []common.Address `json:"from"`  // Senders of the transactions
+	To    []common.Address `json:"to"`    // Recipients of the transactions, or the created contracts
+	Types []hexutil.Uint64 `json:"types"` // Transaction types
+}
+
+// matches returns whether the receipt of tx, sent by from, satisfies the criteria.
+func (crit *ReceiptFilterCriteria) matches(tx *types.Transaction, receipt *types.Receipt, from common.Address) bool {
+	if crit == nil {
+		return true
+	}
+	if len(crit.From) > 0 && !slices.Contains(crit.From, from) {
+		return false
+	}
+	if len(crit.To) > 0 {
+		to := receipt.ContractAddress
+		if tx.To() != nil {
+			to = *tx.To()
+		}
+		if !slices.Contains(crit.To, to) {
+			return false
+		}
+	}
+	if len(crit.Types) > 0 && !slices.Contains(crit.Types, hexutil.Uint64(tx.Type())) {
+		return false
+	}
+	return true
+}
+
+// ReceiptsGap is sent by the receipts subscription in place of the receipts it
+// couldn't deliver, when the blocks between the previous and the new chain head
+// can't be determined, e.g. after a reorg deeper than maxReceiptsReorgDepth. The
+// receipts of the blocks after OldHead up to NewHead, and the removal of the blocks
+// of the old chain, were not sent. The subscription continues with the receipts
+// of NewHead.
+type ReceiptsGap struct {
+	Gap       bool           `json:"gap"` // Always true, distinguishing gaps from receipts
+	OldHead   common.Hash    `json:"oldHead"`
+	OldNumber hexutil.Uint64 `json:"oldNumber"`
+	NewHead   common.Hash    `json:"newHead"`
+	NewNumber hexutil.Uint64 `json:"newNumber"`
+}
+
+// Receipts creates a subscription that sends the receipts matching the given
+// criteria for every block added to the canonical chain, one notification per
+// block. When blocks are dropped by a reorg, their receipts are sent again with
+// the removed property set to true, before the receipts of the new blocks. If
+// the blocks in between two chain heads can't be determined, a ReceiptsGap is
+// sent instead.
+func (api *FilterAPI) Receipts(ctx context.Context, crit *ReceiptFilterCriteria) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		headers := make(chan *types.Header)
+		headersSub := api.events.SubscribeNewHeads(headers)
+		defer headersSub.Unsubscribe()
+
+		// The receipts are looked up in a separate goroutine, so that slow lookups
+		// don't hold up the event system delivering the new heads. The heads
+		// arriving in the meantime are queued, and handed over in one go.
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		process := make(chan []*types.Header)
+		go api.sendReceipts(ctx, notifier, rpcSub.ID, crit, api.sys.backend.CurrentHeader(), process)
+
+		var queue []*types.Header
+		for {
+			var send chan []*types.Header
+			if len(queue) > 0 {
+				send = process
+			}
+			select {
+			case h := <-headers:
+				// If the queue overflows, only the newest head is kept. The blocks
+				// leading to it are still found by walking back from it, and a
+				// ReceiptsGap is sent if they are too many.
+				if len(queue) >= maxReceiptsQueue {
+					log.Debug("Receipts subscription falling behind", "queued", len(queue), "number", h.Number)
+					queue = nil
+				}
+				queue = append(queue, h)
+			case send <- queue:
+				queue = nil
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// sendReceipts sends the receipts of the blocks between the previous and each
+// new chain head handed over by the subscription, starting from last.
+func (api *FilterAPI) sendReceipts(ctx context.Context, notifier *rpc.Notifier, id rpc.ID, crit *ReceiptFilterCriteria, last *types.Header, process <-chan []*types.Header) {
+	for {
+		select {
+		case heads := <-process:
+			for _, h := range heads {
+				removed, added, err := api.chainDiff(ctx, last, h)
+				if err != nil {
+					log.Warn("Failed to track receipts subscription head", "number", h.Number, "hash", h.Hash(), "err", err)
+					notifier.Notify(id, &ReceiptsGap{
+						Gap:       true,
+						OldHead:   last.Hash(),
+						OldNumber: hexutil.Uint64(last.Number.Uint64()),
+						NewHead:   h.Hash(),
+						NewNumber: hexutil.Uint64(h.Number.Uint64()),
+					})
+					removed, added = nil, []*types.Header{h}
+				}
+				for _, header := range removed {
+					api.notifyReceipts(ctx, notifier, id, header, crit, true)
+				}
+				for _, header := range added {
+					api.notifyReceipts(ctx, notifier, id, header, crit, false)
+				}
+				last = h
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// chainDiff returns the blocks dropped from the canonical chain, newest first,
+// and the blocks added to it, oldest first, when the head moves from oldHead to newHead.
+func (api *FilterAPI) chainDiff(ctx context.Context, oldHead, newHead *types.Header) ([]*types.Header, []*types.Header, error) {
+	var (
+		removed, added []*types.Header
+		err            error
+	)
+	parent := func(h *types.Header) (*types.Header, error) {
+		if len(removed)+len(added) > maxReceiptsReorgDepth {
+			return nil, errReorgTooDeep
+		}
+		p, err := api.sys.backend.HeaderByHash(ctx, h.ParentHash)
+		if err == nil && p == nil {
+			err = fmt.Errorf("header %x not found", h.ParentHash)
+		}
+		return p, err
+	}
+	for newHead.Number.Cmp(oldHead.Number) > 0 {
+		added = append(added, newHead)
+		if newHead, err = parent(newHead); err != nil {
+			return nil, nil, err
+		}
+	}
+	for oldHead.Number.Cmp(newHead.Number) > 0 {
+		removed = append(removed, oldHead)
+		if oldHead, err = parent(oldHead); err != nil {
+			return nil, nil, err
+		}
+	}
+	for oldHead.Hash() != newHead.Hash() {
+		removed, added = append(removed, oldHead), append(added, newHead)
+		if oldHead, err = parent(oldHead); err != nil {
+			return nil, nil, err
+		}
+		if newHead, err = parent(newHead); err != nil {
+			return nil, nil, err
+		}
+	}
+	slices.Reverse(added)
+	return removed, added, nil
+}
+
+// notifyReceipts sends the receipts of the given block matching the criteria.
+func (api *FilterAPI) notifyReceipts(ctx context.Context, notifier *rpc.Notifier, id rpc.ID, header *types.Header, crit *ReceiptFilterCriteria, removed bool) {
+	receipts, err := api.blockReceipts(ctx, header, crit, removed)
+	if err != nil {
+		log.Warn("Failed to get receipts for subscription", "number", header.Number, "hash", header.Hash(), "err", err)
+		return
+	}
+	if len(receipts) > 0 {
+		notifier.Notify(id, receipts)
+	}
+}
+
+func (api *FilterAPI) blockReceipts(ctx context.Context, header *types.Header, crit *ReceiptFilterCriteria, removed bool) ([]map[string]interface{}, error) {
+	var (
+		backend = api.sys.backend
+		hash    = header.Hash()
+		number  = header.Number.Uint64()
+	)
+	body, err := backend.GetBody(ctx, hash, rpc.BlockNumber(number))
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := backend.GetReceipts(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if len(body.Transactions) != len(receipts) {
+		return nil, fmt.Errorf("receipts length mismatch: %d vs %d", len(body.Transactions), len(receipts))
+	}
+	// The block metadata is looked up by number, so it only belongs to blocks
+	// which are still canonical.
+	var blockMetadata common.BlockMetadata
+	if !removed {
+		if blockMetadata, err = backend.BlockMetadataByNumber(ctx, number); err != nil {
+			return nil, err
+		}
+	}
+	var (
+		arbosVersion = types.DeserializeHeaderExtraInformation(header).ArbOSFormatVersion
+		signer       = types.MakeSigner(backend.ChainConfig(), header.Number, header.Time, arbosVersion)
+		result       []map[string]interface{}
+	)
+	for i, receipt := range receipts {
+		tx := body.Transactions[i]
+		from, _ := types.Sender(signer, tx)
+		if !crit.matches(tx, receipt, from) {
+			continue
+		}
+		fields := ethapi.MarshalReceipt(receipt, hash, number, signer, tx, i, header, backend.ChainConfig())
+		fields["removed"] = removed
+		if blockMetadata != nil {
+			if fields["timeboosted"], err = blockMetadata.IsTxTimeboosted(i); err != nil {
+				log.Error("Error checking if a tx was timeboosted", "txIndex", i, "txHash", tx.Hash(), "err", err)
+			}
+		}
+		result = append(result, fields)
+	}
+	return result, nil
+}
@@ -0,0 +1,317 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+type receiptNotification struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	To          common.Address `json:"to"`
+	Removed     bool           `json:"removed"`
+	Timeboosted bool           `json:"timeboosted"`
+}
+
+// TestReceiptsSubscription tests that receipts are streamed for new blocks and
+// that the receipts of blocks dropped by a reorg are sent as removed.
+func TestReceiptsSubscription(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(db, Config{})
+		api          = NewFilterAPI(sys)
+		key, _       = crypto.GenerateKey()
+		addr         = crypto.PubkeyToAddress(key.PublicKey)
+		signer       = types.HomesteadSigner{}
+		mainTo       = common.Address{0x01}
+		forkTo       = common.Address{0x02}
+		otherTo      = common.Address{0x03}
+		genesis      = &core.Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+	)
+	transfer := func(to common.Address) func(int, *core.BlockGen) {
+		return func(i int, gen *core.BlockGen) {
+			nonce := gen.TxNonce(addr)
+			gen.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: nonce, To: &to, Gas: params.TxGas, GasPrice: gen.BaseFee()}))
+			gen.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: nonce + 1, To: &otherTo, Gas: params.TxGas, GasPrice: gen.BaseFee()}))
+		}
+	}
+	gendb, chain, receipts := core.GenerateChainWithGenesis(genesis, ethash.NewFaker(), 3, transfer(mainTo))
+	fork, forkReceipts := core.GenerateChain(genesis.Config, chain[0], ethash.NewFaker(), gendb, 2, transfer(forkTo))
+
+	write := func(block *types.Block, receipts types.Receipts) {
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		rawdb.WriteHeadBlockHash(db, block.Hash())
+		rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), receipts)
+	}
+	genesis.MustCommit(db, triedb.NewDatabase(db, triedb.HashDefaults))
+	write(chain[0], receipts[0])
+
+	// The first transaction of block 2 was timeboosted, the metadata of block
+	// 3 isn't known.
+	backend.blockMetadata = map[uint64]common.BlockMetadata{2: {0, 0x01}}
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("eth", api); err != nil {
+		t.Fatal(err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	notifications := make(chan []receiptNotification)
+	sub, err := client.EthSubscribe(context.Background(), notifications, "receipts", ReceiptFilterCriteria{To: []common.Address{mainTo, forkTo}})
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+	time.Sleep(100 * time.Millisecond) // Wait for the subscription to be installed
+
+	// Extend the chain, then reorg onto the fork.
+	for i := 1; i < len(chain); i++ {
+		write(chain[i], receipts[i])
+		backend.chainFeed.Send(core.ChainEvent{Header: chain[i].Header()})
+	}
+	for i := range fork {
+		write(fork[i], forkReceipts[i])
+	}
+	backend.chainFeed.Send(core.ChainEvent{Header: fork[1].Header()})
+
+	want := []receiptNotification{
+		{BlockNumber: 2, To: mainTo, Timeboosted: true},
+		{BlockNumber: 3, To: mainTo},
+		{BlockNumber: 3, To: mainTo, Removed: true},
+		{BlockNumber: 2, To: mainTo, Removed: true},
+		{BlockNumber: 2, To: forkTo, Timeboosted: true},
+		{BlockNumber: 3, To: forkTo},
+	}
+	for i, w := range want {
+		select {
+		case have := <-notifications:
+			if len(have) != 1 || have[0] != w {
+				t.Fatalf("notification %d mismatch: have %+v, want %+v", i, have, w)
+			}
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for notification %d", i)
+		}
+	}
+}
+
+// TestReceiptsSubscriptionGap tests that a gap is reported if the blocks between
+// two chain heads can't be determined.
+func TestReceiptsSubscriptionGap(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(db, Config{})
+		api          = NewFilterAPI(sys)
+		key, _       = crypto.GenerateKey()
+		addr         = crypto.PubkeyToAddress(key.PublicKey)
+		to           = common.Address{0x01}
+		genesis      = &core.Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+	)
+	_, chain, receipts := core.GenerateChainWithGenesis(genesis, ethash.NewFaker(), 3, func(i int, gen *core.BlockGen) {
+		gen.AddTx(types.MustSignNewTx(key, types.HomesteadSigner{}, &types.LegacyTx{Nonce: gen.TxNonce(addr), To: &to, Gas: params.TxGas, GasPrice: gen.BaseFee()}))
+	})
+	write := func(block *types.Block, receipts types.Receipts) {
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		rawdb.WriteHeadBlockHash(db, block.Hash())
+		rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), receipts)
+	}
+	genesis.MustCommit(db, triedb.NewDatabase(db, triedb.HashDefaults))
+	write(chain[0], receipts[0])
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("eth", api); err != nil {
+		t.Fatal(err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	notifications := make(chan json.RawMessage)
+	sub, err := client.EthSubscribe(context.Background(), notifications, "receipts", ReceiptFilterCriteria{})
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+	time.Sleep(100 * time.Millisecond) // Wait for the subscription to be installed
+
+	// Move the head to block 3 while block 2 is missing.
+	write(chain[2], receipts[2])
+	backend.chainFeed.Send(core.ChainEvent{Header: chain[2].Header()})
+
+	next := func() json.RawMessage {
+		select {
+		case n := <-notifications:
+			return n
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for notification")
+		}
+		return nil
+	}
+	var gap ReceiptsGap
+	if err := json.Unmarshal(next(), &gap); err != nil {
+		t.Fatalf("failed to decode gap: %v", err)
+	}
+	want := ReceiptsGap{
+		Gap:       true,
+		OldHead:   chain[0].Hash(),
+		OldNumber: 1,
+		NewHead:   chain[2].Hash(),
+		NewNumber: 3,
+	}
+	if gap != want {
+		t.Fatalf("gap mismatch: have %+v, want %+v", gap, want)
+	}
+	var have []receiptNotification
+	if err := json.Unmarshal(next(), &have); err != nil {
+		t.Fatalf("failed to decode receipts: %v", err)
+	}
+	if len(have) != 1 || have[0] != (receiptNotification{BlockNumber: 3, To: to}) {
+		t.Fatalf("receipts mismatch: have %+v", have)
+	}
+}
+
+// slowReceiptsBackend holds back the receipt lookups until released.
+type slowReceiptsBackend struct {
+	*testBackend
+	release chan struct{}
+}
+
+func (b *slowReceiptsBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
+	select {
+	case <-b.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return b.testBackend.GetReceipts(ctx, hash)
+}
+
+// TestReceiptsSubscriptionSlowLookup tests that slow receipt lookups don't block
+// the delivery of chain events to the other subscriptions.
+func TestReceiptsSubscriptionSlowLookup(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		backend = &slowReceiptsBackend{testBackend: &testBackend{db: db}, release: make(chan struct{})}
+		sys     = NewFilterSystem(backend, Config{})
+		api     = NewFilterAPI(sys)
+		key, _  = crypto.GenerateKey()
+		addr    = crypto.PubkeyToAddress(key.PublicKey)
+		to      = common.Address{0x01}
+		genesis = &core.Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		blocks = 40
+	)
+	_, chain, receipts := core.GenerateChainWithGenesis(genesis, ethash.NewFaker(), blocks, func(i int, gen *core.BlockGen) {
+		gen.AddTx(types.MustSignNewTx(key, types.HomesteadSigner{}, &types.LegacyTx{Nonce: gen.TxNonce(addr), To: &to, Gas: params.TxGas, GasPrice: gen.BaseFee()}))
+	})
+	genesis.MustCommit(db, triedb.NewDatabase(db, triedb.HashDefaults))
+	for i, block := range chain {
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), receipts[i])
+	}
+	rawdb.WriteHeadBlockHash(db, chain[0].Hash())
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("eth", api); err != nil {
+		t.Fatal(err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	notifications := make(chan []receiptNotification, blocks)
+	sub, err := client.EthSubscribe(context.Background(), notifications, "receipts", ReceiptFilterCriteria{})
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+	headers := make(chan *types.Header)
+	headersSub := api.events.SubscribeNewHeads(headers)
+	defer headersSub.Unsubscribe()
+	time.Sleep(100 * time.Millisecond) // Wait for the subscription to be installed
+
+	// The new heads are delivered while the receipts are held back.
+	go func() {
+		for _, block := range chain[1:] {
+			backend.chainFeed.Send(core.ChainEvent{Header: block.Header()})
+		}
+	}()
+	for i := 1; i < blocks; i++ {
+		select {
+		case header := <-headers:
+			if header.Number.Uint64() != uint64(i+1) {
+				t.Fatalf("head %d mismatch: have %d", i, header.Number)
+			}
+		case <-time.After(5 * time.Second):
+			close(backend.release)
+			t.Fatalf("head %d blocked by the receipts subscription", i)
+		}
+	}
+	// Once released, the receipts of all blocks are sent in order.
+	close(backend.release)
+	for i := 2; i <= blocks; i++ {
+		select {
+		case have := <-notifications:
+			if len(have) != 1 || have[0] != (receiptNotification{BlockNumber: hexutil.Uint64(i), To: to}) {
+				t.Fatalf("receipts of block %d mismatch: have %+v", i, have)
+			}
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for the receipts of block %d", i)
+		}
+	}
+}
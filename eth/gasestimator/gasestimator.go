@@ -199,6 +199,15 @@ func Estimate(ctx context.Context, call *core.Message, opts *Options, gasCap uin
 	return hi, nil, nil
 }
 
+// Execute runs the call with the given gas limit, returning the result of the
+// execution, e.g. to break down the gas used by an estimate.
+func Execute(ctx context.Context, call *core.Message, opts *Options, gasLimit uint64) (*core.ExecutionResult, error) {
+	defer func(gas uint64) { call.GasLimit = gas }(call.GasLimit)
+	call.GasLimit = gasLimit
+
+	return run(ctx, call, opts)
+}
+
 // execute is a helper that executes the transaction under a given gas limit and
 // returns true if the transaction fails for a reason that might be related to
 // not enough gas. A non-nil error means execution failed due to reasons unrelated
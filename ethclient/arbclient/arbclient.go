@@ -0,0 +1,221 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package arbclient provides an RPC client for the Arbitrum extensions of the
+// Ethereum RPC API.
+package arbclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/arbitrum_types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Client is a wrapper around rpc.Client that implements the Arbitrum specific
+// functionality.
+//
+// If you want to use the standardized Ethereum RPC functionality, use ethclient.Client instead.
+type Client struct {
+	c rpc.ClientInterface
+}
+
+// New creates a client that uses the given RPC client.
+func New(c rpc.ClientInterface) *Client {
+	return &Client{c}
+}
+
+// Header is a block header together with its decoded Arbitrum information.
+type Header struct {
+	*types.Header
+
+	// L1BlockNumber is the parent chain block number the block was based on.
+	// It is reported by the node for both Nitro and classic blocks.
+	L1BlockNumber uint64
+
+	// Fields of the header extra information of Nitro blocks. They are zero
+	// for classic blocks.
+	SendRoot           common.Hash
+	SendCount          uint64
+	ArbOSFormatVersion uint64
+}
+
+func (h *Header) UnmarshalJSON(input []byte) error {
+	var extra struct {
+		L1BlockNumber *hexutil.Uint64 `json:"l1BlockNumber"`
+	}
+	if err := json.Unmarshal(input, &h.Header); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(input, &extra); err != nil {
+		return err
+	}
+	info := types.DeserializeHeaderExtraInformation(h.Header)
+	h.SendRoot = info.SendRoot
+	h.SendCount = info.SendCount
+	h.ArbOSFormatVersion = info.ArbOSFormatVersion
+	h.L1BlockNumber = info.L1BlockNumber
+	if extra.L1BlockNumber != nil {
+		h.L1BlockNumber = uint64(*extra.L1BlockNumber)
+	}
+	return nil
+}
+
+// HeaderByHash returns the block header with the given hash.
+func (ec *Client) HeaderByHash(ctx context.Context, hash common.Hash) (*Header, error) {
+	var head *Header
+	err := ec.c.CallContext(ctx, &head, "eth_getBlockByHash", hash, false)
+	if err == nil && head == nil {
+		err = ethereum.NotFound
+	}
+	return head, err
+}
+
+// HeaderByNumber returns a block header from the current canonical chain. If number is
+// nil, the latest known header is returned.
+func (ec *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*Header, error) {
+	var head *Header
+	err := ec.c.CallContext(ctx, &head, "eth_getBlockByNumber", toBlockNumArg(number), false)
+	if err == nil && head == nil {
+		err = ethereum.NotFound
+	}
+	return head, err
+}
+
+// Transaction is a transaction of any type, including the Arbitrum specific
+// ones, together with its inclusion information. The fields of the Arbitrum
+// transaction types are accessible through the inner transaction data, e.g.
+//
+//	if retry, ok := tx.GetInner().(*types.ArbitrumRetryTx); ok {
+//		...
+//	}
+type Transaction struct {
+	*types.Transaction
+
+	From             common.Address
+	BlockHash        *common.Hash
+	BlockNumber      *big.Int
+	TransactionIndex *uint64
+}
+
+func (tx *Transaction) UnmarshalJSON(input []byte) error {
+	var extra struct {
+		From             common.Address  `json:"from"`
+		BlockHash        *common.Hash    `json:"blockHash"`
+		BlockNumber      *hexutil.Big    `json:"blockNumber"`
+		TransactionIndex *hexutil.Uint64 `json:"transactionIndex"`
+	}
+	if err := json.Unmarshal(input, &tx.Transaction); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(input, &extra); err != nil {
+		return err
+	}
+	tx.From = extra.From
+	tx.BlockHash = extra.BlockHash
+	tx.BlockNumber = (*big.Int)(extra.BlockNumber)
+	tx.TransactionIndex = (*uint64)(extra.TransactionIndex)
+	return nil
+}
+
+// Pending reports whether the transaction is not included in a block yet.
+func (tx *Transaction) Pending() bool {
+	return tx.BlockHash == nil
+}
+
+// TransactionByHash returns the transaction with the given hash.
+func (ec *Client) TransactionByHash(ctx context.Context, hash common.Hash) (*Transaction, error) {
+	var tx *Transaction
+	err := ec.c.CallContext(ctx, &tx, "eth_getTransactionByHash", hash)
+	if err == nil && tx == nil {
+		err = ethereum.NotFound
+	}
+	return tx, err
+}
+
+// Receipt is a transaction receipt together with its Arbitrum specific fields.
+type Receipt struct {
+	*types.Receipt
+
+	// L1BlockNumber is the parent chain block number the including block was
+	// based on.
+	L1BlockNumber uint64
+
+	// Timeboosted reports whether the transaction was sequenced through the
+	// express lane. It's nil if the node has no block metadata of the block.
+	Timeboosted *bool
+}
+
+func (r *Receipt) UnmarshalJSON(input []byte) error {
+	var extra struct {
+		L1BlockNumber hexutil.Uint64 `json:"l1BlockNumber"`
+		Timeboosted   *bool          `json:"timeboosted"`
+	}
+	if err := json.Unmarshal(input, &r.Receipt); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(input, &extra); err != nil {
+		return err
+	}
+	r.L1BlockNumber = uint64(extra.L1BlockNumber)
+	r.Timeboosted = extra.Timeboosted
+	return nil
+}
+
+// TransactionReceipt returns the receipt of a transaction by transaction hash.
+// The gas used for posting the transaction to the parent chain is available as
+// GasUsedForL1. Note that the receipt is not available for pending transactions.
+func (ec *Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*Receipt, error) {
+	var r *Receipt
+	err := ec.c.CallContext(ctx, &r, "eth_getTransactionReceipt", txHash)
+	if err == nil && r == nil {
+		err = ethereum.NotFound
+	}
+	return r, err
+}
+
+// SendTransactionConditional injects a signed transaction into the pending pool
+// for execution, unless the given conditions are not met at the time the
+// sequencer sequences it.
+func (ec *Client) SendTransactionConditional(ctx context.Context, tx *types.Transaction, options *arbitrum_types.ConditionalOptions) error {
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return ec.c.CallContext(ctx, nil, "eth_sendRawTransactionConditional", hexutil.Encode(data), options)
+}
+
+func toBlockNumArg(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	if number.Sign() >= 0 {
+		return hexutil.EncodeBig(number)
+	}
+	// It's negative.
+	if number.IsInt64() {
+		return rpc.BlockNumber(number.Int64()).String()
+	}
+	// It's negative and large, which is invalid.
+	return fmt.Sprintf("<invalid %d>", number)
+}
@@ -0,0 +1,262 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package arbclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/arbitrum_types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr   = crypto.PubkeyToAddress(testKey.PublicKey)
+)
+
+// newSimulatedBackend creates a simulated backend together with an Arbitrum
+// client attached to it over IPC.
+func newSimulatedBackend(t *testing.T, alloc types.GenesisAlloc) (*simulated.Backend, *Client) {
+	ipcPath := filepath.Join(t.TempDir(), "geth.ipc")
+	sim := simulated.NewBackend(alloc, func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		nodeConf.IPCPath = ipcPath
+	})
+	t.Cleanup(func() { sim.Close() })
+
+	client, err := rpc.Dial(ipcPath)
+	if err != nil {
+		t.Fatalf("could not attach to simulated backend: %v", err)
+	}
+	t.Cleanup(client.Close)
+	return sim, New(client)
+}
+
+func TestSimulatedReceiptAndHeader(t *testing.T) {
+	sim, client := newSimulatedBackend(t, types.GenesisAlloc{
+		testAddr: {Balance: big.NewInt(params.Ether)},
+	})
+	ctx := context.Background()
+	head, err := sim.Client().HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatalf("could not get head: %v", err)
+	}
+	chainID, _ := sim.Client().ChainID(ctx)
+	tx, _ := types.SignNewTx(testKey, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: new(big.Int).Add(head.BaseFee, big.NewInt(params.GWei)),
+		Gas:       21000,
+		To:        &testAddr,
+	})
+	if err := sim.Client().SendTransaction(ctx, tx); err != nil {
+		t.Fatalf("could not send transaction: %v", err)
+	}
+	pending, err := client.TransactionByHash(ctx, tx.Hash())
+	if err != nil {
+		t.Fatalf("could not get pending transaction: %v", err)
+	}
+	if !pending.Pending() || pending.From != testAddr {
+		t.Fatalf("pending transaction mismatch: pending %v, from %v", pending.Pending(), pending.From)
+	}
+	blockHash := sim.Commit()
+
+	receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		t.Fatalf("could not get receipt: %v", err)
+	}
+	if receipt.BlockHash != blockHash || receipt.GasUsed != 21000 || receipt.GasUsedForL1 != 0 {
+		t.Fatalf("receipt mismatch: %+v", receipt.Receipt)
+	}
+	included, err := client.TransactionByHash(ctx, tx.Hash())
+	if err != nil {
+		t.Fatalf("could not get transaction: %v", err)
+	}
+	if included.Pending() || *included.BlockHash != blockHash || included.BlockNumber.Uint64() != 1 || *included.TransactionIndex != 0 {
+		t.Fatalf("included transaction mismatch: %+v", included)
+	}
+	header, err := client.HeaderByHash(ctx, blockHash)
+	if err != nil {
+		t.Fatalf("could not get header: %v", err)
+	}
+	if header.Hash() != blockHash || header.L1BlockNumber != 0 || header.SendCount != 0 {
+		t.Fatalf("header mismatch: %+v", header)
+	}
+	if _, err := client.TransactionReceipt(ctx, common.Hash{1}); !errors.Is(err, ethereum.NotFound) {
+		t.Fatalf("missing receipt error mismatch: have %v, want %v", err, ethereum.NotFound)
+	}
+}
+
+// testArbitrumAPI serves the Arbitrum extensions of the eth namespace.
+type testArbitrumAPI struct {
+	header      map[string]interface{}
+	tx          map[string]interface{}
+	sent        hexutil.Bytes
+	sentOptions *arbitrum_types.ConditionalOptions
+}
+
+func (api *testArbitrumAPI) GetBlockByNumber(number rpc.BlockNumber, fullTx bool) map[string]interface{} {
+	return api.header
+}
+
+func (api *testArbitrumAPI) GetTransactionByHash(hash common.Hash) map[string]interface{} {
+	return api.tx
+}
+
+func (api *testArbitrumAPI) SendRawTransactionConditional(input hexutil.Bytes, options *arbitrum_types.ConditionalOptions) (common.Hash, error) {
+	api.sent, api.sentOptions = input, options
+	return crypto.Keccak256Hash(input), nil
+}
+
+func (api *testArbitrumAPI) Call(args map[string]interface{}, block string) (hexutil.Bytes, error) {
+	if to, _ := args["to"].(string); common.HexToAddress(to) != types.NodeInterfaceAddress {
+		return nil, errors.New("not a NodeInterface call")
+	}
+	input := common.FromHex(args["input"].(string))
+	method, err := nodeInterface.MethodById(input[:4])
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "blockL1Num":
+		inputs, _ := method.Inputs.Unpack(input[4:])
+		return method.Outputs.Pack(inputs[0].(uint64) + 100)
+	case "gasEstimateComponents":
+		inputs, _ := method.Inputs.Unpack(input[4:])
+		data := inputs[2].([]byte)
+		return method.Outputs.Pack(uint64(21000+len(data)), uint64(len(data)), big.NewInt(100), big.NewInt(7))
+	}
+	return nil, errors.New("unsupported method")
+}
+
+func newTestClient(t *testing.T, api *testArbitrumAPI) *Client {
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", api); err != nil {
+		t.Fatalf("could not register API: %v", err)
+	}
+	t.Cleanup(server.Stop)
+	client := rpc.DialInProc(server)
+	t.Cleanup(client.Close)
+	return New(client)
+}
+
+func TestHeaderInfo(t *testing.T) {
+	header := &types.Header{
+		Number:     big.NewInt(5),
+		Difficulty: common.Big1,
+		BaseFee:    big.NewInt(100),
+	}
+	types.HeaderInfo{SendRoot: common.Hash{1}, SendCount: 2, L1BlockNumber: 3, ArbOSFormatVersion: 32}.UpdateHeaderWithInfo(header)
+
+	var fields map[string]interface{}
+	enc, _ := json.Marshal(header)
+	json.Unmarshal(enc, &fields)
+	fields["l1BlockNumber"] = "0x3"
+
+	client := newTestClient(t, &testArbitrumAPI{header: fields})
+	have, err := client.HeaderByNumber(context.Background(), big.NewInt(5))
+	if err != nil {
+		t.Fatalf("could not get header: %v", err)
+	}
+	if have.Hash() != header.Hash() {
+		t.Fatalf("header hash mismatch: have %v, want %v", have.Hash(), header.Hash())
+	}
+	if have.SendRoot != (common.Hash{1}) || have.SendCount != 2 || have.L1BlockNumber != 3 || have.ArbOSFormatVersion != 32 {
+		t.Fatalf("header info mismatch: %+v", have)
+	}
+}
+
+func TestArbitrumTransactionTypes(t *testing.T) {
+	chainID := big.NewInt(412346)
+	retryTo := common.Address{0xbb}
+	for _, inner := range []types.TxData{
+		&types.ArbitrumDepositTx{ChainId: chainID, L1RequestId: common.Hash{1}, From: testAddr, To: retryTo, Value: big.NewInt(1)},
+		&types.ArbitrumUnsignedTx{ChainId: chainID, From: testAddr, Nonce: 1, GasFeeCap: big.NewInt(1), Gas: 21000, To: &retryTo, Value: big.NewInt(1), Data: []byte{1}},
+		&types.ArbitrumContractTx{ChainId: chainID, RequestId: common.Hash{2}, From: testAddr, GasFeeCap: big.NewInt(1), Gas: 21000, To: &retryTo, Value: big.NewInt(1)},
+		&types.ArbitrumRetryTx{ChainId: chainID, Nonce: 1, From: testAddr, GasFeeCap: big.NewInt(1), Gas: 21000, To: &retryTo, Value: big.NewInt(1), TicketId: common.Hash{3}, RefundTo: retryTo, MaxRefund: big.NewInt(2), SubmissionFeeRefund: big.NewInt(3)},
+		&types.ArbitrumSubmitRetryableTx{ChainId: chainID, RequestId: common.Hash{4}, From: testAddr, L1BaseFee: big.NewInt(1), DepositValue: big.NewInt(2), GasFeeCap: big.NewInt(1), Gas: 21000, RetryTo: &retryTo, RetryValue: big.NewInt(1), Beneficiary: retryTo, MaxSubmissionFee: big.NewInt(3), FeeRefundAddr: retryTo, RetryData: []byte{1}},
+		&types.ArbitrumInternalTx{ChainId: chainID, Data: []byte{1}},
+	} {
+		tx := types.NewTx(inner)
+		var fields map[string]interface{}
+		enc, _ := json.Marshal(tx)
+		json.Unmarshal(enc, &fields)
+		fields["from"] = testAddr
+
+		client := newTestClient(t, &testArbitrumAPI{tx: fields})
+		have, err := client.TransactionByHash(context.Background(), tx.Hash())
+		if err != nil {
+			t.Fatalf("could not get transaction of type %#x: %v", tx.Type(), err)
+		}
+		if have.Type() != tx.Type() || have.Hash() != tx.Hash() || have.From != testAddr {
+			t.Fatalf("transaction mismatch for type %#x: have %+v", tx.Type(), have)
+		}
+	}
+}
+
+func TestSendTransactionConditional(t *testing.T) {
+	api := new(testArbitrumAPI)
+	client := newTestClient(t, api)
+
+	tx, _ := types.SignNewTx(testKey, types.LatestSignerForChainID(common.Big1), &types.LegacyTx{Gas: 21000, GasPrice: common.Big1})
+	maxBlock := math.HexOrDecimal64(10)
+	options := &arbitrum_types.ConditionalOptions{BlockNumberMax: &maxBlock}
+	if err := client.SendTransactionConditional(context.Background(), tx, options); err != nil {
+		t.Fatalf("could not send transaction: %v", err)
+	}
+	enc, _ := tx.MarshalBinary()
+	if !bytes.Equal(api.sent, enc) {
+		t.Fatalf("sent transaction mismatch: have %x, want %x", api.sent, enc)
+	}
+	if api.sentOptions == nil || api.sentOptions.BlockNumberMax == nil || *api.sentOptions.BlockNumberMax != maxBlock {
+		t.Fatalf("sent options mismatch: %+v", api.sentOptions)
+	}
+}
+
+func TestNodeInterface(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		client = newTestClient(t, new(testArbitrumAPI))
+	)
+	l1Block, err := client.BlockL1Num(ctx, 5)
+	if err != nil || l1Block != 105 {
+		t.Fatalf("BlockL1Num mismatch: have %d, %v, want 105", l1Block, err)
+	}
+	to := common.Address{0xbb}
+	estimate, err := client.GasEstimateComponents(ctx, ethereum.CallMsg{To: &to, Data: []byte{1, 2}}, nil)
+	if err != nil {
+		t.Fatalf("could not estimate gas: %v", err)
+	}
+	if estimate.Gas != 21002 || estimate.GasForL1 != 2 || estimate.BaseFee.Uint64() != 100 || estimate.L1BaseFeeEstimate.Uint64() != 7 {
+		t.Fatalf("estimate mismatch: %+v", estimate)
+	}
+}
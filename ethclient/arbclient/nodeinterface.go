@@ -0,0 +1,201 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package arbclient
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// nodeInterfaceABI is the subset of the NodeInterface virtual contract served
+// by the client.
+const nodeInterfaceABI = `[
+	{"type":"function","name":"nitroGenesisBlock","stateMutability":"pure","inputs":[],"outputs":[{"name":"number","type":"uint256"}]},
+	{"type":"function","name":"blockL1Num","stateMutability":"view","inputs":[{"name":"l2BlockNum","type":"uint64"}],"outputs":[{"name":"l1BlockNum","type":"uint64"}]},
+	{"type":"function","name":"l2BlockRangeForL1","stateMutability":"view","inputs":[{"name":"blockNum","type":"uint64"}],"outputs":[{"name":"firstBlock","type":"uint64"},{"name":"lastBlock","type":"uint64"}]},
+	{"type":"function","name":"findBatchContainingBlock","stateMutability":"view","inputs":[{"name":"blockNum","type":"uint64"}],"outputs":[{"name":"batch","type":"uint64"}]},
+	{"type":"function","name":"getL1Confirmations","stateMutability":"view","inputs":[{"name":"blockHash","type":"bytes32"}],"outputs":[{"name":"confirmations","type":"uint64"}]},
+	{"type":"function","name":"gasEstimateComponents","stateMutability":"payable","inputs":[{"name":"to","type":"address"},{"name":"contractCreation","type":"bool"},{"name":"data","type":"bytes"}],"outputs":[{"name":"gasEstimate","type":"uint64"},{"name":"gasEstimateForL1","type":"uint64"},{"name":"baseFee","type":"uint256"},{"name":"l1BaseFeeEstimate","type":"uint256"}]},
+	{"type":"function","name":"gasEstimateL1Component","stateMutability":"payable","inputs":[{"name":"to","type":"address"},{"name":"contractCreation","type":"bool"},{"name":"data","type":"bytes"}],"outputs":[{"name":"gasEstimateForL1","type":"uint64"},{"name":"baseFee","type":"uint256"},{"name":"l1BaseFeeEstimate","type":"uint256"}]}
+]`
+
+var nodeInterface = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(nodeInterfaceABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// callNodeInterface calls the given method of the NodeInterface virtual
+// contract at the given block, returning the unpacked outputs.
+func (ec *Client) callNodeInterface(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := nodeInterface.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	to := types.NodeInterfaceAddress
+	msg.To = &to
+	msg.Data = data
+
+	var hex hexutil.Bytes
+	if err := ec.c.CallContext(ctx, &hex, "eth_call", toCallArg(msg), toBlockNumArg(blockNumber)); err != nil {
+		return nil, err
+	}
+	return nodeInterface.Unpack(method, hex)
+}
+
+// NitroGenesisBlock returns the number of the first Nitro block of the chain.
+func (ec *Client) NitroGenesisBlock(ctx context.Context) (uint64, error) {
+	out, err := ec.callNodeInterface(ctx, ethereum.CallMsg{}, nil, "nitroGenesisBlock")
+	if err != nil {
+		return 0, err
+	}
+	return out[0].(*big.Int).Uint64(), nil
+}
+
+// BlockL1Num returns the parent chain block number the given block was based on.
+func (ec *Client) BlockL1Num(ctx context.Context, number uint64) (uint64, error) {
+	out, err := ec.callNodeInterface(ctx, ethereum.CallMsg{}, nil, "blockL1Num", number)
+	if err != nil {
+		return 0, err
+	}
+	return out[0].(uint64), nil
+}
+
+// L2BlockRangeForL1 returns the range of blocks based on the given parent chain
+// block.
+func (ec *Client) L2BlockRangeForL1(ctx context.Context, l1Number uint64) (first uint64, last uint64, err error) {
+	out, err := ec.callNodeInterface(ctx, ethereum.CallMsg{}, nil, "l2BlockRangeForL1", l1Number)
+	if err != nil {
+		return 0, 0, err
+	}
+	return out[0].(uint64), out[1].(uint64), nil
+}
+
+// FindBatchContainingBlock returns the number of the batch posting the given
+// block to the parent chain.
+func (ec *Client) FindBatchContainingBlock(ctx context.Context, number uint64) (uint64, error) {
+	out, err := ec.callNodeInterface(ctx, ethereum.CallMsg{}, nil, "findBatchContainingBlock", number)
+	if err != nil {
+		return 0, err
+	}
+	return out[0].(uint64), nil
+}
+
+// L1Confirmations returns the number of parent chain confirmations of the batch
+// posting the given block.
+func (ec *Client) L1Confirmations(ctx context.Context, blockHash common.Hash) (uint64, error) {
+	out, err := ec.callNodeInterface(ctx, ethereum.CallMsg{}, nil, "getL1Confirmations", blockHash)
+	if err != nil {
+		return 0, err
+	}
+	return out[0].(uint64), nil
+}
+
+// GasEstimate is the breakdown of a gas estimate into its execution and parent
+// chain posting components.
+type GasEstimate struct {
+	// Gas is the total estimate, including GasForL1. It's zero for estimates of
+	// the parent chain component only.
+	Gas uint64
+
+	// GasForL1 is the gas paying for posting the transaction to the parent chain.
+	GasForL1 uint64
+
+	BaseFee           *big.Int
+	L1BaseFeeEstimate *big.Int
+}
+
+// estimateArgs returns the arguments of the NodeInterface estimation methods,
+// which take the target of the estimated call as argument.
+func estimateArgs(msg ethereum.CallMsg) []interface{} {
+	var (
+		to       common.Address
+		creation = msg.To == nil
+	)
+	if !creation {
+		to = *msg.To
+	}
+	data := msg.Data
+	if data == nil {
+		data = []byte{}
+	}
+	return []interface{}{to, creation, data}
+}
+
+// GasEstimateComponents estimates the gas of the given call, broken down into
+// the execution and parent chain posting components.
+func (ec *Client) GasEstimateComponents(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (*GasEstimate, error) {
+	out, err := ec.callNodeInterface(ctx, msg, blockNumber, "gasEstimateComponents", estimateArgs(msg)...)
+	if err != nil {
+		return nil, err
+	}
+	return &GasEstimate{
+		Gas:               out[0].(uint64),
+		GasForL1:          out[1].(uint64),
+		BaseFee:           out[2].(*big.Int),
+		L1BaseFeeEstimate: out[3].(*big.Int),
+	}, nil
+}
+
+// GasEstimateL1Component estimates the gas paying for posting the given call to
+// the parent chain.
+func (ec *Client) GasEstimateL1Component(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (*GasEstimate, error) {
+	out, err := ec.callNodeInterface(ctx, msg, blockNumber, "gasEstimateL1Component", estimateArgs(msg)...)
+	if err != nil {
+		return nil, err
+	}
+	return &GasEstimate{
+		GasForL1:          out[0].(uint64),
+		BaseFee:           out[1].(*big.Int),
+		L1BaseFeeEstimate: out[2].(*big.Int),
+	}, nil
+}
+
+func toCallArg(msg ethereum.CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["input"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	if msg.GasFeeCap != nil {
+		arg["maxFeePerGas"] = (*hexutil.Big)(msg.GasFeeCap)
+	}
+	if msg.GasTipCap != nil {
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(msg.GasTipCap)
+	}
+	return arg
+}
@@ -323,6 +323,13 @@ type BlockOverrides struct {
 	Random common.Hash
 	// BaseFee overrides the block base fee.
 	BaseFee *big.Int
+	// L1BlockNumber overrides the L1 block number seen by the NUMBER and
+	// BLOCKHASH opcodes on Arbitrum chains. L1BlockNumber is applied only
+	// when it is non-zero.
+	L1BlockNumber uint64
+	// ArbOSVersion overrides the ArbOS version on Arbitrum chains.
+	// ArbOSVersion is applied only when it is non-zero.
+	ArbOSVersion uint64
 }
 
 func (o BlockOverrides) MarshalJSON() ([]byte, error) {
@@ -334,6 +341,9 @@ func (o BlockOverrides) MarshalJSON() ([]byte, error) {
 		Coinbase   *common.Address `json:"feeRecipient,omitempty"`
 		Random     *common.Hash    `json:"prevRandao,omitempty"`
 		BaseFee    *hexutil.Big    `json:"baseFeePerGas,omitempty"`
+
+		L1BlockNumber hexutil.Uint64 `json:"l1BlockNumber,omitempty"`
+		ArbOSVersion  hexutil.Uint64 `json:"arbOSVersion,omitempty"`
 	}
 
 	output := override{
@@ -342,6 +352,9 @@ func (o BlockOverrides) MarshalJSON() ([]byte, error) {
 		Time:       hexutil.Uint64(o.Time),
 		GasLimit:   hexutil.Uint64(o.GasLimit),
 		BaseFee:    (*hexutil.Big)(o.BaseFee),
+
+		L1BlockNumber: hexutil.Uint64(o.L1BlockNumber),
+		ArbOSVersion:  hexutil.Uint64(o.ArbOSVersion),
 	}
 	if o.Coinbase != (common.Address{}) {
 		output.Coinbase = &o.Coinbase
@@ -553,6 +553,13 @@ func TestBlockOverridesMarshal(t *testing.T) {
 			},
 			want: `{"number":"0x1","difficulty":"0x2","time":"0x3","gasLimit":"0x4","baseFeePerGas":"0x5"}`,
 		},
+		{
+			bo: BlockOverrides{
+				L1BlockNumber: 6,
+				ArbOSVersion:  32,
+			},
+			want: `{"l1BlockNumber":"0x6","arbOSVersion":"0x20"}`,
+		},
 	} {
 		marshalled, err := json.Marshal(&tt.bo)
 		if err != nil {
@@ -0,0 +1,425 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package simulated
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/holiman/uint256"
+)
+
+var errNotArbitrum = errors.New("simulated backend is not running in Arbitrum mode")
+
+// ArbitrumConfig configures the Arbitrum mode of the simulated backend.
+type ArbitrumConfig struct {
+	// ArbOSVersion is the ArbOS version recorded in the produced blocks. It
+	// gates the EVM rules, e.g. Shanghai requires ArbOS 11. Defaults to
+	// params.MaxArbosVersionSupported.
+	ArbOSVersion uint64
+
+	// L1Pricing prices the posting of transactions to the parent chain.
+	// Defaults to DefaultL1Pricing.
+	L1Pricing *L1Pricing
+
+	// TxProcessor creates the hooks processing each message. Defaults to the
+	// TxProcessor itself.
+	TxProcessor TxProcessorFactory
+}
+
+// WithArbitrum configures the simulated backend to run an Arbitrum chain. Blocks
+// carry the Arbitrum header information, Arbitrum transaction types are accepted
+// and every message is processed by the configured TxProcessingHook.
+//
+// Note, blocks are produced by the backend itself like on a Nitro node, instead
+// of through the engine API.
+func WithArbitrum(config ArbitrumConfig) func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+	if config.ArbOSVersion == 0 {
+		config.ArbOSVersion = params.MaxArbosVersionSupported
+	}
+	if config.L1Pricing == nil {
+		config.L1Pricing = &DefaultL1Pricing
+	}
+	return func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		chainConfig := *ethConf.Genesis.Config
+		chainConfig.ArbitrumChainParams = params.ArbitrumChainParams{
+			EnableArbOS:         true,
+			InitialArbOSVersion: config.ArbOSVersion,
+		}
+		ethConf.Genesis.Config = &chainConfig
+
+		// Record the ArbOS version in the genesis header too, like Nitro does.
+		var header types.Header
+		types.HeaderInfo{ArbOSFormatVersion: config.ArbOSVersion}.UpdateHeaderWithInfo(&header)
+		ethConf.Genesis.ExtraData = header.Extra
+		ethConf.Genesis.Mixhash = header.MixDigest
+		ethConf.Genesis.Difficulty = common.Big1
+
+		// The chain is attached to the backend through its consensus engine,
+		// which also installs the transaction hooks into the EVMs of the chain.
+		chain := &arbitrumChain{
+			config:  config,
+			pricing: *config.L1Pricing,
+		}
+		ethConf.ConsensusEngine = func(chainConfig *params.ChainConfig, db ethdb.Database) (consensus.Engine, error) {
+			engine, err := ethconfig.CreateConsensusEngine(chainConfig, db)
+			if err != nil {
+				return nil, err
+			}
+			return &arbitrumEngine{Engine: engine, chain: chain}, nil
+		}
+	}
+}
+
+// arbitrumEngine wraps the consensus engine of a simulated Arbitrum chain.
+type arbitrumEngine struct {
+	consensus.Engine
+	chain *arbitrumChain
+}
+
+// Prepare initializes the Arbitrum header information of blocks built by the
+// miner, so that pending blocks are executed with the ArbOS version of the chain.
+func (e *arbitrumEngine) Prepare(chain consensus.ChainHeaderReader, header *types.Header) error {
+	if err := e.Engine.Prepare(chain, header); err != nil {
+		return err
+	}
+	header.Difficulty = common.Big1
+	e.chain.headerInfo().UpdateHeaderWithInfo(header)
+	return nil
+}
+
+var _ core.L2Hooks = (*arbitrumEngine)(nil)
+
+// ReadyEVMForL2 installs the transaction processing hook of the chain, see
+// core.L2Hooks.
+func (e *arbitrumEngine) ReadyEVMForL2(evm *vm.EVM, msg *core.Message) {
+	evm.ProcessingHook = e.chain.txProcessor(evm, msg)
+}
+
+// arbitrumChain produces the blocks of a simulated Arbitrum chain.
+type arbitrumChain struct {
+	eth     *eth.Ethereum
+	config  ArbitrumConfig
+	pricing L1Pricing
+
+	l1BlockNumber atomic.Uint64 // Parent chain block number of new blocks
+
+	mu        sync.Mutex
+	queue     types.Transactions // Messages from the parent chain to sequence first
+	requestId uint64             // Last parent chain request id used
+}
+
+// newArbitrumChain attaches the Arbitrum block production to the backend, if
+// it was configured by WithArbitrum.
+func newArbitrumChain(backend *eth.Ethereum) *arbitrumChain {
+	engine, ok := backend.Engine().(*arbitrumEngine)
+	if !ok {
+		return nil
+	}
+	engine.chain.eth = backend
+	return engine.chain
+}
+
+func (c *arbitrumChain) txProcessor(evm *vm.EVM, msg *core.Message) vm.TxProcessingHook {
+	defaults := &TxProcessor{evm: evm, msg: msg, chain: c}
+	if c.config.TxProcessor != nil {
+		return c.config.TxProcessor(evm, msg, defaults)
+	}
+	return defaults
+}
+
+// headerInfo returns the Arbitrum header information of new blocks.
+func (c *arbitrumChain) headerInfo() types.HeaderInfo {
+	return types.HeaderInfo{
+		L1BlockNumber:      c.l1BlockNumber.Load(),
+		ArbOSFormatVersion: c.config.ArbOSVersion,
+	}
+}
+
+// l1BlockNumberAt returns the parent chain block number of the given block, or
+// of the next block if it wasn't produced yet.
+func (c *arbitrumChain) l1BlockNumberAt(number uint64) uint64 {
+	if header := c.eth.BlockChain().GetHeaderByNumber(number); header != nil {
+		return types.DeserializeHeaderExtraInformation(header).L1BlockNumber
+	}
+	return c.l1BlockNumber.Load()
+}
+
+// enqueue queues a message from the parent chain for the next block, returning
+// its hash.
+func (c *arbitrumChain) enqueue(inner types.TxData) common.Hash {
+	tx := types.NewTx(inner)
+	c.queue = append(c.queue, tx)
+	return tx.Hash()
+}
+
+// nextRequestId returns a new parent chain request id. The caller must hold the
+// lock.
+func (c *arbitrumChain) nextRequestId() common.Hash {
+	c.requestId++
+	return common.BigToHash(new(big.Int).SetUint64(c.requestId))
+}
+
+// rollback drops the queued messages.
+func (c *arbitrumChain) rollback() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queue = nil
+}
+
+// pending reports whether there are messages waiting to be sequenced.
+func (c *arbitrumChain) pending() bool {
+	c.mu.Lock()
+	queued := len(c.queue)
+	c.mu.Unlock()
+	if queued > 0 {
+		return true
+	}
+	return len(c.eth.TxPool().Pending(txpool.PendingFilter{})) > 0
+}
+
+// produceBlock sequences the queued parent chain messages followed by the pool
+// transactions into a new block on top of the current head. Scheduled retries
+// are executed right after the message scheduling them.
+func (c *arbitrumChain) produceBlock(timestamp uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Make sure the pool caught up with the previous block, see sealBlock in
+	// the simulated beacon.
+	if err := c.eth.TxPool().Sync(); err != nil {
+		return err
+	}
+	var (
+		bc     = c.eth.BlockChain()
+		config = bc.Config()
+		parent = bc.CurrentBlock()
+	)
+	if timestamp <= parent.Time {
+		timestamp = parent.Time + 1
+	}
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:   parent.GasLimit,
+		Time:       timestamp,
+		Difficulty: common.Big1,
+		BaseFee:    eip1559.CalcBaseFee(config, parent),
+	}
+	c.headerInfo().UpdateHeaderWithInfo(header)
+
+	// The fork specific header fields are present but empty, there are no
+	// withdrawals or blobs on Arbitrum.
+	var withdrawals []*types.Withdrawal
+	if config.IsShanghai(header.Number, header.Time, c.config.ArbOSVersion) {
+		withdrawals = make([]*types.Withdrawal, 0)
+	}
+	if config.IsCancun(header.Number, header.Time, c.config.ArbOSVersion) {
+		header.BlobGasUsed = new(uint64)
+		header.ExcessBlobGas = new(uint64)
+	}
+	statedb, err := bc.StateAt(parent.Root)
+	if err != nil {
+		return err
+	}
+	var (
+		evm      = vm.NewEVM(core.NewEVMBlockContext(header, bc, nil), statedb, config, vm.Config{})
+		gasPool  = new(core.GasPool).AddGas(header.GasLimit)
+		txs      types.Transactions
+		receipts types.Receipts
+	)
+	var apply func(tx *types.Transaction) error
+	apply = func(tx *types.Transaction) error {
+		var (
+			snap = statedb.Snapshot()
+			gas  = gasPool.Gas()
+		)
+		statedb.SetTxContext(tx.Hash(), len(txs))
+		receipt, result, err := core.ApplyTransactionWithResultFilter(evm, gasPool, statedb, header, tx, &header.GasUsed, core.NewMessageCommitContext(nil), nil)
+		if err != nil {
+			statedb.RevertToSnapshot(snap)
+			gasPool.SetGas(gas)
+			return err
+		}
+		txs = append(txs, tx)
+		receipts = append(receipts, receipt)
+
+		for _, scheduled := range result.ScheduledTxes {
+			if err := apply(scheduled); err != nil {
+				log.Warn("Failed to execute scheduled transaction", "hash", scheduled.Hash(), "err", err)
+			}
+		}
+		return nil
+	}
+	for _, tx := range c.queue {
+		if err := apply(tx); err != nil {
+			log.Warn("Failed to execute parent chain message", "hash", tx.Hash(), "err", err)
+		}
+	}
+	c.queue = nil
+
+	// Sequence the pool transactions in a deterministic order, skipping the
+	// remaining transactions of a sender after a failure.
+	pending := c.eth.TxPool().Pending(txpool.PendingFilter{BaseFee: uint256.MustFromBig(header.BaseFee)})
+	senders := make([]common.Address, 0, len(pending))
+	for addr := range pending {
+		senders = append(senders, addr)
+	}
+	slices.SortFunc(senders, func(a, b common.Address) int { return bytes.Compare(a[:], b[:]) })
+	for _, addr := range senders {
+		for _, lazy := range pending[addr] {
+			tx := lazy.Resolve()
+			if tx == nil {
+				break
+			}
+			if err := apply(tx); err != nil {
+				log.Debug("Skipping pool transaction", "hash", tx.Hash(), "err", err)
+				break
+			}
+		}
+	}
+	return c.writeBlock(header, &types.Body{Transactions: txs, Withdrawals: withdrawals}, receipts, statedb)
+}
+
+// writeBlock assembles the block and makes it the new chain head.
+func (c *arbitrumChain) writeBlock(header *types.Header, body *types.Body, receipts types.Receipts, statedb *state.StateDB) error {
+	bc := c.eth.BlockChain()
+	header.Root = statedb.IntermediateRoot(bc.Config().IsEIP158(header.Number))
+	block := types.NewBlock(header, body, receipts, trie.NewStackTrie(nil))
+
+	// The receipts and logs were created before the block hash was known.
+	var logs []*types.Log
+	for _, receipt := range receipts {
+		receipt.BlockHash = block.Hash()
+		for _, l := range receipt.Logs {
+			l.BlockHash = block.Hash()
+		}
+		logs = append(logs, receipt.Logs...)
+	}
+	_, err := bc.WriteBlockAndSetHeadWithTime(block, receipts, logs, statedb, true, 0)
+	return err
+}
+
+// SendDeposit queues a deposit of the given value to the given address, as sent
+// from the parent chain. It's included at the start of the next block, returning
+// the hash of the deposit transaction.
+func (n *Backend) SendDeposit(from, to common.Address, value *big.Int) (common.Hash, error) {
+	c := n.arbitrum
+	if c == nil {
+		return common.Hash{}, errNotArbitrum
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.enqueue(&types.ArbitrumDepositTx{
+		ChainId:     c.eth.BlockChain().Config().ChainID,
+		L1RequestId: c.nextRequestId(),
+		From:        from,
+		To:          to,
+		Value:       value,
+	}), nil
+}
+
+// Retryable is a retryable ticket submitted from the parent chain.
+type Retryable struct {
+	From          common.Address  // Sender of the retry, funded by the deposit
+	To            *common.Address // Target of the retry, nil for contract creation
+	Value         *big.Int        // Value of the retry
+	Data          []byte          // Calldata of the retry
+	Deposit       *big.Int        // Value minted to the sender
+	Gas           uint64          // Gas limit of the retry, zero to not execute it
+	GasFeeCap     *big.Int        // Gas fee cap of the retry
+	Beneficiary   common.Address
+	FeeRefundAddr common.Address
+}
+
+// SubmitRetryable queues the submission of a retryable ticket, as sent from the
+// parent chain. The submission is included at the start of the next block,
+// directly followed by the retry if the ticket has a gas limit. The returned
+// hash of the submission is the ticket id.
+func (n *Backend) SubmitRetryable(ticket Retryable) (common.Hash, error) {
+	c := n.arbitrum
+	if c == nil {
+		return common.Hash{}, errNotArbitrum
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	orZero := func(v *big.Int) *big.Int {
+		if v == nil {
+			return new(big.Int)
+		}
+		return v
+	}
+	return c.enqueue(&types.ArbitrumSubmitRetryableTx{
+		ChainId:          c.eth.BlockChain().Config().ChainID,
+		RequestId:        c.nextRequestId(),
+		From:             ticket.From,
+		L1BaseFee:        new(big.Int),
+		DepositValue:     orZero(ticket.Deposit),
+		GasFeeCap:        orZero(ticket.GasFeeCap),
+		Gas:              ticket.Gas,
+		RetryTo:          ticket.To,
+		RetryValue:       orZero(ticket.Value),
+		Beneficiary:      ticket.Beneficiary,
+		MaxSubmissionFee: new(big.Int),
+		FeeRefundAddr:    ticket.FeeRefundAddr,
+		RetryData:        ticket.Data,
+	}), nil
+}
+
+// SetL1BlockNumber sets the parent chain block number recorded in the following
+// blocks, as returned by the NUMBER opcode. The simulated parent chain doesn't
+// advance on its own.
+func (n *Backend) SetL1BlockNumber(number uint64) error {
+	c := n.arbitrum
+	if c == nil {
+		return errNotArbitrum
+	}
+	c.l1BlockNumber.Store(number)
+	return nil
+}
+
+// adjustTime produces an empty block with the timestamp of the head block moved
+// forward by the given adjustment.
+func (c *arbitrumChain) adjustTime(adjustment time.Duration) error {
+	if c.pending() {
+		return errors.New("could not adjust time on non-empty block")
+	}
+	parent := c.eth.BlockChain().CurrentBlock()
+	return c.produceBlock(parent.Time + uint64(adjustment/time.Second))
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package simulated
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+)
+
+// L1Pricing is a deterministic stand-in for the parent chain pricing of ArbOS.
+// Transactions are charged gas for posting their calldata to the parent chain
+// on top of their execution gas, independently of any fee market.
+type L1Pricing struct {
+	PerTxGas   uint64 // Gas charged for posting any transaction
+	PerByteGas uint64 // Gas charged per byte of calldata
+}
+
+// DefaultL1Pricing is the parent chain pricing used if none is configured.
+var DefaultL1Pricing = L1Pricing{
+	PerTxGas:   1000,
+	PerByteGas: 16,
+}
+
+// GasForL1 returns the gas charged for posting the message to the parent chain.
+func (p L1Pricing) GasForL1(msg *core.Message) uint64 {
+	return p.PerTxGas + p.PerByteGas*uint64(len(msg.Data))
+}
+
+// TxProcessorFactory creates the hooks processing a message on the simulated
+// Arbitrum chain. The default processor of the message is passed in, so custom
+// hooks can embed it and only override the methods they are interested in.
+type TxProcessorFactory func(evm *vm.EVM, msg *core.Message, defaults *TxProcessor) vm.TxProcessingHook
+
+// TxProcessor is the default transaction processing hook of the simulated
+// Arbitrum chain. It models a simplified subset of ArbOS:
+//
+//   - user transactions and calls are charged gas for posting to the parent
+//     chain, as priced by the configured L1Pricing.
+//   - deposits mint their value to the recipient.
+//   - retryable submissions mint their deposit to the sender and schedule the
+//     retry for immediate execution, which pays for its gas out of the sender's
+//     balance. Submission fees, escrow and refunds are not modelled.
+//   - the NUMBER and BLOCKHASH opcodes operate on parent chain blocks.
+type TxProcessor struct {
+	evm   *vm.EVM
+	msg   *core.Message
+	chain *arbitrumChain
+
+	gasForL1  uint64
+	scheduled types.Transactions
+}
+
+// ArbOSVersion returns the ArbOS version of the block being processed.
+func (p *TxProcessor) ArbOSVersion() uint64 {
+	return p.evm.Context.ArbOSVersion
+}
+
+// chargesL1Gas reports whether the message pays for being posted to the parent
+// chain. Messages originating from the parent chain, like deposits and retries,
+// don't.
+func (p *TxProcessor) chargesL1Gas() bool {
+	return p.msg.Tx == nil || p.msg.Tx.Type() < types.ArbitrumDepositTxType
+}
+
+func (p *TxProcessor) StartTxHook() (bool, uint64, error, []byte) {
+	if p.msg.Tx == nil {
+		return false, 0, nil, nil
+	}
+	switch tx := p.msg.Tx.GetInner().(type) {
+	case *types.ArbitrumDepositTx:
+		p.mint(tx.To, tx.Value)
+		return true, 0, nil, nil
+
+	case *types.ArbitrumSubmitRetryableTx:
+		p.mint(tx.From, tx.DepositValue)
+		if tx.Gas > 0 {
+			p.scheduled = append(p.scheduled, types.NewTx(&types.ArbitrumRetryTx{
+				ChainId:             p.evm.ChainConfig().ChainID,
+				Nonce:               0,
+				From:                tx.From,
+				GasFeeCap:           tx.GasFeeCap,
+				Gas:                 tx.Gas,
+				To:                  tx.RetryTo,
+				Value:               tx.RetryValue,
+				Data:                tx.RetryData,
+				TicketId:            p.msg.Tx.Hash(),
+				RefundTo:            tx.FeeRefundAddr,
+				MaxRefund:           new(big.Int),
+				SubmissionFeeRefund: new(big.Int),
+			}))
+		}
+		return true, 0, nil, nil
+	}
+	return false, 0, nil, nil
+}
+
+func (p *TxProcessor) mint(addr common.Address, value *big.Int) {
+	if value == nil || value.Sign() <= 0 {
+		return
+	}
+	p.evm.StateDB.AddBalance(addr, uint256.MustFromBig(value), tracing.BalanceIncreaseDeposit)
+}
+
+func (p *TxProcessor) GasChargingHook(gasRemaining *uint64) (common.Address, error) {
+	if p.chargesL1Gas() {
+		gas := p.chain.pricing.GasForL1(p.msg)
+		if *gasRemaining < gas {
+			return common.Address{}, fmt.Errorf("%w: not enough gas for L1 posting: have %d, want %d", core.ErrIntrinsicGas, *gasRemaining, gas)
+		}
+		*gasRemaining -= gas
+		p.gasForL1 = gas
+	}
+	return p.evm.Context.Coinbase, nil
+}
+
+func (p *TxProcessor) PushContract(contract *vm.Contract) {}
+
+func (p *TxProcessor) PopContract() {}
+
+func (p *TxProcessor) ForceRefundGas() uint64 { return 0 }
+
+// NonrefundableGas returns the gas charged for posting to the parent chain,
+// which doesn't count towards the refund cap.
+func (p *TxProcessor) NonrefundableGas() uint64 { return p.gasForL1 }
+
+func (p *TxProcessor) DropTip() bool { return false }
+
+func (p *TxProcessor) EndTxHook(totalGasUsed uint64, evmSuccess bool) {}
+
+func (p *TxProcessor) ScheduledTxes() types.Transactions {
+	return p.scheduled
+}
+
+// L1BlockNumber returns the parent chain block number of the block being
+// processed, as recorded in its header.
+func (p *TxProcessor) L1BlockNumber(blockCtx vm.BlockContext) (uint64, error) {
+	return p.chain.l1BlockNumberAt(blockCtx.BlockNumber.Uint64()), nil
+}
+
+// L1BlockHash returns a deterministic hash of the given parent chain block, as
+// the simulated chain has no parent chain to query.
+func (p *TxProcessor) L1BlockHash(blockCtx vm.BlockContext, l1BlockNumber uint64) (common.Hash, error) {
+	return crypto.Keccak256Hash(binary.BigEndian.AppendUint64(nil, l1BlockNumber)), nil
+}
+
+func (p *TxProcessor) GasPriceOp(evm *vm.EVM) *big.Int {
+	return evm.GasPrice
+}
+
+// FillReceiptInfo records the gas charged for posting to the parent chain.
+func (p *TxProcessor) FillReceiptInfo(receipt *types.Receipt) {
+	receipt.GasUsedForL1 = p.gasForL1
+}
+
+func (p *TxProcessor) MsgIsNonMutating() bool {
+	return p.msg.TxRunContext != nil && p.msg.TxRunContext.IsNonMutating()
+}
+
+func (p *TxProcessor) ExecuteWASM(scope *vm.ScopeContext, input []byte, interpreter *vm.EVMInterpreter) ([]byte, error) {
+	return nil, errors.New("stylus programs are not supported by the simulated backend")
+}
+
+func (p *TxProcessor) IsCalldataPricingIncreaseEnabled() bool {
+	return true
+}
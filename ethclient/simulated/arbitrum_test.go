@@ -0,0 +1,216 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package simulated
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	// push0Code deploys a contract returning 42, using the PUSH0 opcode
+	// introduced by Shanghai.
+	push0Code = common.FromHex("6008600a5f3960085ff3" + "602a5f5260205ff3")
+
+	// numberCode deploys a contract returning the block number.
+	numberCode = common.FromHex("6007600a5f3960075ff3" + "435f5260205ff3")
+)
+
+func newArbitrumBackend(t *testing.T, config ArbitrumConfig) *Backend {
+	sim := NewBackend(types.GenesisAlloc{
+		testAddr: {Balance: big.NewInt(params.Ether)},
+	}, WithArbitrum(config))
+	t.Cleanup(func() { sim.Close() })
+	return sim
+}
+
+func deploy(t *testing.T, sim *Backend, code []byte) (common.Address, *types.Receipt, error) {
+	t.Helper()
+	ctx := context.Background()
+
+	chainID, _ := sim.Client().ChainID(ctx)
+	opts := bind.NewKeyedTransactor(testKey, chainID)
+	addr, tx, err := bind.DeployContract(opts, code, sim.Client(), nil)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	sim.Commit()
+
+	receipt, err := bind.WaitMined(ctx, sim.Client(), tx.Hash())
+	if err != nil {
+		t.Fatalf("failed to wait for deployment: %v", err)
+	}
+	return addr, receipt, nil
+}
+
+func TestArbitrumVersionGating(t *testing.T) {
+	sim := newArbitrumBackend(t, ArbitrumConfig{ArbOSVersion: params.ArbosVersion_32})
+
+	addr, receipt, err := deploy(t, sim, push0Code)
+	if err != nil {
+		t.Fatalf("failed to deploy: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatal("deployment failed")
+	}
+	if want := DefaultL1Pricing.PerTxGas + DefaultL1Pricing.PerByteGas*uint64(len(push0Code)); receipt.GasUsedForL1 != want {
+		t.Errorf("gas used for L1 mismatch: have %d, want %d", receipt.GasUsedForL1, want)
+	}
+	out, err := sim.Client().CallContract(context.Background(), ethereum.CallMsg{To: &addr}, nil)
+	if err != nil {
+		t.Fatalf("failed to call: %v", err)
+	}
+	if new(big.Int).SetBytes(out).Uint64() != 42 {
+		t.Errorf("unexpected result: %x", out)
+	}
+	header, err := sim.Client().HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := types.DeserializeHeaderExtraInformation(header).ArbOSFormatVersion; v != params.ArbosVersion_32 {
+		t.Errorf("header ArbOS version mismatch: have %d, want %d", v, params.ArbosVersion_32)
+	}
+
+	// Before ArbOS 11 Shanghai isn't active.
+	old := newArbitrumBackend(t, ArbitrumConfig{ArbOSVersion: params.ArbosVersion_10})
+	if _, _, err := deploy(t, old, push0Code); err == nil {
+		t.Fatal("deployed PUSH0 code before ArbOS 11")
+	}
+}
+
+func TestArbitrumDeposit(t *testing.T) {
+	sim := newArbitrumBackend(t, ArbitrumConfig{})
+	ctx := context.Background()
+
+	var (
+		to    = common.Address{0x01}
+		value = big.NewInt(params.Ether)
+	)
+	hash, err := sim.SendDeposit(common.Address{0xff}, to, value)
+	if err != nil {
+		t.Fatalf("failed to send deposit: %v", err)
+	}
+	sim.Commit()
+
+	if balance, _ := sim.Client().BalanceAt(ctx, to, nil); balance.Cmp(value) != 0 {
+		t.Errorf("balance mismatch: have %v, want %v", balance, value)
+	}
+	receipt, err := sim.Client().TransactionReceipt(ctx, hash)
+	if err != nil {
+		t.Fatalf("failed to get deposit receipt: %v", err)
+	}
+	if receipt.Type != types.ArbitrumDepositTxType || receipt.Status != types.ReceiptStatusSuccessful {
+		t.Errorf("unexpected receipt: type %d, status %d", receipt.Type, receipt.Status)
+	}
+	plain := NewBackend(types.GenesisAlloc{})
+	defer plain.Close()
+	if _, err := plain.SendDeposit(common.Address{}, to, value); err != errNotArbitrum {
+		t.Errorf("unexpected error on non-Arbitrum backend: %v", err)
+	}
+}
+
+func TestArbitrumRetryable(t *testing.T) {
+	sim := newArbitrumBackend(t, ArbitrumConfig{})
+	ctx := context.Background()
+
+	var (
+		from  = common.Address{0xaa}
+		to    = common.Address{0x02}
+		value = big.NewInt(params.GWei)
+	)
+	head, _ := sim.Client().HeaderByNumber(ctx, nil)
+	ticket, err := sim.SubmitRetryable(Retryable{
+		From:      from,
+		To:        &to,
+		Value:     value,
+		Deposit:   big.NewInt(params.Ether),
+		Gas:       100000,
+		GasFeeCap: new(big.Int).Mul(head.BaseFee, big.NewInt(2)),
+	})
+	if err != nil {
+		t.Fatalf("failed to submit retryable: %v", err)
+	}
+	sim.Commit()
+
+	if balance, _ := sim.Client().BalanceAt(ctx, to, nil); balance.Cmp(value) != 0 {
+		t.Errorf("balance mismatch: have %v, want %v", balance, value)
+	}
+	block := sim.arbitrum.eth.BlockChain().CurrentBlock()
+	txs := sim.arbitrum.eth.BlockChain().GetBlockByHash(block.Hash()).Transactions()
+	if len(txs) != 2 || txs[0].Hash() != ticket {
+		t.Fatalf("unexpected block transactions: %v", txs)
+	}
+	retry, ok := txs[1].GetInner().(*types.ArbitrumRetryTx)
+	if !ok || retry.TicketId != ticket {
+		t.Fatalf("retry not sequenced after submission: %v", txs[1].GetInner())
+	}
+	receipt, err := sim.Client().TransactionReceipt(ctx, txs[1].Hash())
+	if err != nil {
+		t.Fatalf("failed to get retry receipt: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful || receipt.GasUsedForL1 != 0 {
+		t.Errorf("unexpected retry receipt: status %d, gas used for L1 %d", receipt.Status, receipt.GasUsedForL1)
+	}
+}
+
+// constantL1BlockNumber is a custom hook reporting a fixed parent chain block.
+type constantL1BlockNumber struct {
+	*TxProcessor
+}
+
+func (p constantL1BlockNumber) L1BlockNumber(vm.BlockContext) (uint64, error) {
+	return 1234, nil
+}
+
+func TestArbitrumL1BlockNumber(t *testing.T) {
+	tests := []struct {
+		config ArbitrumConfig
+		want   uint64
+	}{
+		{ArbitrumConfig{}, 100},
+		{ArbitrumConfig{TxProcessor: func(evm *vm.EVM, msg *core.Message, defaults *TxProcessor) vm.TxProcessingHook {
+			return constantL1BlockNumber{defaults}
+		}}, 1234},
+	}
+	for i, tt := range tests {
+		sim := newArbitrumBackend(t, tt.config)
+		if err := sim.SetL1BlockNumber(100); err != nil {
+			t.Fatal(err)
+		}
+		addr, _, err := deploy(t, sim, numberCode)
+		if err != nil {
+			t.Fatalf("test %d: failed to deploy: %v", i, err)
+		}
+		out, err := sim.Client().CallContract(context.Background(), ethereum.CallMsg{To: &addr}, nil)
+		if err != nil {
+			t.Fatalf("test %d: failed to call: %v", i, err)
+		}
+		if have := new(big.Int).SetBytes(out).Uint64(); have != tt.want {
+			t.Errorf("test %d: block number mismatch: have %d, want %d (%s)", i, have, tt.want, hexutil.Bytes(out))
+		}
+	}
+}
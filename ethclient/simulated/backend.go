@@ -29,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/params"
@@ -62,9 +63,10 @@ type simClient struct {
 // Backend is a simulated blockchain. You can use it to test your contracts or
 // other code that interacts with the Ethereum chain.
 type Backend struct {
-	node   *node.Node
-	beacon *catalyst.SimulatedBeacon
-	client simClient
+	node     *node.Node
+	beacon   *catalyst.SimulatedBeacon
+	arbitrum *arbitrumChain // Block production of the Arbitrum mode, if enabled
+	client   simClient
 }
 
 // NewBackend creates a new simulated blockchain that can be used as a backend for
@@ -129,9 +131,10 @@ func newWithNode(stack *node.Node, conf *eth.Config, blockPeriod uint64) (*Backe
 		return nil, err
 	}
 	return &Backend{
-		node:   stack,
-		beacon: beacon,
-		client: simClient{ethclient.NewClient(stack.Attach())},
+		node:     stack,
+		beacon:   beacon,
+		arbitrum: newArbitrumChain(backend),
+		client:   simClient{ethclient.NewClient(stack.Attach())},
 	}, nil
 }
 
@@ -142,6 +145,7 @@ func (n *Backend) Close() error {
 		n.client.Close()
 		n.client = simClient{}
 	}
+	n.arbitrum = nil
 	var err error
 	if n.beacon != nil {
 		err = n.beacon.Stop()
@@ -156,11 +160,20 @@ func (n *Backend) Close() error {
 
 // Commit seals a block and moves the chain forward to a new empty block.
 func (n *Backend) Commit() common.Hash {
+	if n.arbitrum != nil {
+		if err := n.arbitrum.produceBlock(uint64(time.Now().Unix())); err != nil {
+			log.Warn("Error producing block", "err", err)
+		}
+		return n.arbitrum.eth.BlockChain().CurrentBlock().Hash()
+	}
 	return n.beacon.Commit()
 }
 
 // Rollback removes all pending transactions, reverting to the last committed state.
 func (n *Backend) Rollback() {
+	if n.arbitrum != nil {
+		n.arbitrum.rollback()
+	}
 	n.beacon.Rollback()
 }
 
@@ -183,6 +196,9 @@ func (n *Backend) Fork(parentHash common.Hash) error {
 // AdjustTime changes the block timestamp and creates a new block.
 // It can only be called on empty blocks.
 func (n *Backend) AdjustTime(adjustment time.Duration) error {
+	if n.arbitrum != nil {
+		return n.arbitrum.adjustTime(adjustment)
+	}
 	return n.beacon.AdjustTime(adjustment)
 }
 
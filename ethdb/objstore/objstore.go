@@ -0,0 +1,84 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package objstore implements a minimal object store abstraction used for
+// offloading immutable ancient data files. Two backends are provided: a local
+// filesystem directory and an S3-compatible HTTP service (AWS S3, MinIO etc).
+package objstore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// ErrNotFound is returned if the requested object does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// Store is a flat key/value object store for large immutable blobs.
+type Store interface {
+	// Put uploads the content of the reader under the given key, replacing
+	// any existing object. The size of the content is known in advance.
+	Put(key string, r io.Reader, size int64) error
+
+	// Get returns a reader for the object with the given key. The caller is
+	// responsible for closing it. ErrNotFound is returned for missing objects.
+	Get(key string) (io.ReadCloser, error)
+
+	// Delete removes the object with the given key. Deleting a non-existent
+	// object is not an error.
+	Delete(key string) error
+
+	// List returns the keys of all objects starting with the given prefix.
+	List(prefix string) ([]string, error)
+}
+
+// Open creates a store from the given location. Supported formats are:
+//
+//	file:///path/to/dir                      local directory
+//	/path/to/dir                             local directory
+//	s3://bucket/prefix?endpoint=URL&region=R S3-compatible service
+//
+// For S3 stores, the credentials are taken from the AWS_ACCESS_KEY_ID and
+// AWS_SECRET_ACCESS_KEY environment variables.
+func Open(location string) (Store, error) {
+	if !strings.Contains(location, "://") {
+		return NewFileStore(location)
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "file":
+		return NewFileStore(u.Path)
+	case "s3":
+		q := u.Query()
+		return NewS3Store(S3Config{
+			Endpoint:  q.Get("endpoint"),
+			Region:    q.Get("region"),
+			Bucket:    u.Host,
+			Prefix:    strings.TrimPrefix(u.Path, "/"),
+			AccessKey: os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		})
+	default:
+		return nil, fmt.Errorf("unsupported object store scheme %q", u.Scheme)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package objstore
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testS3Server is a minimal in-memory S3 stand-in supporting path-style
+// object requests and ListObjectsV2.
+type testS3Server struct {
+	lock    sync.Mutex
+	bucket  string
+	objects map[string][]byte
+}
+
+func newTestS3Server(t *testing.T, bucket string) *httptest.Server {
+	s := &testS3Server{bucket: bucket, objects: make(map[string][]byte)}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func (s *testS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+		http.Error(w, "missing signature", http.StatusForbidden)
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != s.bucket {
+		http.Error(w, "no such bucket", http.StatusNotFound)
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && key == "" && r.URL.Query().Get("list-type") == "2":
+		type content struct {
+			Key string `xml:"Key"`
+		}
+		var result struct {
+			XMLName     xml.Name  `xml:"ListBucketResult"`
+			Contents    []content `xml:"Contents"`
+			IsTruncated bool      `xml:"IsTruncated"`
+		}
+		prefix := r.URL.Query().Get("prefix")
+		for k := range s.objects {
+			if strings.HasPrefix(k, prefix) {
+				result.Contents = append(result.Contents, content{Key: k})
+			}
+		}
+		xml.NewEncoder(w).Encode(&result)
+	case r.Method == http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.objects[key] = data
+	case r.Method == http.MethodGet:
+		data, ok := s.objects[key]
+		if !ok {
+			http.Error(w, "no such key", http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	case r.Method == http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "unsupported", http.StatusMethodNotAllowed)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, store)
+}
+
+func TestS3Store(t *testing.T) {
+	srv := newTestS3Server(t, "ancient")
+	store, err := Open("s3://ancient/node1?endpoint=" + srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, store)
+}
+
+func testStore(t *testing.T, store Store) {
+	objects := map[string][]byte{
+		"chain/headers.0000.cdat": bytes.Repeat([]byte{1}, 1000),
+		"chain/headers.0001.cdat": bytes.Repeat([]byte{2}, 10),
+		"state/history.0000.rdat": {},
+	}
+	for key, data := range objects {
+		if err := store.Put(key, bytes.NewReader(data), int64(len(data))); err != nil {
+			t.Fatalf("Failed to put %s: %v", key, err)
+		}
+	}
+	for key, data := range objects {
+		r, err := store.Get(key)
+		if err != nil {
+			t.Fatalf("Failed to get %s: %v", key, err)
+		}
+		got, err := io.ReadAll(r)
+		r.Close()
+		if err != nil || !bytes.Equal(got, data) {
+			t.Fatalf("Content mismatch for %s: %v", key, err)
+		}
+	}
+	if _, err := store.Get("chain/missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound for missing object, got %v", err)
+	}
+	keys, err := store.List("chain/")
+	if err != nil {
+		t.Fatalf("Failed to list objects: %v", err)
+	}
+	slices.Sort(keys)
+	if !slices.Equal(keys, []string{"chain/headers.0000.cdat", "chain/headers.0001.cdat"}) {
+		t.Fatalf("Unexpected object list: %v", keys)
+	}
+	if err := store.Delete("chain/headers.0000.cdat"); err != nil {
+		t.Fatalf("Failed to delete object: %v", err)
+	}
+	if err := store.Delete("chain/headers.0000.cdat"); err != nil {
+		t.Fatalf("Failed to delete missing object: %v", err)
+	}
+	if keys, _ := store.List("chain/"); !slices.Equal(keys, []string{"chain/headers.0001.cdat"}) {
+		t.Fatalf("Unexpected object list after deletion: %v", keys)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package objstore
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// unsignedPayload is used as the payload hash of all requests, which allows
+// streaming uploads without hashing the data twice.
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3Config contains the settings of an S3-compatible object store.
+type S3Config struct {
+	Endpoint  string // service endpoint, e.g. http://127.0.0.1:9000 (default: AWS regional endpoint)
+	Region    string // signing region (default: us-east-1)
+	Bucket    string // bucket name
+	Prefix    string // key prefix inside the bucket
+	AccessKey string
+	SecretKey string
+	Client    *http.Client // optional HTTP client
+}
+
+// S3Store is an object store talking to an S3-compatible service using
+// path-style requests, which are supported by AWS S3, MinIO and most
+// S3 stand-ins.
+type S3Store struct {
+	endpoint *url.URL
+	config   S3Config
+	signer   *v4.Signer
+	client   *http.Client
+}
+
+// NewS3Store creates a client for the given S3-compatible service.
+func NewS3Store(config S3Config) (*S3Store, error) {
+	if config.Bucket == "" {
+		return nil, errors.New("missing S3 bucket name")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", config.Region)
+	}
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint: %v", err)
+	}
+	client := config.Client
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &S3Store{
+		endpoint: endpoint,
+		config:   config,
+		signer: v4.NewSigner(func(o *v4.SignerOptions) {
+			o.DisableURIPathEscaping = true
+		}),
+		client: client,
+	}, nil
+}
+
+// objectURL returns the path-style URL of the given key.
+func (s *S3Store) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	p := path.Join("/", s.config.Bucket, s.config.Prefix, key)
+	u.Path = p
+	u.RawPath = (&url.URL{Path: p}).EscapedPath()
+	return &u
+}
+
+// do signs and sends the given request.
+func (s *S3Store) do(req *http.Request) (*http.Response, error) {
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	creds := aws.Credentials{AccessKeyID: s.config.AccessKey, SecretAccessKey: s.config.SecretKey}
+	if err := s.signer.SignHTTP(context.Background(), creds, req, unsignedPayload, "s3", s.config.Region, time.Now()); err != nil {
+		return nil, err
+	}
+	return s.client.Do(req)
+}
+
+// responseError converts an unexpected response into an error.
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("S3 request failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
+
+// Put implements Store.
+func (s *S3Store) Put(key string, r io.Reader, size int64) error {
+	req, err := http.NewRequest(http.MethodPut, s.objectURL(key).String(), io.NopCloser(r))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+	return nil
+}
+
+// Get implements Store.
+func (s *S3Store) Get(key string) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, s.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, responseError(resp)
+	}
+}
+
+// Delete implements Store.
+func (s *S3Store) Delete(key string) error {
+	req, err := http.NewRequest(http.MethodDelete, s.objectURL(key).String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return responseError(resp)
+	}
+	return nil
+}
+
+// listResult is the relevant subset of a ListObjectsV2 response.
+type listResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List implements Store.
+func (s *S3Store) List(prefix string) ([]string, error) {
+	var (
+		keys       []string
+		fullPrefix = strings.TrimPrefix(path.Join(s.config.Prefix, prefix), "/")
+		token      string
+	)
+	if strings.HasSuffix(prefix, "/") && !strings.HasSuffix(fullPrefix, "/") {
+		fullPrefix += "/"
+	}
+	for {
+		u := *s.endpoint
+		u.Path = path.Join("/", s.config.Bucket) + "/"
+		q := url.Values{"list-type": {"2"}, "prefix": {fullPrefix}}
+		if token != "" {
+			q.Set("continuation-token", token)
+		}
+		u.RawQuery = q.Encode()
+		req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			err := responseError(resp)
+			resp.Body.Close()
+			return nil, err
+		}
+		var result listResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range result.Contents {
+			key := c.Key
+			if s.config.Prefix != "" {
+				key = strings.TrimPrefix(strings.TrimPrefix(key, strings.Trim(s.config.Prefix, "/")), "/")
+			}
+			keys = append(keys, key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
@@ -22,6 +22,7 @@ package pebble
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"runtime"
 	"sync"
 	"sync/atomic"
@@ -29,6 +30,7 @@ import (
 
 	"github.com/cockroachdb/pebble"
 	"github.com/cockroachdb/pebble/bloom"
+	"github.com/cockroachdb/pebble/vfs"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
@@ -167,6 +169,35 @@ func (l panicLogger) Fatalf(format string, args ...interface{}) {
 // New returns a wrapped pebble DB object. The namespace is the prefix that the
 // metrics reporting should use for surfacing internal stats.
 func New(file string, cache int, handles int, namespace string, readonly bool, ephemeral bool, extraOptions *ExtraOptions) (*Database, error) {
+	return open(file, cache, handles, namespace, readonly, ephemeral, extraOptions, vfs.Default)
+}
+
+// NewSecondary opens a database owned by another process in read-only mode.
+// The directory lock is not acquired, so the database is opened even if the
+// primary instance is running. The returned database is a snapshot of the
+// state at the time of opening; it has to be reopened to observe new writes.
+//
+// The primary might delete the table files which haven't been opened by the
+// secondary yet, failing the reads touching them. Such errors are transient,
+// reopening the database resolves them.
+func NewSecondary(file string, cache int, handles int, namespace string, extraOptions *ExtraOptions) (*Database, error) {
+	return open(file, cache, handles, namespace, true, false, extraOptions, noLockFS{vfs.Default})
+}
+
+// noLockFS is a file system which doesn't lock the database directory.
+type noLockFS struct {
+	vfs.FS
+}
+
+func (noLockFS) Lock(name string) (io.Closer, error) {
+	return noopCloser{}, nil
+}
+
+type noopCloser struct{}
+
+func (noopCloser) Close() error { return nil }
+
+func open(file string, cache int, handles int, namespace string, readonly bool, ephemeral bool, extraOptions *ExtraOptions, fs vfs.FS) (*Database, error) {
 	if extraOptions == nil {
 		extraOptions = &ExtraOptions{}
 	}
@@ -266,6 +297,7 @@ func New(file string, cache int, handles int, namespace string, readonly bool, e
 		// extraOptions for the last level are used for all subsequent levels.
 		Levels:   levels,
 		ReadOnly: readonly,
+		FS:       fs,
 		EventListener: &pebble.EventListener{
 			CompactionBegin: db.onCompactionBegin,
 			CompactionEnd:   db.onCompactionEnd,
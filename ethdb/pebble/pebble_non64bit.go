@@ -11,3 +11,7 @@ import (
 func New(file string, cache int, handles int, namespace string, readonly bool, ephemeral bool, extraOptions *ExtraOptions) (ethdb.Database, error) {
 	return nil, errors.New("pebble is not supported on this platform")
 }
+
+func NewSecondary(file string, cache int, handles int, namespace string, extraOptions *ExtraOptions) (ethdb.Database, error) {
+	return nil, errors.New("pebble is not supported on this platform")
+}
@@ -56,3 +56,48 @@ func BenchmarkPebbleDB(b *testing.B) {
 		}
 	})
 }
+
+func TestPebbleSecondary(t *testing.T) {
+	dir := t.TempDir()
+	primary, err := New(dir, 16, 16, "", false, true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer primary.Close()
+
+	// The writes are made durable explicitly, as the unsynced WAL writes
+	// of the primary are only flushed in the background.
+	if err := primary.Put([]byte("a"), []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := primary.db.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	// The secondary should open despite the lock held by the primary
+	secondary, err := NewSecondary(dir, 16, 16, "", nil)
+	if err != nil {
+		t.Fatalf("failed to open secondary: %v", err)
+	}
+	if val, err := secondary.Get([]byte("a")); err != nil || string(val) != "1" {
+		t.Fatalf("unexpected value: %q, %v", val, err)
+	}
+	if err := secondary.Put([]byte("b"), []byte("2")); err == nil {
+		t.Fatal("write to secondary succeeded")
+	}
+	// New writes of the primary are visible after reopening the secondary
+	if err := primary.Put([]byte("b"), []byte("2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := primary.db.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	secondary.Close()
+	secondary, err = NewSecondary(dir, 16, 16, "", nil)
+	if err != nil {
+		t.Fatalf("failed to reopen secondary: %v", err)
+	}
+	defer secondary.Close()
+	if val, err := secondary.Get([]byte("b")); err != nil || string(val) != "2" {
+		t.Fatalf("unexpected value: %q, %v", val, err)
+	}
+}
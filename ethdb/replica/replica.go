@@ -0,0 +1,404 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package replica implements a read-only database following a database which
+// is written by another process.
+//
+// The replica opens the primary's key-value store and freezer as secondary
+// instances. Such an instance only observes the data which was persisted at the
+// time it was opened, so the replica periodically opens a fresh instance and
+// swaps it in. Readers which are still using the previous instance keep it alive
+// until they are done; it is closed afterwards.
+//
+// The replica never modifies the primary's data. Key-value writes issued by the
+// services of the replica node, like unclean shutdown markers, are discarded,
+// while modifications of the ancient store are rejected.
+//
+// Only data persisted by the primary is visible. Recent state held in memory by
+// the primary, e.g. the dirty trie nodes or path-scheme diff layers, can not be
+// accessed, so primaries serving replicas should commit every state (archive
+// mode) to make state queries available at the chain head.
+package replica
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// errClosed is returned if the replica is accessed after it was closed.
+	errClosed = errors.New("replica database closed")
+
+	// errReadOnly is returned for any attempt to modify the ancient store.
+	errReadOnly = errors.New("replica database is read-only")
+)
+
+// OpenFunc opens a new read-only instance of the primary database.
+type OpenFunc func() (ethdb.Database, error)
+
+// CatchUpEvent is posted after the replica has switched to a fresh instance
+// of the primary database.
+type CatchUpEvent struct {
+	Time time.Time
+}
+
+// generation is a single opened instance of the primary database, reference
+// counted by the readers currently using it.
+type generation struct {
+	db   ethdb.Database
+	refs sync.WaitGroup
+}
+
+// Database is a read-only database following a primary database. All methods
+// are forwarded to the most recently opened instance.
+type Database struct {
+	open OpenFunc
+
+	lock    sync.RWMutex
+	current *generation
+	closed  bool
+
+	catchUpFeed event.Feed
+	quit        chan struct{}
+	wg          sync.WaitGroup // tracks the follow loop and retiring generations
+}
+
+// New opens the primary database and returns a replica following it.
+func New(open OpenFunc) (*Database, error) {
+	db, err := open()
+	if err != nil {
+		return nil, err
+	}
+	return &Database{
+		open:    open,
+		current: &generation{db: db},
+		quit:    make(chan struct{}),
+	}, nil
+}
+
+// acquire returns the current generation, pinning it until the caller releases
+// its reference.
+func (db *Database) acquire() (*generation, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, errClosed
+	}
+	db.current.refs.Add(1)
+	return db.current, nil
+}
+
+// CatchUp opens a fresh instance of the primary database and swaps it in. The
+// previous instance is closed once all its readers are done.
+func (db *Database) CatchUp() error {
+	fresh, err := db.open()
+	if err != nil {
+		return err
+	}
+	db.lock.Lock()
+	if db.closed {
+		db.lock.Unlock()
+		return fresh.Close()
+	}
+	old := db.current
+	db.current = &generation{db: fresh}
+	db.wg.Add(1)
+	db.lock.Unlock()
+
+	go func() {
+		defer db.wg.Done()
+		old.refs.Wait()
+		if err := old.db.Close(); err != nil {
+			log.Warn("Failed to close stale replica instance", "err", err)
+		}
+	}()
+	db.catchUpFeed.Send(CatchUpEvent{Time: time.Now()})
+	return nil
+}
+
+// Follow starts catching up with the primary database in the given interval.
+// The loop is terminated when the database is closed.
+func (db *Database) Follow(interval time.Duration) {
+	db.wg.Add(1)
+	go func() {
+		defer db.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := db.CatchUp(); err != nil {
+					log.Warn("Failed to catch up with primary database", "err", err)
+				}
+			case <-db.quit:
+				return
+			}
+		}
+	}()
+}
+
+// SubscribeCatchUp registers a subscription for the events posted after the
+// replica switched to a fresh instance of the primary database.
+func (db *Database) SubscribeCatchUp(ch chan<- CatchUpEvent) event.Subscription {
+	return db.catchUpFeed.Subscribe(ch)
+}
+
+// Close stops following the primary and closes the database once all pending
+// readers are done.
+func (db *Database) Close() error {
+	db.lock.Lock()
+	if db.closed {
+		db.lock.Unlock()
+		return nil
+	}
+	db.closed = true
+	close(db.quit)
+	current := db.current
+	db.lock.Unlock()
+
+	db.wg.Wait()
+	current.refs.Wait()
+	return current.db.Close()
+}
+
+// Has retrieves if a key is present in the key-value data store.
+func (db *Database) Has(key []byte) (bool, error) {
+	gen, err := db.acquire()
+	if err != nil {
+		return false, err
+	}
+	defer gen.refs.Done()
+	return gen.db.Has(key)
+}
+
+// Get retrieves the given key if it's present in the key-value data store.
+func (db *Database) Get(key []byte) ([]byte, error) {
+	gen, err := db.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer gen.refs.Done()
+	return gen.db.Get(key)
+}
+
+// NewIterator creates a binary-alphabetical iterator over a subset of database
+// content with a particular key prefix, starting at a particular initial key.
+// The iterator pins the instance it was created on until it is released.
+func (db *Database) NewIterator(prefix []byte, start []byte) ethdb.Iterator {
+	gen, err := db.acquire()
+	if err != nil {
+		return &errIterator{err: err}
+	}
+	return &iterator{Iterator: gen.db.NewIterator(prefix, start), gen: gen}
+}
+
+// Stat returns the statistic data of the current instance.
+func (db *Database) Stat() (string, error) {
+	gen, err := db.acquire()
+	if err != nil {
+		return "", err
+	}
+	defer gen.refs.Done()
+	return gen.db.Stat()
+}
+
+// HasAncient returns an indicator whether the specified data exists in the
+// ancient store.
+func (db *Database) HasAncient(kind string, number uint64) (bool, error) {
+	gen, err := db.acquire()
+	if err != nil {
+		return false, err
+	}
+	defer gen.refs.Done()
+	return gen.db.HasAncient(kind, number)
+}
+
+// Ancient retrieves an ancient binary blob from the append-only immutable files.
+func (db *Database) Ancient(kind string, number uint64) ([]byte, error) {
+	gen, err := db.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer gen.refs.Done()
+	return gen.db.Ancient(kind, number)
+}
+
+// AncientRange retrieves multiple items in sequence, starting from the index 'start'.
+func (db *Database) AncientRange(kind string, start, count, maxBytes uint64) ([][]byte, error) {
+	gen, err := db.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer gen.refs.Done()
+	return gen.db.AncientRange(kind, start, count, maxBytes)
+}
+
+// Ancients returns the ancient item numbers in the ancient store.
+func (db *Database) Ancients() (uint64, error) {
+	gen, err := db.acquire()
+	if err != nil {
+		return 0, err
+	}
+	defer gen.refs.Done()
+	return gen.db.Ancients()
+}
+
+// Tail returns the number of first stored item in the ancient store.
+func (db *Database) Tail() (uint64, error) {
+	gen, err := db.acquire()
+	if err != nil {
+		return 0, err
+	}
+	defer gen.refs.Done()
+	return gen.db.Tail()
+}
+
+// AncientSize returns the ancient size of the specified category.
+func (db *Database) AncientSize(kind string) (uint64, error) {
+	gen, err := db.acquire()
+	if err != nil {
+		return 0, err
+	}
+	defer gen.refs.Done()
+	return gen.db.AncientSize(kind)
+}
+
+// ReadAncients runs the given read operation against a single instance.
+func (db *Database) ReadAncients(fn func(ethdb.AncientReaderOp) error) error {
+	gen, err := db.acquire()
+	if err != nil {
+		return err
+	}
+	defer gen.refs.Done()
+	return gen.db.ReadAncients(fn)
+}
+
+// AncientDatadir returns the path of the ancient store directory.
+func (db *Database) AncientDatadir() (string, error) {
+	gen, err := db.acquire()
+	if err != nil {
+		return "", err
+	}
+	defer gen.refs.Done()
+	return gen.db.AncientDatadir()
+}
+
+// WasmDataBase returns the replica itself, the wasm store is not followed
+// separately.
+func (db *Database) WasmDataBase() ethdb.KeyValueStore {
+	return db
+}
+
+// Put discards the given key-value pair.
+func (db *Database) Put(key []byte, value []byte) error {
+	return nil
+}
+
+// Delete discards the deletion of the given key.
+func (db *Database) Delete(key []byte) error {
+	return nil
+}
+
+// DeleteRange discards the deletion of the given key range.
+func (db *Database) DeleteRange(start, end []byte) error {
+	return nil
+}
+
+// NewBatch returns a batch whose modifications are discarded.
+func (db *Database) NewBatch() ethdb.Batch {
+	return new(batch)
+}
+
+// NewBatchWithSize returns a batch whose modifications are discarded.
+func (db *Database) NewBatchWithSize(size int) ethdb.Batch {
+	return new(batch)
+}
+
+// Compact is a no-op, the database is compacted by the primary.
+func (db *Database) Compact(start []byte, limit []byte) error {
+	return nil
+}
+
+// ModifyAncients is not supported by the replica.
+func (db *Database) ModifyAncients(func(ethdb.AncientWriteOp) error) (int64, error) {
+	return 0, errReadOnly
+}
+
+// TruncateHead is not supported by the replica.
+func (db *Database) TruncateHead(n uint64) (uint64, error) {
+	return 0, errReadOnly
+}
+
+// TruncateTail is not supported by the replica.
+func (db *Database) TruncateTail(n uint64) (uint64, error) {
+	return 0, errReadOnly
+}
+
+// Sync is a no-op, the replica has no pending ancient data.
+func (db *Database) Sync() error {
+	return nil
+}
+
+// iterator releases the pinned instance together with the wrapped iterator.
+type iterator struct {
+	ethdb.Iterator
+	gen  *generation
+	once sync.Once
+}
+
+func (it *iterator) Release() {
+	it.Iterator.Release()
+	it.once.Do(it.gen.refs.Done)
+}
+
+// errIterator is an empty iterator reporting an error.
+type errIterator struct {
+	err error
+}
+
+func (it *errIterator) Next() bool    { return false }
+func (it *errIterator) Error() error  { return it.err }
+func (it *errIterator) Key() []byte   { return nil }
+func (it *errIterator) Value() []byte { return nil }
+func (it *errIterator) Release()      {}
+
+// batch discards all modifications.
+type batch struct {
+	size int
+}
+
+func (b *batch) Put(key []byte, value []byte) error {
+	b.size += len(key) + len(value)
+	return nil
+}
+
+func (b *batch) Delete(key []byte) error {
+	b.size += len(key)
+	return nil
+}
+
+func (b *batch) ValueSize() int                      { return b.size }
+func (b *batch) Write() error                        { return nil }
+func (b *batch) Reset()                              { b.size = 0 }
+func (b *batch) Replay(w ethdb.KeyValueWriter) error { return nil }
@@ -0,0 +1,159 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package replica
+
+import (
+	"bytes"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+// snapshotDB is a database snapshot which records whether it was closed.
+type snapshotDB struct {
+	ethdb.Database
+	closed atomic.Bool
+}
+
+func (db *snapshotDB) Close() error {
+	db.closed.Store(true)
+	return db.Database.Close()
+}
+
+// newTestReplica creates a replica whose instances are snapshots of the
+// returned primary database.
+func newTestReplica(t *testing.T) (*Database, *memorydb.Database, *[]*snapshotDB) {
+	primary := memorydb.New()
+	opened := new([]*snapshotDB)
+
+	open := func() (ethdb.Database, error) {
+		snap := memorydb.New()
+		it := primary.NewIterator(nil, nil)
+		for it.Next() {
+			snap.Put(it.Key(), it.Value())
+		}
+		it.Release()
+
+		db := &snapshotDB{Database: rawdb.NewDatabase(snap)}
+		*opened = append(*opened, db)
+		return db, nil
+	}
+	db, err := New(open)
+	if err != nil {
+		t.Fatalf("Failed to open replica: %v", err)
+	}
+	return db, primary, opened
+}
+
+func TestReplicaCatchUp(t *testing.T) {
+	db, primary, opened := newTestReplica(t)
+	defer db.Close()
+
+	primary.Put([]byte("a"), []byte("1"))
+	if has, _ := db.Has([]byte("a")); has {
+		t.Fatal("Replica observed data written after opening")
+	}
+	events := make(chan CatchUpEvent, 1)
+	sub := db.SubscribeCatchUp(events)
+	defer sub.Unsubscribe()
+
+	if err := db.CatchUp(); err != nil {
+		t.Fatalf("Failed to catch up: %v", err)
+	}
+	select {
+	case <-events:
+	case <-time.After(time.Second):
+		t.Fatal("Catch-up event not delivered")
+	}
+	if val, err := db.Get([]byte("a")); err != nil || !bytes.Equal(val, []byte("1")) {
+		t.Fatalf("Unexpected value after catch-up: %x, %v", val, err)
+	}
+	// The stale instance is closed in the background.
+	for i := 0; !(*opened)[0].closed.Load(); i++ {
+		if i == 100 {
+			t.Fatal("Stale instance was not closed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestReplicaIteratorPinsInstance(t *testing.T) {
+	db, primary, opened := newTestReplica(t)
+	defer db.Close()
+
+	primary.Put([]byte("a"), []byte("1"))
+	db.CatchUp()
+
+	it := db.NewIterator(nil, nil)
+	primary.Put([]byte("b"), []byte("2"))
+	db.CatchUp()
+
+	time.Sleep(50 * time.Millisecond)
+	if (*opened)[1].closed.Load() {
+		t.Fatal("Instance closed while an iterator was open")
+	}
+	var keys int
+	for it.Next() {
+		keys++
+	}
+	if keys != 1 {
+		t.Fatalf("Iterator observed %d keys, want 1", keys)
+	}
+	it.Release()
+
+	for i := 0; !(*opened)[1].closed.Load(); i++ {
+		if i == 100 {
+			t.Fatal("Instance was not closed after the iterator was released")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if has, _ := db.Has([]byte("b")); !has {
+		t.Fatal("Replica did not observe the latest data")
+	}
+}
+
+func TestReplicaReadOnly(t *testing.T) {
+	db, _, _ := newTestReplica(t)
+
+	// Key-value writes are discarded.
+	if err := db.Put([]byte("a"), []byte("1")); err != nil {
+		t.Fatalf("Failed to put: %v", err)
+	}
+	batch := db.NewBatch()
+	batch.Put([]byte("b"), []byte("2"))
+	if err := batch.Write(); err != nil {
+		t.Fatalf("Failed to write batch: %v", err)
+	}
+	for _, key := range []string{"a", "b"} {
+		if has, _ := db.Has([]byte(key)); has {
+			t.Fatalf("Discarded write of %q is visible", key)
+		}
+	}
+	// Modifications of the ancient store are rejected.
+	if _, err := db.ModifyAncients(func(ethdb.AncientWriteOp) error { return nil }); !errors.Is(err, errReadOnly) {
+		t.Fatalf("ModifyAncients error mismatch: have %v, want %v", err, errReadOnly)
+	}
+	db.Close()
+	if _, err := db.Get([]byte("a")); !errors.Is(err, errClosed) {
+		t.Fatalf("Get error mismatch after close: have %v, want %v", err, errClosed)
+	}
+}
@@ -0,0 +1,478 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethgrpc
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/ethgrpc/ethpb"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxTopics is the maximum number of topic positions of a log filter.
+	maxTopics = 4
+
+	// chainHeadChanSize is the size of the channel receiving chain head events.
+	chainHeadChanSize = 10
+
+	// maxQueuedHeads is the number of headers queued for a subscriber before it
+	// is considered too slow and dropped.
+	maxQueuedHeads = 256
+)
+
+var (
+	errTooSlow             = status.Error(codes.ResourceExhausted, "subscriber too slow")
+	errIndexing            = status.Error(codes.Unavailable, "transaction indexing is in progress")
+	errTooManyTopics       = status.Error(codes.InvalidArgument, "exceed max topics")
+	errInvalidRange        = status.Error(codes.InvalidArgument, "invalid block range")
+	errHashInRange         = status.Error(codes.InvalidArgument, "block range must not refer to blocks by hash")
+	errBlockNotFound       = status.Error(codes.NotFound, "block not found")
+	errReceiptNotFound     = status.Error(codes.NotFound, "receipt not found")
+	errInvalidBlockHash    = status.Error(codes.InvalidArgument, "invalid block hash")
+	errBlockNumberTooLarge = status.Error(codes.InvalidArgument, "block number too large")
+)
+
+// ethServer implements ethpb.EthServer on top of an ethapi.Backend.
+type ethServer struct {
+	ethpb.UnimplementedEthServer
+
+	backend      ethapi.Backend
+	filterSystem *filters.FilterSystem
+}
+
+// GetBlock implements ethpb.EthServer.
+func (s *ethServer) GetBlock(ctx context.Context, req *ethpb.GetBlockRequest) (*ethpb.Block, error) {
+	id, err := blockNumberOrHash(req.GetBlock())
+	if err != nil {
+		return nil, err
+	}
+	block, err := s.backend.BlockByNumberOrHash(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errBlockNotFound
+	}
+	res := &ethpb.Block{Header: newHeader(block.Header())}
+	for _, tx := range block.Transactions() {
+		res.TransactionHashes = append(res.TransactionHashes, tx.Hash().Bytes())
+		if req.GetFullTransactions() {
+			enc, err := tx.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			res.Transactions = append(res.Transactions, enc)
+		}
+	}
+	return res, nil
+}
+
+// GetBlockReceipts implements ethpb.EthServer.
+func (s *ethServer) GetBlockReceipts(ctx context.Context, req *ethpb.GetBlockReceiptsRequest) (*ethpb.GetBlockReceiptsResponse, error) {
+	id, err := blockNumberOrHash(req.GetBlock())
+	if err != nil {
+		return nil, err
+	}
+	block, err := s.backend.BlockByNumberOrHash(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errBlockNotFound
+	}
+	receipts, err := s.backend.GetReceipts(ctx, block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	txs := block.Transactions()
+	if len(txs) != len(receipts) {
+		return nil, status.Errorf(codes.Internal, "receipts length mismatch: %d vs %d", len(receipts), len(txs))
+	}
+	header := block.Header()
+	signer := s.signer(header)
+	res := &ethpb.GetBlockReceiptsResponse{Receipts: make([]*ethpb.Receipt, len(receipts))}
+	for i, receipt := range receipts {
+		res.Receipts[i] = s.newReceipt(receipt, txs[i], header, signer)
+	}
+	return res, nil
+}
+
+// GetTransactionReceipt implements ethpb.EthServer.
+func (s *ethServer) GetTransactionReceipt(ctx context.Context, req *ethpb.GetTransactionReceiptRequest) (*ethpb.Receipt, error) {
+	found, tx, blockHash, _, index := s.backend.GetTransaction(common.BytesToHash(req.GetTransactionHash()))
+	if !found {
+		if !s.backend.TxIndexDone() {
+			return nil, errIndexing
+		}
+		return nil, errReceiptNotFound
+	}
+	header, err := s.backend.HeaderByHash(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := s.backend.GetReceipts(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil || uint64(len(receipts)) <= index {
+		return nil, errReceiptNotFound
+	}
+	return s.newReceipt(receipts[index], tx, header, s.signer(header)), nil
+}
+
+// GetLogs implements ethpb.EthServer.
+func (s *ethServer) GetLogs(ctx context.Context, req *ethpb.GetLogsRequest) (*ethpb.GetLogsResponse, error) {
+	if len(req.GetTopics()) > maxTopics {
+		return nil, errTooManyTopics
+	}
+	addresses := make([]common.Address, len(req.GetAddresses()))
+	for i, addr := range req.GetAddresses() {
+		addresses[i] = common.BytesToAddress(addr)
+	}
+	topics := make([][]common.Hash, len(req.GetTopics()))
+	for i, alternatives := range req.GetTopics() {
+		for _, topic := range alternatives.GetTopics() {
+			topics[i] = append(topics[i], common.BytesToHash(topic))
+		}
+	}
+
+	var filter *filters.Filter
+	if hash := req.GetBlockHash(); len(hash) > 0 {
+		if len(hash) != common.HashLength || req.GetFromBlock() != nil || req.GetToBlock() != nil {
+			return nil, errInvalidBlockHash
+		}
+		filter = s.filterSystem.NewBlockFilter(common.BytesToHash(hash), addresses, topics)
+	} else {
+		begin, err := blockNumber(req.GetFromBlock())
+		if err != nil {
+			return nil, err
+		}
+		end, err := blockNumber(req.GetToBlock())
+		if err != nil {
+			return nil, err
+		}
+		if begin > 0 && end > 0 && begin > end {
+			return nil, errInvalidRange
+		}
+		if err := s.filterSystem.CheckPruned(begin.Int64()); err != nil {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		filter = s.filterSystem.NewRangeFilter(begin.Int64(), end.Int64(), addresses, topics)
+	}
+	logs, err := filter.Logs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := &ethpb.GetLogsResponse{Logs: make([]*ethpb.Log, len(logs))}
+	for i, log := range logs {
+		res.Logs[i] = newLog(log)
+	}
+	return res, nil
+}
+
+// Call implements ethpb.EthServer.
+func (s *ethServer) Call(ctx context.Context, req *ethpb.CallRequest) (*ethpb.CallResponse, error) {
+	id, err := blockNumberOrHash(req.GetBlock())
+	if err != nil {
+		return nil, err
+	}
+	var args ethapi.TransactionArgs
+	if from := req.GetFrom(); len(from) > 0 {
+		args.From = addressPtr(from)
+	}
+	if to := req.GetTo(); len(to) > 0 {
+		args.To = addressPtr(to)
+	}
+	if req.Gas != nil {
+		args.Gas = (*hexutil.Uint64)(req.Gas)
+	}
+	args.GasPrice = bigPtr(req.GetGasPrice())
+	args.MaxFeePerGas = bigPtr(req.GetMaxFeePerGas())
+	args.MaxPriorityFeePerGas = bigPtr(req.GetMaxPriorityFeePerGas())
+	args.Value = bigPtr(req.GetValue())
+	if data := req.GetData(); len(data) > 0 {
+		args.Input = (*hexutil.Bytes)(&data)
+	}
+	result, err := ethapi.DoCall(ctx, s.backend, args, id, nil, nil, s.backend.RPCEVMTimeout(), s.backend.RPCGasCap(), core.NewMessageEthcallContext())
+	if err != nil {
+		if client := ethapi.FallbackClientFor(s.backend, err); client != nil {
+			return forwardCall(ctx, client, args, id)
+		}
+		return nil, err
+	}
+	res := &ethpb.CallResponse{ReturnData: result.ReturnData, GasUsed: result.UsedGas}
+	if result.Err != nil {
+		res.Error = result.Err.Error()
+	}
+	return res, nil
+}
+
+// forwardCall executes the call as eth_call on the node holding the state the
+// local node lacks, i.e. the classic node or an archive node. Execution errors
+// reported by that node are returned in the response like those of local calls.
+// The gas used is not known for forwarded calls.
+func forwardCall(ctx context.Context, client types.FallbackClient, args ethapi.TransactionArgs, id rpc.BlockNumberOrHash) (*ethpb.CallResponse, error) {
+	var output hexutil.Bytes
+	err := client.CallContext(ctx, &output, "eth_call", args, id)
+	if err == nil {
+		return &ethpb.CallResponse{ReturnData: output}, nil
+	}
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return nil, status.Errorf(codes.Unavailable, "fallback node unavailable: %v", err)
+	}
+	res := &ethpb.CallResponse{Error: err.Error()}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			res.ReturnData = common.FromHex(data)
+		}
+	}
+	return res, nil
+}
+
+// SendRawTransaction implements ethpb.EthServer.
+func (s *ethServer) SendRawTransaction(ctx context.Context, req *ethpb.SendRawTransactionRequest) (*ethpb.SendRawTransactionResponse, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(req.GetTransaction()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	hash, err := ethapi.SubmitTransaction(ctx, s.backend, tx)
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.SendRawTransactionResponse{TransactionHash: hash.Bytes()}, nil
+}
+
+// SubscribeNewHeads implements ethpb.EthServer.
+func (s *ethServer) SubscribeNewHeads(req *ethpb.SubscribeNewHeadsRequest, stream ethpb.Eth_SubscribeNewHeadsServer) error {
+	var (
+		heads    = make(chan core.ChainHeadEvent, chainHeadChanSize)
+		queue    = make(chan *types.Header, maxQueuedHeads)
+		overflow = make(chan struct{})
+		quit     = make(chan struct{})
+	)
+	sub := s.backend.SubscribeChainHeadEvent(heads)
+	defer sub.Unsubscribe()
+	defer close(quit)
+
+	// Keep draining the chain head feed while the stream is blocked by a slow
+	// client, so it can't hold up the other subscribers of the feed.
+	go func() {
+		dropped := false
+		for {
+			select {
+			case ev := <-heads:
+				select {
+				case queue <- ev.Header:
+				default:
+					if !dropped {
+						close(overflow)
+						dropped = true
+					}
+				}
+			case <-quit:
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case header := <-queue:
+			if err := stream.Send(newHeader(header)); err != nil {
+				return err
+			}
+		case <-overflow:
+			return errTooSlow
+		case err := <-sub.Err():
+			return err
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// signer returns the signer for recovering the senders of transactions in the
+// block with the given header.
+func (s *ethServer) signer(header *types.Header) types.Signer {
+	arbosVersion := types.DeserializeHeaderExtraInformation(header).ArbOSFormatVersion
+	return types.MakeSigner(s.backend.ChainConfig(), header.Number, header.Time, arbosVersion)
+}
+
+// blockNumber converts a block identifier of a range to a block number.
+func blockNumber(id *ethpb.BlockId) (rpc.BlockNumber, error) {
+	switch id := id.GetId().(type) {
+	case nil:
+		return rpc.LatestBlockNumber, nil
+	case *ethpb.BlockId_Number:
+		// Larger numbers would wrap around to the negative special block numbers.
+		if id.Number > math.MaxInt64 {
+			return 0, errBlockNumberTooLarge
+		}
+		return rpc.BlockNumber(id.Number), nil
+	case *ethpb.BlockId_Tag:
+		return blockTag(id.Tag)
+	default:
+		return 0, errHashInRange
+	}
+}
+
+// blockNumberOrHash converts a block identifier to its JSON-RPC equivalent.
+func blockNumberOrHash(id *ethpb.BlockId) (rpc.BlockNumberOrHash, error) {
+	if hash, ok := id.GetId().(*ethpb.BlockId_Hash); ok {
+		if len(hash.Hash) != common.HashLength {
+			return rpc.BlockNumberOrHash{}, errInvalidBlockHash
+		}
+		return rpc.BlockNumberOrHashWithHash(common.BytesToHash(hash.Hash), false), nil
+	}
+	number, err := blockNumber(id)
+	if err != nil {
+		return rpc.BlockNumberOrHash{}, err
+	}
+	return rpc.BlockNumberOrHashWithNumber(number), nil
+}
+
+func blockTag(tag ethpb.BlockTag) (rpc.BlockNumber, error) {
+	switch tag {
+	case ethpb.BlockTag_BLOCK_TAG_LATEST:
+		return rpc.LatestBlockNumber, nil
+	case ethpb.BlockTag_BLOCK_TAG_PENDING:
+		return rpc.PendingBlockNumber, nil
+	case ethpb.BlockTag_BLOCK_TAG_SAFE:
+		return rpc.SafeBlockNumber, nil
+	case ethpb.BlockTag_BLOCK_TAG_FINALIZED:
+		return rpc.FinalizedBlockNumber, nil
+	case ethpb.BlockTag_BLOCK_TAG_EARLIEST:
+		return rpc.EarliestBlockNumber, nil
+	default:
+		return 0, status.Errorf(codes.InvalidArgument, "unknown block tag %v", tag)
+	}
+}
+
+func newHeader(header *types.Header) *ethpb.Header {
+	enc, _ := rlp.EncodeToBytes(header)
+	return &ethpb.Header{
+		Hash:             header.Hash().Bytes(),
+		ParentHash:       header.ParentHash.Bytes(),
+		Number:           header.Number.Uint64(),
+		Timestamp:        header.Time,
+		Miner:            header.Coinbase.Bytes(),
+		StateRoot:        header.Root.Bytes(),
+		TransactionsRoot: header.TxHash.Bytes(),
+		ReceiptsRoot:     header.ReceiptHash.Bytes(),
+		LogsBloom:        header.Bloom.Bytes(),
+		GasLimit:         header.GasLimit,
+		GasUsed:          header.GasUsed,
+		BaseFee:          bigBytes(header.BaseFee),
+		ExtraData:        header.Extra,
+		MixHash:          header.MixDigest.Bytes(),
+		Rlp:              enc,
+	}
+}
+
+// newReceipt converts a receipt of the block with the given header. On Arbitrum
+// chains, it sets the same additional fields as eth_getTransactionReceipt.
+func (s *ethServer) newReceipt(receipt *types.Receipt, tx *types.Transaction, header *types.Header, signer types.Signer) *ethpb.Receipt {
+	from, _ := types.Sender(signer, tx)
+	res := &ethpb.Receipt{
+		TransactionHash:   receipt.TxHash.Bytes(),
+		TransactionIndex:  uint64(receipt.TransactionIndex),
+		BlockHash:         receipt.BlockHash.Bytes(),
+		BlockNumber:       receipt.BlockNumber.Uint64(),
+		From:              from.Bytes(),
+		Type:              uint32(receipt.Type),
+		Status:            receipt.Status,
+		CumulativeGasUsed: receipt.CumulativeGasUsed,
+		GasUsed:           receipt.GasUsed,
+		EffectiveGasPrice: bigBytes(receipt.EffectiveGasPrice),
+		LogsBloom:         receipt.Bloom.Bytes(),
+		Logs:              make([]*ethpb.Log, len(receipt.Logs)),
+	}
+	if to := tx.To(); to != nil {
+		res.To = to.Bytes()
+	} else {
+		res.ContractAddress = receipt.ContractAddress.Bytes()
+	}
+	for i, log := range receipt.Logs {
+		res.Logs[i] = newLog(log)
+	}
+	if config := s.backend.ChainConfig(); config.IsArbitrum() {
+		gasUsedForL1 := receipt.GasUsedForL1
+		res.GasUsedForL1 = &gasUsedForL1
+		if config.IsArbitrumNitro(header.Number) {
+			l1BlockNumber := types.DeserializeHeaderExtraInformation(header).L1BlockNumber
+			res.EffectiveGasPrice = bigBytes(header.BaseFee)
+			res.L1BlockNumber = &l1BlockNumber
+		} else if arbTx, ok := tx.GetInner().(*types.ArbitrumLegacyTxData); ok {
+			res.EffectiveGasPrice = new(big.Int).SetUint64(arbTx.EffectiveGasPrice).Bytes()
+			res.L1BlockNumber = &arbTx.L1BlockNumber
+		}
+	}
+	return res
+}
+
+func newLog(log *types.Log) *ethpb.Log {
+	res := &ethpb.Log{
+		Address:          log.Address.Bytes(),
+		Topics:           make([][]byte, len(log.Topics)),
+		Data:             log.Data,
+		BlockNumber:      log.BlockNumber,
+		BlockHash:        log.BlockHash.Bytes(),
+		TransactionHash:  log.TxHash.Bytes(),
+		TransactionIndex: uint64(log.TxIndex),
+		LogIndex:         uint64(log.Index),
+		Removed:          log.Removed,
+	}
+	for i, topic := range log.Topics {
+		res.Topics[i] = topic.Bytes()
+	}
+	return res
+}
+
+func addressPtr(b []byte) *common.Address {
+	addr := common.BytesToAddress(b)
+	return &addr
+}
+
+// bigPtr decodes a big-endian quantity, returning nil if it is absent.
+func bigPtr(b []byte) *hexutil.Big {
+	if len(b) == 0 {
+		return nil
+	}
+	return (*hexutil.Big)(new(big.Int).SetBytes(b))
+}
+
+// bigBytes encodes a quantity as big-endian bytes, returning nil if it is absent.
+func bigBytes(v *big.Int) []byte {
+	if v == nil {
+		return nil
+	}
+	return v.Bytes()
+}
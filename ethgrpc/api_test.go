@@ -0,0 +1,548 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethgrpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/ethgrpc/ethpb"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	testKey, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr    = crypto.PubkeyToAddress(testKey.PublicKey)
+	logContract = common.HexToAddress("0x0000000000000000000000000000000000000dad")
+	retContract = common.HexToAddress("0x0000000000000000000000000000000000000bee")
+	testSecret  = []byte("0123456789abcdef0123456789abcdef")
+)
+
+type testChain struct {
+	stack   *node.Node
+	backend *eth.Ethereum
+	engine  *beacon.Beacon
+	blocks  []*types.Block
+	signer  types.Signer
+}
+
+// newTestChain creates a node serving the gateway on top of a chain with a
+// single transaction emitting a log. The node config may be changed by configure.
+func newTestChain(t *testing.T, secrets [][]byte, configure func(*node.Config)) *testChain {
+	config := &node.Config{
+		HTTPHost:             "127.0.0.1",
+		HTTPTimeouts:         node.DefaultConfig.HTTPTimeouts,
+		HTTPUnencryptedHTTP2: true,
+	}
+	if configure != nil {
+		configure(config)
+	}
+	stack, err := node.New(config)
+	if err != nil {
+		t.Fatalf("could not create node: %v", err)
+	}
+	t.Cleanup(func() { stack.Close() })
+
+	genesis := &core.Genesis{
+		Config:     params.AllEthashProtocolChanges,
+		GasLimit:   11500000,
+		Difficulty: big.NewInt(1048576),
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		Alloc: types.GenesisAlloc{
+			testAddr: {Balance: big.NewInt(params.Ether)},
+			// LOG0 with empty data.
+			logContract: {Code: []byte{byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.LOG0)}},
+			// Returns the word 0x2a.
+			retContract: {Code: []byte{
+				byte(vm.PUSH1), 0x2a, byte(vm.PUSH1), 0, byte(vm.MSTORE),
+				byte(vm.PUSH1), 0x20, byte(vm.PUSH1), 0, byte(vm.RETURN),
+			}},
+		},
+	}
+	backend, err := eth.New(stack, &ethconfig.Config{
+		Genesis:        genesis,
+		NetworkId:      1337,
+		TrieCleanCache: 5,
+		TrieDirtyCache: 5,
+		TrieTimeout:    60 * time.Minute,
+		SnapshotCache:  5,
+		RPCGasCap:      1000000,
+		StateScheme:    rawdb.HashScheme,
+	})
+	if err != nil {
+		t.Fatalf("could not create eth backend: %v", err)
+	}
+	c := &testChain{
+		stack:   stack,
+		backend: backend,
+		engine:  beacon.New(ethash.NewFaker()),
+		signer:  types.LatestSigner(genesis.Config),
+	}
+	c.blocks = c.generate(t, backend.BlockChain().Genesis(), 2, func(i int, gen *core.BlockGen) {
+		if i == 0 {
+			gen.AddTx(c.signTx(t, 0, logContract))
+		}
+	})
+	filterSystem := filters.NewFilterSystem(backend.APIBackend, filters.Config{})
+	stack.RegisterAPIs([]rpc.API{{Namespace: "eth", Service: filters.NewFilterAPI(filterSystem)}})
+	if _, err := New(stack, backend.APIBackend, filterSystem, secrets); err != nil {
+		t.Fatalf("could not create gRPC service: %v", err)
+	}
+	if err := stack.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+	return c
+}
+
+func (c *testChain) signTx(t *testing.T, nonce uint64, to common.Address) *types.Transaction {
+	tx, err := types.SignNewTx(testKey, c.signer, &types.DynamicFeeTx{
+		ChainID:   params.AllEthashProtocolChanges.ChainID,
+		Nonce:     nonce,
+		To:        &to,
+		Gas:       100000,
+		GasFeeCap: big.NewInt(params.GWei),
+		GasTipCap: big.NewInt(params.GWei),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+// generate creates and imports n blocks on top of parent.
+func (c *testChain) generate(t *testing.T, parent *types.Block, n int, gen func(int, *core.BlockGen)) []*types.Block {
+	chain, _ := core.GenerateChain(params.AllEthashProtocolChanges, parent, c.engine, c.backend.ChainDb(), n, gen)
+	if _, err := c.backend.BlockChain().InsertChain(chain); err != nil {
+		t.Fatalf("could not import blocks: %v", err)
+	}
+	return chain
+}
+
+func (c *testChain) dial(t *testing.T, opts ...grpc.DialOption) ethpb.EthClient {
+	target := strings.TrimPrefix(c.stack.HTTPEndpoint(), "http://")
+	opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return ethpb.NewEthClient(conn)
+}
+
+func TestGateway(t *testing.T) {
+	c := newTestChain(t, nil, nil)
+	client := c.dial(t)
+	ctx := context.Background()
+
+	// Blocks.
+	block, err := client.GetBlock(ctx, &ethpb.GetBlockRequest{
+		Block:            &ethpb.BlockId{Id: &ethpb.BlockId_Number{Number: 1}},
+		FullTransactions: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := c.blocks[0]
+	if !bytes.Equal(block.Header.Hash, want.Hash().Bytes()) || len(block.Transactions) != 1 {
+		t.Fatalf("wrong block %x with %d transactions", block.Header.Hash, len(block.Transactions))
+	}
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(block.Transactions[0]); err != nil || tx.Hash() != want.Transactions()[0].Hash() {
+		t.Errorf("wrong transaction encoding: %v", err)
+	}
+	latest, err := client.GetBlock(ctx, &ethpb.GetBlockRequest{})
+	if err != nil || latest.Header.Number != 2 || len(latest.Transactions) != 0 {
+		t.Errorf("wrong latest block: %v %v", latest, err)
+	}
+	_, err = client.GetBlock(ctx, &ethpb.GetBlockRequest{Block: &ethpb.BlockId{Id: &ethpb.BlockId_Number{Number: 100}}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("wrong error for missing block: %v", err)
+	}
+
+	// Receipts.
+	txHash := want.Transactions()[0].Hash()
+	receipt, err := client.GetTransactionReceipt(ctx, &ethpb.GetTransactionReceiptRequest{TransactionHash: txHash.Bytes()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful || receipt.BlockNumber != 1 || !bytes.Equal(receipt.From, testAddr.Bytes()) || len(receipt.Logs) != 1 {
+		t.Errorf("wrong receipt: %v", receipt)
+	}
+	receipts, err := client.GetBlockReceipts(ctx, &ethpb.GetBlockReceiptsRequest{
+		Block: &ethpb.BlockId{Id: &ethpb.BlockId_Hash{Hash: want.Hash().Bytes()}},
+	})
+	if err != nil || len(receipts.Receipts) != 1 || !bytes.Equal(receipts.Receipts[0].TransactionHash, txHash.Bytes()) {
+		t.Errorf("wrong block receipts: %v %v", receipts, err)
+	}
+
+	// Logs.
+	logs, err := client.GetLogs(ctx, &ethpb.GetLogsRequest{
+		FromBlock: &ethpb.BlockId{Id: &ethpb.BlockId_Tag{Tag: ethpb.BlockTag_BLOCK_TAG_EARLIEST}},
+		Addresses: [][]byte{logContract.Bytes()},
+	})
+	if err != nil || len(logs.Logs) != 1 || !bytes.Equal(logs.Logs[0].TransactionHash, txHash.Bytes()) {
+		t.Errorf("wrong logs: %v %v", logs, err)
+	}
+	_, err = client.GetLogs(ctx, &ethpb.GetLogsRequest{
+		FromBlock: &ethpb.BlockId{Id: &ethpb.BlockId_Hash{Hash: want.Hash().Bytes()}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("wrong error for range by hash: %v", err)
+	}
+	_, err = client.GetLogs(ctx, &ethpb.GetLogsRequest{
+		FromBlock: &ethpb.BlockId{Id: &ethpb.BlockId_Number{Number: 1 << 63}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("wrong error for block number beyond int64: %v", err)
+	}
+
+	// Calls.
+	call, err := client.Call(ctx, &ethpb.CallRequest{To: retContract.Bytes()})
+	if err != nil || new(big.Int).SetBytes(call.ReturnData).Int64() != 0x2a || call.Error != "" {
+		t.Errorf("wrong call result: %v %v", call, err)
+	}
+
+	// Transactions.
+	next := c.signTx(t, 1, logContract)
+	enc, _ := next.MarshalBinary()
+	sent, err := client.SendRawTransaction(ctx, &ethpb.SendRawTransactionRequest{Transaction: enc})
+	if err != nil || !bytes.Equal(sent.TransactionHash, next.Hash().Bytes()) {
+		t.Errorf("wrong result of sending transaction: %v %v", sent, err)
+	}
+	_, err = client.SendRawTransaction(ctx, &ethpb.SendRawTransactionRequest{Transaction: []byte{1, 2, 3}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("wrong error for invalid transaction: %v", err)
+	}
+}
+
+// prunedBackend reports the history before block 2 as pruned.
+type prunedBackend struct {
+	*eth.EthAPIBackend
+}
+
+func (b prunedBackend) HistoryPruningCutoff() uint64 { return 2 }
+
+func TestGetLogsPrunedHistory(t *testing.T) {
+	c := newTestChain(t, nil, nil)
+	s := &ethServer{
+		backend:      c.backend.APIBackend,
+		filterSystem: filters.NewFilterSystem(prunedBackend{c.backend.APIBackend}, filters.Config{}),
+	}
+	_, err := s.GetLogs(context.Background(), &ethpb.GetLogsRequest{
+		FromBlock: &ethpb.BlockId{Id: &ethpb.BlockId_Number{Number: 1}},
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("wrong error for pruned range: %v", err)
+	}
+	logs, err := s.GetLogs(context.Background(), &ethpb.GetLogsRequest{
+		FromBlock: &ethpb.BlockId{Id: &ethpb.BlockId_Number{Number: 2}},
+	})
+	if err != nil || len(logs.Logs) != 0 {
+		t.Errorf("wrong logs after the cutoff: %v %v", logs, err)
+	}
+}
+
+func TestGatewaySubscribeNewHeads(t *testing.T) {
+	c := newTestChain(t, nil, nil)
+	client := c.dial(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := client.SubscribeNewHeads(ctx, &ethpb.SubscribeNewHeadsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The subscription is set up asynchronously, so keep importing blocks until
+	// the first header arrives.
+	var (
+		done = make(chan struct{})
+		wg   sync.WaitGroup
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		parent := c.blocks[len(c.blocks)-1]
+		for i := 0; i < 20; i++ {
+			select {
+			case <-done:
+				return
+			case <-time.After(50 * time.Millisecond):
+			}
+			chain, _ := core.GenerateChain(params.AllEthashProtocolChanges, parent, c.engine, c.backend.ChainDb(), 1, nil)
+			if _, err := c.backend.BlockChain().InsertChain(chain); err != nil {
+				return
+			}
+			parent = chain[0]
+		}
+	}()
+	defer wg.Wait()
+	defer close(done)
+
+	first, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Number <= 2 || second.Number != first.Number+1 || !bytes.Equal(second.ParentHash, first.Hash) {
+		t.Errorf("wrong headers %d and %d", first.Number, second.Number)
+	}
+}
+
+// jwtCredentials authenticates calls with a fresh JWT, optionally restricted to
+// some methods.
+type jwtCredentials struct {
+	secret  []byte
+	methods []string
+}
+
+func (c jwtCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	claims := jwt.MapClaims{"iat": time.Now().Unix()}
+	if c.methods != nil {
+		claims["methods"] = c.methods
+	}
+	s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(c.secret)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": "Bearer " + s}, nil
+}
+
+func (jwtCredentials) RequireTransportSecurity() bool { return false }
+
+func TestGatewayJWT(t *testing.T) {
+	c := newTestChain(t, [][]byte{testSecret}, nil)
+	req := &ethpb.GetBlockRequest{}
+
+	_, err := c.dial(t).GetBlock(context.Background(), req)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("wrong error for unauthenticated call: %v", err)
+	}
+	_, err = c.dial(t, grpc.WithPerRPCCredentials(jwtCredentials{secret: []byte("wrong secret")})).GetBlock(context.Background(), req)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("wrong error for wrong secret: %v", err)
+	}
+	if _, err := c.dial(t, grpc.WithPerRPCCredentials(jwtCredentials{secret: testSecret})).GetBlock(context.Background(), req); err != nil {
+		t.Errorf("authenticated call failed: %v", err)
+	}
+
+	// A token restricted to some methods only permits the corresponding calls.
+	client := c.dial(t, grpc.WithPerRPCCredentials(jwtCredentials{secret: testSecret, methods: []string{"eth_getBlockByNumber"}}))
+	if _, err := client.GetBlock(context.Background(), req); err != nil {
+		t.Errorf("permitted call failed: %v", err)
+	}
+	_, err = client.Call(context.Background(), &ethpb.CallRequest{})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("wrong error for call not permitted by token: %v", err)
+	}
+}
+
+// This test checks that the module list, method allow-list and call quota of the
+// HTTP endpoint apply to the corresponding gRPC methods.
+func TestGatewayRestrictions(t *testing.T) {
+	c := newTestChain(t, nil, func(config *node.Config) {
+		config.RPCQuota = rpc.QuotaConfig{Rate: 0.001, Burst: 2}
+	})
+	client := c.dial(t)
+	ctx := context.Background()
+	byNumber := &ethpb.GetBlockRequest{}
+	byHash := &ethpb.GetBlockRequest{Block: &ethpb.BlockId{Id: &ethpb.BlockId_Hash{Hash: c.blocks[0].Hash().Bytes()}}}
+
+	// Methods filtered out of the allow-list are unavailable.
+	c.stack.SetMethodAllowList([]string{"eth_getBlockByNumber"})
+	if _, err := client.GetBlock(ctx, byNumber); err != nil {
+		t.Errorf("allowed call failed: %v", err)
+	}
+	_, err := client.GetBlock(ctx, byHash)
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("wrong error for block by hash not in allow-list: %v", err)
+	}
+	_, err = client.SendRawTransaction(ctx, &ethpb.SendRawTransactionRequest{Transaction: []byte{1}})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("wrong error for transaction not in allow-list: %v", err)
+	}
+	stream, err := client.SubscribeNewHeads(ctx, &ethpb.SubscribeNewHeadsRequest{})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("wrong error for subscription not in allow-list: %v", err)
+	}
+	c.stack.SetMethodAllowList(nil)
+
+	// Disabled modules are unavailable.
+	if err := c.stack.SetRPCModules("http", []string{"web3"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.GetBlock(ctx, byNumber)
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("wrong error for disabled module: %v", err)
+	}
+	if err := c.stack.SetRPCModules("http", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// The calls are charged to the quota of the client.
+	if _, err := client.GetBlock(ctx, byHash); err != nil {
+		t.Errorf("call within quota failed: %v", err)
+	}
+	_, err = client.GetBlock(ctx, byNumber)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("wrong error for exceeded quota: %v", err)
+	}
+}
+
+// arbitrumBackend reports an Arbitrum chain config.
+type arbitrumBackend struct {
+	ethapi.Backend
+	config *params.ChainConfig
+}
+
+func (b arbitrumBackend) ChainConfig() *params.ChainConfig { return b.config }
+
+func TestArbitrumReceipt(t *testing.T) {
+	config := *params.AllEthashProtocolChanges
+	config.ArbitrumChainParams = params.ArbitrumChainParams{EnableArbOS: true, GenesisBlockNum: 10}
+	s := &ethServer{backend: arbitrumBackend{config: &config}}
+	signer := types.LatestSigner(&config)
+	tx := types.NewTx(&types.LegacyTx{To: &logContract, GasPrice: big.NewInt(200)})
+
+	// Nitro blocks carry the L1 block number in the header.
+	header := &types.Header{Number: big.NewInt(20), BaseFee: big.NewInt(100), Difficulty: common.Big1}
+	types.HeaderInfo{L1BlockNumber: 5000}.UpdateHeaderWithInfo(header)
+	receipt := &types.Receipt{GasUsedForL1: 42, BlockNumber: header.Number, EffectiveGasPrice: big.NewInt(200)}
+	res := s.newReceipt(receipt, tx, header, signer)
+	if res.GasUsedForL1 == nil || res.GetGasUsedForL1() != 42 || res.L1BlockNumber == nil || res.GetL1BlockNumber() != 5000 {
+		t.Errorf("wrong Nitro receipt fields: %v %v", res.GasUsedForL1, res.L1BlockNumber)
+	}
+	if price := new(big.Int).SetBytes(res.EffectiveGasPrice); price.Int64() != 100 {
+		t.Errorf("wrong Nitro effective gas price: %v", price)
+	}
+
+	// Classic transactions carry it themselves.
+	legacy, err := types.NewArbitrumLegacyTx(tx, common.Hash{1}, 300, 4000, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header = &types.Header{Number: big.NewInt(5), Difficulty: common.Big1}
+	res = s.newReceipt(&types.Receipt{GasUsedForL1: 7, BlockNumber: header.Number}, legacy, header, signer)
+	if res.GetGasUsedForL1() != 7 || res.GetL1BlockNumber() != 4000 {
+		t.Errorf("wrong classic receipt fields: %v %v", res.GasUsedForL1, res.L1BlockNumber)
+	}
+	if price := new(big.Int).SetBytes(res.EffectiveGasPrice); price.Int64() != 300 {
+		t.Errorf("wrong classic effective gas price: %v", price)
+	}
+
+	// Other chains don't have the fields.
+	s.backend = arbitrumBackend{config: params.AllEthashProtocolChanges}
+	res = s.newReceipt(receipt, tx, header, signer)
+	if res.GasUsedForL1 != nil || res.L1BlockNumber != nil {
+		t.Errorf("Arbitrum fields set on other chain: %v %v", res.GasUsedForL1, res.L1BlockNumber)
+	}
+}
+
+// fallbackBackend lacks the state of all blocks, redirecting calls to client.
+type fallbackBackend struct {
+	ethapi.Backend
+	client types.FallbackClient
+}
+
+func (b fallbackBackend) StateAndHeaderByNumberOrHash(context.Context, rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
+	return nil, nil, types.ErrUseFallback
+}
+
+func (b fallbackBackend) FallbackClient() types.FallbackClient { return b.client }
+func (b fallbackBackend) RPCEVMTimeout() time.Duration         { return 0 }
+func (b fallbackBackend) RPCGasCap() uint64                    { return 0 }
+
+// revertError is the error returned by the fallback node for reverted calls.
+type revertError struct{ data string }
+
+func (e *revertError) Error() string          { return "execution reverted" }
+func (e *revertError) ErrorCode() int         { return 3 }
+func (e *revertError) ErrorData() interface{} { return e.data }
+
+// fallbackClient serves eth_call like the classic node.
+type fallbackClient struct {
+	method string
+	err    error
+}
+
+func (c *fallbackClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	c.method = method
+	if c.err != nil {
+		return c.err
+	}
+	*result.(*hexutil.Bytes) = hexutil.Bytes{0x2a}
+	return nil
+}
+
+func TestCallFallback(t *testing.T) {
+	client := new(fallbackClient)
+	s := &ethServer{backend: fallbackBackend{client: client}}
+	req := &ethpb.CallRequest{To: retContract.Bytes(), Block: &ethpb.BlockId{Id: &ethpb.BlockId_Number{Number: 1}}}
+
+	res, err := s.Call(context.Background(), req)
+	if err != nil || !bytes.Equal(res.ReturnData, []byte{0x2a}) || res.Error != "" {
+		t.Errorf("wrong forwarded call result: %v %v", res, err)
+	}
+	if client.method != "eth_call" {
+		t.Errorf("wrong forwarded method %q", client.method)
+	}
+
+	// Execution errors of the fallback node are returned in the response.
+	client.err = &revertError{data: "0x08c379a0"}
+	res, err = s.Call(context.Background(), req)
+	if err != nil || !bytes.Equal(res.ReturnData, []byte{0x08, 0xc3, 0x79, 0xa0}) || res.Error != "execution reverted" {
+		t.Errorf("wrong forwarded revert: %v %v", res, err)
+	}
+
+	// Failing to reach the fallback node fails the call.
+	client.err = errors.New("connection refused")
+	if _, err := s.Call(context.Background(), req); status.Code(err) != codes.Unavailable {
+		t.Errorf("wrong error for unreachable fallback node: %v", err)
+	}
+}
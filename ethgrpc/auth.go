@@ -0,0 +1,113 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethgrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/ethgrpc/ethpb"
+	"github.com/ethereum/go-ethereum/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// rpcMethods maps the gRPC methods to the JSON-RPC methods they correspond to.
+// The gRPC method is admitted if the HTTP endpoint admits a call of the JSON-RPC
+// method, i.e. the JWT scope, module list, method allow-list and call quota of the
+// endpoint apply to it. GetBlock is checked as eth_getBlockByHash or
+// eth_getBlockByNumber, depending on the block identifier of the request.
+var rpcMethods = map[string]string{
+	ethpb.Eth_GetBlockReceipts_FullMethodName:      "eth_getBlockReceipts",
+	ethpb.Eth_GetTransactionReceipt_FullMethodName: "eth_getTransactionReceipt",
+	ethpb.Eth_GetLogs_FullMethodName:               "eth_getLogs",
+	ethpb.Eth_Call_FullMethodName:                  "eth_call",
+	ethpb.Eth_SendRawTransaction_FullMethodName:    "eth_sendRawTransaction",
+}
+
+// rpcSubscriptions maps the streaming gRPC methods to the namespace and name of the
+// JSON-RPC subscriptions they correspond to.
+var rpcSubscriptions = map[string][2]string{
+	ethpb.Eth_SubscribeNewHeads_FullMethodName: {"eth", "newHeads"},
+}
+
+// callChecker checks calls against the restrictions of the HTTP endpoint. It is
+// implemented by node.Node.
+type callChecker interface {
+	CheckHTTPCall(peer rpc.PeerInfo, method string) error
+	CheckHTTPSubscription(peer rpc.PeerInfo, namespace, name string) error
+}
+
+// interceptor admits the gRPC calls the HTTP endpoint would admit as JSON-RPC calls.
+type interceptor struct {
+	checker callChecker
+}
+
+func (i *interceptor) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	method, ok := rpcMethods[info.FullMethod]
+	if r, isGetBlock := req.(*ethpb.GetBlockRequest); isGetBlock {
+		method, ok = "eth_getBlockByNumber", true
+		if _, byHash := r.GetBlock().GetId().(*ethpb.BlockId_Hash); byHash {
+			method = "eth_getBlockByHash"
+		}
+	}
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "unknown method %s", info.FullMethod)
+	}
+	if err := i.checker.CheckHTTPCall(peerInfo(ctx), method); err != nil {
+		return nil, checkError(err)
+	}
+	return handler(ctx, req)
+}
+
+func (i *interceptor) stream(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	sub, ok := rpcSubscriptions[info.FullMethod]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "unknown method %s", info.FullMethod)
+	}
+	if err := i.checker.CheckHTTPSubscription(peerInfo(stream.Context()), sub[0], sub[1]); err != nil {
+		return checkError(err)
+	}
+	return handler(srv, stream)
+}
+
+// peerInfo returns the client information the checks and the quota are based on.
+func peerInfo(ctx context.Context) rpc.PeerInfo {
+	var remoteAddr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+	return rpc.GatewayPeerInfo(ctx, "grpc", remoteAddr)
+}
+
+// checkError converts the error of a rejected call to a gRPC status.
+func checkError(err error) error {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	switch rpcErr.ErrorCode() {
+	case rpc.ErrcodeMethodNotFound:
+		return status.Error(codes.Unimplemented, err.Error())
+	case rpc.ErrcodeLimitExceeded:
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package ethgrpc implements a gRPC gateway to a subset of the eth API, as
+// defined in ethpb/eth.proto.
+package ethgrpc
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/ethgrpc/ethpb"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/node"
+	"google.golang.org/grpc"
+)
+
+// Service is the gRPC gateway registered on the HTTP server of a node.
+type Service struct {
+	server *grpc.Server
+}
+
+// New registers the gRPC gateway on the HTTP server of the stack. The gateway is
+// served next to JSON-RPC, on the path of the gRPC service. As gRPC requires
+// HTTP/2, the HTTP server must be configured to serve unencrypted HTTP/2.
+//
+// Every gRPC method is subject to the restrictions of the JSON-RPC method it
+// corresponds to on the HTTP endpoint: its module list, method allow-list and call
+// quota. If jwtSecrets is not empty, calls must be authenticated with a JWT in the
+// same way as on the authenticated JSON-RPC endpoints. A JWT restricted to some
+// namespaces and methods permits the gRPC methods corresponding to them.
+func New(stack *node.Node, backend ethapi.Backend, filterSystem *filters.FilterSystem, jwtSecrets [][]byte) (*Service, error) {
+	if !stack.Config().HTTPUnencryptedHTTP2 {
+		return nil, errors.New("gRPC requires HTTP/2, enable unencrypted HTTP/2 on the HTTP server")
+	}
+	checks := &interceptor{checker: stack}
+	s := &Service{server: grpc.NewServer(
+		grpc.UnaryInterceptor(checks.unary),
+		grpc.StreamInterceptor(checks.stream),
+	)}
+	ethpb.RegisterEthServer(s.server, &ethServer{backend: backend, filterSystem: filterSystem})
+
+	handler := node.NewHTTPHandlerStackWithSecrets(s.server, nil, stack.Config().HTTPVirtualHosts, jwtSecrets, stack.Config().GRPCLongLivedJWT)
+	stack.RegisterHandler("gRPC", "/"+ethpb.Eth_ServiceDesc.ServiceName+"/", handler)
+	stack.RegisterLifecycle(s)
+	return s, nil
+}
+
+// Start implements node.Lifecycle.
+func (s *Service) Start() error {
+	return nil
+}
+
+// Stop implements node.Lifecycle, ending all open calls and subscriptions.
+func (s *Service) Stop() error {
+	s.server.Stop()
+	return nil
+}
@@ -658,6 +658,156 @@ func (t *Transaction) RawReceipt(ctx context.Context) (hexutil.Bytes, error) {
 	return receipt.MarshalBinary()
 }
 
+// Arbitrum: fields of the Arbitrum specific transaction types and receipts.
+
+func (t *Transaction) GasUsedForL1(ctx context.Context) (*hexutil.Uint64, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil || !t.r.backend.ChainConfig().IsArbitrum() {
+		return nil, err
+	}
+	ret := hexutil.Uint64(receipt.GasUsedForL1)
+	return &ret, nil
+}
+
+func (t *Transaction) RequestId(ctx context.Context) *common.Hash {
+	tx, _ := t.resolve(ctx)
+	if tx == nil {
+		return nil
+	}
+	switch inner := tx.GetInner().(type) {
+	case *types.ArbitrumDepositTx:
+		return &inner.L1RequestId
+	case *types.ArbitrumContractTx:
+		return &inner.RequestId
+	case *types.ArbitrumSubmitRetryableTx:
+		return &inner.RequestId
+	}
+	return nil
+}
+
+func (t *Transaction) TicketId(ctx context.Context) *common.Hash {
+	tx, _ := t.resolve(ctx)
+	if tx == nil {
+		return nil
+	}
+	if inner, ok := tx.GetInner().(*types.ArbitrumRetryTx); ok {
+		return &inner.TicketId
+	}
+	return nil
+}
+
+func (t *Transaction) MaxRefund(ctx context.Context) *hexutil.Big {
+	tx, _ := t.resolve(ctx)
+	if tx == nil {
+		return nil
+	}
+	if inner, ok := tx.GetInner().(*types.ArbitrumRetryTx); ok {
+		return (*hexutil.Big)(inner.MaxRefund)
+	}
+	return nil
+}
+
+func (t *Transaction) SubmissionFeeRefund(ctx context.Context) *hexutil.Big {
+	tx, _ := t.resolve(ctx)
+	if tx == nil {
+		return nil
+	}
+	if inner, ok := tx.GetInner().(*types.ArbitrumRetryTx); ok {
+		return (*hexutil.Big)(inner.SubmissionFeeRefund)
+	}
+	return nil
+}
+
+func (t *Transaction) RefundTo(ctx context.Context, args BlockNumberArgs) *Account {
+	tx, _ := t.resolve(ctx)
+	if tx == nil {
+		return nil
+	}
+	var address common.Address
+	switch inner := tx.GetInner().(type) {
+	case *types.ArbitrumRetryTx:
+		address = inner.RefundTo
+	case *types.ArbitrumSubmitRetryableTx:
+		address = inner.FeeRefundAddr
+	default:
+		return nil
+	}
+	return &Account{
+		r:             t.r,
+		address:       address,
+		blockNrOrHash: args.NumberOrLatest(),
+	}
+}
+
+// submitRetryable returns the retryable submission, if the transaction is one.
+func (t *Transaction) submitRetryable(ctx context.Context) *types.ArbitrumSubmitRetryableTx {
+	tx, _ := t.resolve(ctx)
+	if tx == nil {
+		return nil
+	}
+	inner, _ := tx.GetInner().(*types.ArbitrumSubmitRetryableTx)
+	return inner
+}
+
+func (t *Transaction) L1BaseFee(ctx context.Context) *hexutil.Big {
+	if inner := t.submitRetryable(ctx); inner != nil {
+		return (*hexutil.Big)(inner.L1BaseFee)
+	}
+	return nil
+}
+
+func (t *Transaction) DepositValue(ctx context.Context) *hexutil.Big {
+	if inner := t.submitRetryable(ctx); inner != nil {
+		return (*hexutil.Big)(inner.DepositValue)
+	}
+	return nil
+}
+
+func (t *Transaction) RetryTo(ctx context.Context, args BlockNumberArgs) *Account {
+	inner := t.submitRetryable(ctx)
+	if inner == nil || inner.RetryTo == nil {
+		return nil
+	}
+	return &Account{
+		r:             t.r,
+		address:       *inner.RetryTo,
+		blockNrOrHash: args.NumberOrLatest(),
+	}
+}
+
+func (t *Transaction) RetryValue(ctx context.Context) *hexutil.Big {
+	if inner := t.submitRetryable(ctx); inner != nil {
+		return (*hexutil.Big)(inner.RetryValue)
+	}
+	return nil
+}
+
+func (t *Transaction) RetryData(ctx context.Context) *hexutil.Bytes {
+	if inner := t.submitRetryable(ctx); inner != nil {
+		return (*hexutil.Bytes)(&inner.RetryData)
+	}
+	return nil
+}
+
+func (t *Transaction) Beneficiary(ctx context.Context, args BlockNumberArgs) *Account {
+	inner := t.submitRetryable(ctx)
+	if inner == nil {
+		return nil
+	}
+	return &Account{
+		r:             t.r,
+		address:       inner.Beneficiary,
+		blockNrOrHash: args.NumberOrLatest(),
+	}
+}
+
+func (t *Transaction) MaxSubmissionFee(ctx context.Context) *hexutil.Big {
+	if inner := t.submitRetryable(ctx); inner != nil {
+		return (*hexutil.Big)(inner.MaxSubmissionFee)
+	}
+	return nil
+}
+
 type BlockType int
 
 // Block represents an Ethereum block.
@@ -1097,6 +1247,45 @@ func (b *Block) ExcessBlobGas(ctx context.Context) (*hexutil.Uint64, error) {
 	return &ret, nil
 }
 
+// nitroHeaderInfo returns the Arbitrum information of Nitro block headers.
+func (b *Block) nitroHeaderInfo(ctx context.Context) (*types.HeaderInfo, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !b.r.backend.ChainConfig().IsArbitrumNitro(header.Number) {
+		return nil, nil
+	}
+	info := types.DeserializeHeaderExtraInformation(header)
+	return &info, nil
+}
+
+func (b *Block) L1BlockNumber(ctx context.Context) (*hexutil.Uint64, error) {
+	info, err := b.nitroHeaderInfo(ctx)
+	if err != nil || info == nil {
+		return nil, err
+	}
+	ret := hexutil.Uint64(info.L1BlockNumber)
+	return &ret, nil
+}
+
+func (b *Block) SendRoot(ctx context.Context) (*common.Hash, error) {
+	info, err := b.nitroHeaderInfo(ctx)
+	if err != nil || info == nil {
+		return nil, err
+	}
+	return &info.SendRoot, nil
+}
+
+func (b *Block) SendCount(ctx context.Context) (*hexutil.Uint64, error) {
+	info, err := b.nitroHeaderInfo(ctx)
+	if err != nil || info == nil {
+		return nil, err
+	}
+	ret := hexutil.Uint64(info.SendCount)
+	return &ret, nil
+}
+
 // BlockFilterCriteria encapsulates criteria passed to a `logs` accessor inside
 // a block.
 type BlockFilterCriteria struct {
@@ -484,3 +484,86 @@ func newGQLService(t *testing.T, stack *node.Node, shanghai bool, gspec *core.Ge
 	}
 	return handler, chain
 }
+
+func TestGraphQLArbitrumFields(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		config = *params.AllEthashProtocolChanges
+	)
+	config.ArbitrumChainParams.EnableArbOS = true
+
+	// The Nitro header information is encoded in the extra data and mix hash.
+	var mixHash common.Hash
+	mixHash[7] = 3  // send count
+	mixHash[15] = 7 // parent chain block number
+	genesis := &core.Genesis{
+		Config:     &config,
+		GasLimit:   11500000,
+		Difficulty: common.Big1,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		ExtraData:  common.HexToHash("0x01").Bytes(),
+		Mixhash:    mixHash,
+		Alloc: types.GenesisAlloc{
+			addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	stack := createNode(t)
+	defer stack.Close()
+
+	handler, _ := newGQLService(t, stack, false, genesis, 0, func(i int, gen *core.BlockGen) {})
+	if err := stack.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+	res := handler.Schema.Exec(context.Background(), "{block(number: 0) { l1BlockNumber sendRoot sendCount } }", "", map[string]interface{}{})
+	if res.Errors != nil {
+		t.Fatalf("failed to execute query: %v", res.Errors)
+	}
+	have, err := json.Marshal(res.Data)
+	if err != nil {
+		t.Fatalf("failed to encode graphql response: %s", err)
+	}
+	want := `{"block":{"l1BlockNumber":"0x7","sendRoot":"0x0000000000000000000000000000000000000000000000000000000000000001","sendCount":"0x3"}}`
+	if string(have) != want {
+		t.Errorf("response unmatch.\nhave:\n%s\nwant:\n%s", have, want)
+	}
+}
+
+func TestGraphQLArbitrumFieldsNonArbitrum(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+
+		genesis = &core.Genesis{
+			Config:     params.AllEthashProtocolChanges,
+			GasLimit:   11500000,
+			Difficulty: common.Big1,
+			Alloc: types.GenesisAlloc{
+				addr: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		signer = types.LatestSigner(genesis.Config)
+		stack  = createNode(t)
+	)
+	defer stack.Close()
+
+	handler, _ := newGQLService(t, stack, false, genesis, 1, func(i int, gen *core.BlockGen) {
+		tx, _ := types.SignNewTx(key, signer, &types.LegacyTx{To: &common.Address{}, Gas: 100000, GasPrice: big.NewInt(params.InitialBaseFee)})
+		gen.AddTx(tx)
+	})
+	if err := stack.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+	res := handler.Schema.Exec(context.Background(), "{block(number: 1) { l1BlockNumber sendRoot sendCount transactions { gasUsedForL1 requestId ticketId refundTo { address } retryTo { address } } } }", "", map[string]interface{}{})
+	if res.Errors != nil {
+		t.Fatalf("failed to execute query: %v", res.Errors)
+	}
+	have, err := json.Marshal(res.Data)
+	if err != nil {
+		t.Fatalf("failed to encode graphql response: %s", err)
+	}
+	want := `{"block":{"l1BlockNumber":null,"sendRoot":null,"sendCount":null,"transactions":[{"gasUsedForL1":null,"requestId":null,"ticketId":null,"refundTo":null,"retryTo":null}]}}`
+	if string(have) != want {
+		t.Errorf("response unmatch.\nhave:\n%s\nwant:\n%s", have, want)
+	}
+}
@@ -170,6 +170,39 @@ const schema string = `
         rawReceipt: Bytes!
         # BlobVersionedHashes is a set of hash outputs from the blobs in the transaction.
         blobVersionedHashes: [Bytes32!]
+
+        # Arbitrum: the fields below are null unless the chain, or the type of
+        # the transaction, is an Arbitrum one.
+        # GasUsedForL1 is the part of the gas used which paid for posting the
+        # transaction to the parent chain.
+        gasUsedForL1: Long
+        # RequestId is the id of the parent chain message which created a
+        # deposit, contract or retryable submission transaction.
+        requestId: Bytes32
+        # TicketId is the id of the retryable redeemed by a retry transaction.
+        ticketId: Bytes32
+        # MaxRefund is the maximum refund sent to the refund address of a retry.
+        maxRefund: BigInt
+        # SubmissionFeeRefund is the submission fee refunded by a retry.
+        submissionFeeRefund: BigInt
+        # RefundTo is the account which receives the refunds of a retryable.
+        refundTo(block: Long): Account
+        # L1BaseFee is the parent chain base fee of a retryable submission.
+        l1BaseFee: BigInt
+        # DepositValue is the value deposited by a retryable submission.
+        depositValue: BigInt
+        # RetryTo is the account called by the retries of a retryable
+        # submission. This is null for contract-creating retryables.
+        retryTo(block: Long): Account
+        # RetryValue is the value sent by the retries of a retryable submission.
+        retryValue: BigInt
+        # RetryData is the input data of the retries of a retryable submission.
+        retryData: Bytes
+        # Beneficiary is the account which may cancel a retryable submission
+        # and receives its escrowed value.
+        beneficiary(block: Long): Account
+        # MaxSubmissionFee is the maximum submission fee of a retryable.
+        maxSubmissionFee: BigInt
     }
 
     # BlockFilterCriteria encapsulates log filter criteria for a filter applied
@@ -276,6 +309,16 @@ const schema string = `
         blobGasUsed: Long
         # ExcessBlobGas is a running total of blob gas consumed in excess of the target, prior to the block.
         excessBlobGas: Long
+
+        # Arbitrum: the fields below are null for blocks before Nitro.
+        # L1BlockNumber is the parent chain block number the block was based on.
+        l1BlockNumber: Long
+        # SendRoot is the merkle root of the outbox messages sent up to and
+        # including this block.
+        sendRoot: Bytes32
+        # SendCount is the number of outbox messages sent up to and including
+        # this block.
+        sendCount: Long
     }
 
     # CallData represents the data associated with a local contract call.
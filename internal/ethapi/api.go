@@ -28,6 +28,7 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/arbitrum/multigas"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
@@ -41,6 +42,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/gasestimator"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/internal/ethapi/override"
+	"github.com/ethereum/go-ethereum/internal/telemetry"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/p2p"
@@ -56,7 +58,10 @@ var (
 	gasUsedEthCallGauge        = metrics.NewRegisteredCounter("rpc/gas_used/eth_call", nil)
 )
 
-func fallbackClientFor(b Backend, err error) types.FallbackClient {
+// FallbackClientFor returns the client serving a request which failed with err
+// because the state is only available on the classic node or on an archive node.
+// It returns nil for all other errors.
+func FallbackClientFor(b Backend, err error) types.FallbackClient {
 	if errors.Is(err, types.ErrUseFallback) {
 		return b.FallbackClient()
 	}
@@ -317,7 +322,7 @@ func (api *BlockChainAPI) BlockNumber() hexutil.Uint64 {
 func (api *BlockChainAPI) GetBalance(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error) {
 	state, _, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 	if state == nil || err != nil {
-		if client := fallbackClientFor(api.b, err); client != nil {
+		if client := FallbackClientFor(api.b, err); client != nil {
 			var res hexutil.Big
 			err := client.CallContext(ctx, &res, "eth_getBalance", address, blockNrOrHash)
 			return &res, err
@@ -582,7 +587,7 @@ func (api *BlockChainAPI) GetUncleCountByBlockHash(ctx context.Context, blockHas
 func (api *BlockChainAPI) GetCode(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
 	state, _, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 	if state == nil || err != nil {
-		if client := fallbackClientFor(api.b, err); client != nil {
+		if client := FallbackClientFor(api.b, err); client != nil {
 			var res hexutil.Bytes
 			err := client.CallContext(ctx, &res, "eth_getCode", address, blockNrOrHash)
 			return res, err
@@ -603,7 +608,7 @@ func (api *BlockChainAPI) GetStorageAt(ctx context.Context, address common.Addre
 	}
 	state, _, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 	if state == nil || err != nil {
-		if client := fallbackClientFor(api.b, err); client != nil {
+		if client := FallbackClientFor(api.b, err); client != nil {
 			var res hexutil.Bytes
 			err := client.CallContext(ctx, &res, "eth_getStorageAt", address, key, blockNrOrHash)
 			return res, err
@@ -775,7 +780,17 @@ func applyMessageWithEVM(ctx context.Context, evm *vm.EVM, msg *core.Message, st
 	}()
 
 	// Execute the message.
+	_, span, spanEnd := telemetry.StartSpan(ctx, "core.ApplyMessage",
+		telemetry.Int64Attribute("gas.limit", int64(msg.GasLimit)),
+	)
 	result, err := core.ApplyMessage(evm, msg, gp)
+	if result != nil {
+		span.SetAttributes(
+			telemetry.Int64Attribute("gas.used", int64(result.UsedGas)),
+			telemetry.BoolAttribute("failed", result.Failed()),
+		)
+	}
+	spanEnd(&err)
 
 	// If the timer caused an abort, return an appropriate error message
 	if evm.Cancelled() {
@@ -849,6 +864,13 @@ func updateHeaderForPendingBlocks(blockNrOrHash rpc.BlockNumberOrHash, header *t
 	return header
 }
 
+// DoCallAtState executes the call on top of the given state and header, leaving
+// state retrieval to the caller, e.g. when iterating over a range of blocks.
+// Note, the state is modified by the call.
+func DoCallAtState(ctx context.Context, b Backend, args TransactionArgs, state *state.StateDB, header *types.Header, overrides *override.StateOverride, blockOverrides *override.BlockOverrides, timeout time.Duration, globalGasCap uint64, runCtx *core.MessageRunContext) (*core.ExecutionResult, error) {
+	return doCall(ctx, b, args, state, header, overrides, blockOverrides, timeout, globalGasCap, runCtx)
+}
+
 func DoCall(ctx context.Context, b Backend, args TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *override.StateOverride, blockOverrides *override.BlockOverrides, timeout time.Duration, globalGasCap uint64, runCtx *core.MessageRunContext) (*core.ExecutionResult, error) {
 	defer func(start time.Time) { log.Debug("Executing EVM call finished", "runtime", time.Since(start)) }(time.Now())
 
@@ -873,7 +895,7 @@ func (api *BlockChainAPI) Call(ctx context.Context, args TransactionArgs, blockN
 	}
 	result, err := DoCall(ctx, api.b, args, *blockNrOrHash, overrides, blockOverrides, api.b.RPCEVMTimeout(), api.b.RPCGasCap(), core.NewMessageEthcallContext())
 	if err != nil {
-		if client := fallbackClientFor(api.b, err); client != nil {
+		if client := FallbackClientFor(api.b, err); client != nil {
 			var res hexutil.Bytes
 			err := client.CallContext(ctx, &res, "eth_call", args, blockNrOrHash, overrides)
 			return res, err
@@ -931,16 +953,27 @@ func (api *BlockChainAPI) SimulateV1(ctx context.Context, opts simOpts, blockNrO
 // there are unexpected failures. The gas limit is capped by both `args.Gas` (if non-nil &
 // non-zero) and `gasCap` (if non-zero).
 func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *override.StateOverride, blockOverrides *override.BlockOverrides, gasCap uint64) (hexutil.Uint64, error) {
+	estimate, _, _, err := doEstimateGas(ctx, b, args, blockNrOrHash, overrides, blockOverrides, gasCap)
+	return hexutil.Uint64(estimate), err
+}
+
+// doEstimateGas runs the gas estimation of DoEstimateGas, also returning the
+// estimated message and the estimation options to execute it with.
+func doEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *override.StateOverride, blockOverrides *override.BlockOverrides, gasCap uint64) (uint64, *core.Message, *gasestimator.Options, error) {
 	// Retrieve the base state and mutate it with any overrides
 	state, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 	if state == nil || err != nil {
-		return 0, err
+		return 0, nil, nil, err
 	}
 	if err := overrides.Apply(state, nil); err != nil {
-		return 0, err
+		return 0, nil, nil, err
 	}
 	header = updateHeaderForPendingBlocks(blockNrOrHash, header)
 
+	// Apply the block overrides to the header once, so that the message and the
+	// posting cost are priced with the same base fee the estimate runs with.
+	header = blockOverrides.MakeHeader(header)
+
 	// Construct the gas estimator option from the user input
 	opts := &gasestimator.Options{
 		Config:           b.ChainConfig(),
@@ -958,7 +991,7 @@ func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNr
 		args.Gas = new(hexutil.Uint64)
 	}
 	if err := args.CallDefaults(gasCap, header.BaseFee, b.ChainConfig().ChainID); err != nil {
-		return 0, err
+		return 0, nil, nil, err
 	}
 	// Run the gas estimation and wrap any revertals into a custom return
 	// Arbitrum: this also appropriately recursively calls another args.ToMessage with increased gasCap by posterCostInL2Gas amount
@@ -968,7 +1001,7 @@ func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNr
 	if gasCap > 0 {
 		postingGas, err := core.RPCPostingGasHook(call, header, state)
 		if err != nil {
-			return 0, err
+			return 0, nil, nil, err
 		}
 		gasCap += postingGas
 	}
@@ -976,11 +1009,11 @@ func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNr
 	estimate, revert, err := gasestimator.Estimate(ctx, call, opts, gasCap)
 	if err != nil {
 		if errors.Is(err, vm.ErrExecutionReverted) {
-			return 0, newRevertError(revert)
+			return 0, nil, nil, newRevertError(revert)
 		}
-		return 0, err
+		return 0, nil, nil, err
 	}
-	return hexutil.Uint64(estimate), nil
+	return estimate, call, opts, nil
 }
 
 // EstimateGas returns the lowest possible gas limit that allows the transaction to run
@@ -995,7 +1028,7 @@ func (api *BlockChainAPI) EstimateGas(ctx context.Context, args TransactionArgs,
 		bNrOrHash = *blockNrOrHash
 	}
 	res, err := DoEstimateGas(ctx, api.b, args, bNrOrHash, overrides, blockOverrides, api.b.RPCGasCap())
-	if client := fallbackClientFor(api.b, err); client != nil {
+	if client := FallbackClientFor(api.b, err); client != nil {
 		var res hexutil.Uint64
 		err := client.CallContext(ctx, &res, "eth_estimateGas", args, blockNrOrHash, overrides)
 		return res, err
@@ -1006,6 +1039,85 @@ func (api *BlockChainAPI) EstimateGas(ctx context.Context, args TransactionArgs,
 	return res, err
 }
 
+// MultiGasResult is the gas used by an execution per resource kind.
+type MultiGasResult struct {
+	Unknown       hexutil.Uint64 `json:"unknown"`
+	Computation   hexutil.Uint64 `json:"computation"`
+	HistoryGrowth hexutil.Uint64 `json:"historyGrowth"`
+	StorageAccess hexutil.Uint64 `json:"storageAccess"`
+	StorageGrowth hexutil.Uint64 `json:"storageGrowth"`
+	Refund        hexutil.Uint64 `json:"refund"`
+	Total         hexutil.Uint64 `json:"total"`
+}
+
+func newMultiGasResult(gas *multigas.MultiGas) *MultiGasResult {
+	if gas == nil {
+		gas = multigas.ZeroGas()
+	}
+	return &MultiGasResult{
+		Unknown:       hexutil.Uint64(gas.Get(multigas.ResourceKindUnknown)),
+		Computation:   hexutil.Uint64(gas.Get(multigas.ResourceKindComputation)),
+		HistoryGrowth: hexutil.Uint64(gas.Get(multigas.ResourceKindHistoryGrowth)),
+		StorageAccess: hexutil.Uint64(gas.Get(multigas.ResourceKindStorageAccess)),
+		StorageGrowth: hexutil.Uint64(gas.Get(multigas.ResourceKindStorageGrowth)),
+		Refund:        hexutil.Uint64(gas.GetRefund()),
+		Total:         hexutil.Uint64(gas.SingleGas()),
+	}
+}
+
+// GasEstimateDetails is the result of eth_estimateGasDetailed, breaking the gas
+// estimate of eth_estimateGas down into its components.
+type GasEstimateDetails struct {
+	Gas      hexutil.Uint64  `json:"gas"`      // Total estimate, as returned by eth_estimateGas
+	GasForL2 hexutil.Uint64  `json:"gasForL2"` // Gas paying for the execution
+	GasForL1 hexutil.Uint64  `json:"gasForL1"` // Gas paying for posting the transaction to the parent chain
+	BaseFee  *hexutil.Big    `json:"baseFee"`  // Base fee the L1 posting cost was converted to gas with
+	MultiGas *MultiGasResult `json:"multiGas"` // Gas used by the execution per resource kind
+}
+
+// EstimateGasDetailed estimates the gas of a transaction like EstimateGas, but
+// breaks the estimate down into the gas paying for the execution and the gas
+// paying for posting the transaction to the parent chain. The execution part
+// is further broken down into the resource kinds it used.
+func (api *BlockChainAPI) EstimateGasDetailed(ctx context.Context, args TransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *override.StateOverride, blockOverrides *override.BlockOverrides) (*GasEstimateDetails, error) {
+	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+	estimate, call, opts, err := doEstimateGas(ctx, api.b, args, bNrOrHash, overrides, blockOverrides, api.b.RPCGasCap())
+	if client := FallbackClientFor(api.b, err); client != nil {
+		var res *GasEstimateDetails
+		err := client.CallContext(ctx, &res, "eth_estimateGasDetailed", args, blockNrOrHash, overrides, blockOverrides)
+		return res, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The posting cost is priced with the base fee of the block the estimate
+	// was executed in, including any overrides.
+	header := opts.Header
+	postingGas, err := core.RPCPostingGasHook(call, header, opts.State)
+	if err != nil {
+		return nil, err
+	}
+	postingGas = min(postingGas, estimate)
+
+	result, err := gasestimator.Execute(ctx, call, opts, estimate)
+	if err != nil {
+		return nil, err
+	}
+	details := &GasEstimateDetails{
+		Gas:      hexutil.Uint64(estimate),
+		GasForL2: hexutil.Uint64(estimate - postingGas),
+		GasForL1: hexutil.Uint64(postingGas),
+		MultiGas: newMultiGasResult(result.UsedMultiGas),
+	}
+	if header.BaseFee != nil {
+		details.BaseFee = (*hexutil.Big)(header.BaseFee)
+	}
+	return details, nil
+}
+
 // RPCMarshalHeader converts the given header to the RPC output .
 func RPCMarshalHeader(head *types.Header) map[string]interface{} {
 	result := map[string]interface{}{
@@ -1085,6 +1197,14 @@ func RPCMarshalBlock(block *types.Block, inclTx bool, fullTx bool, config *param
 	return fields
 }
 
+// RPCMarshalNitroHeader converts the given header to the RPC output, including
+// the Arbitrum Nitro information encoded in it.
+func RPCMarshalNitroHeader(head *types.Header) map[string]interface{} {
+	fields := RPCMarshalHeader(head)
+	fillArbitrumNitroHeaderInfo(head, fields)
+	return fields
+}
+
 func fillArbitrumNitroHeaderInfo(header *types.Header, fields map[string]interface{}) {
 	info := types.DeserializeHeaderExtraInformation(header)
 	fields["l1BlockNumber"] = hexutil.Uint64(info.L1BlockNumber)
@@ -1567,7 +1687,7 @@ func (api *TransactionAPI) GetTransactionCount(ctx context.Context, address comm
 	// Resolve block number and use its state to ask for the nonce
 	state, _, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 	if state == nil || err != nil {
-		if client := fallbackClientFor(api.b, err); client != nil {
+		if client := FallbackClientFor(api.b, err); client != nil {
 			var res hexutil.Uint64
 			err := client.CallContext(ctx, &res, "eth_getTransactionCount", address, blockNrOrHash)
 			return &res, err
@@ -1652,6 +1772,36 @@ func (api *TransactionAPI) GetTransactionReceipt(ctx context.Context, hash commo
 
 // marshalReceipt marshals a transaction receipt into a JSON object.
 func marshalReceipt(ctx context.Context, receipt *types.Receipt, blockHash common.Hash, blockNumber uint64, signer types.Signer, tx *types.Transaction, txIndex int, backend Backend) (map[string]interface{}, error) {
+	var header *types.Header
+	if backend.ChainConfig().IsArbitrum() {
+		var err error
+		header, err = backend.HeaderByHash(ctx, blockHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+	fields := MarshalReceipt(receipt, blockHash, blockNumber, signer, tx, txIndex, header, backend.ChainConfig())
+
+	if backend.ChainConfig().IsArbitrum() {
+		// If blockMetadata exists for the block containing this tx, then we will determine if it was timeboosted or not
+		// and add that info to the receipt object
+		blockMetadata, err := backend.BlockMetadataByNumber(ctx, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		if blockMetadata != nil {
+			fields["timeboosted"], err = blockMetadata.IsTxTimeboosted(txIndex)
+			if err != nil {
+				log.Error("Error checking if a tx was timeboosted", "txIndex", txIndex, "txHash", tx.Hash(), "err", err)
+			}
+		}
+	}
+	return fields, nil
+}
+
+// MarshalReceipt converts the given receipt to the RPC output. On Arbitrum chains
+// the header of the containing block is required to fill in the L1 information.
+func MarshalReceipt(receipt *types.Receipt, blockHash common.Hash, blockNumber uint64, signer types.Signer, tx *types.Transaction, txIndex int, header *types.Header, config *params.ChainConfig) map[string]interface{} {
 	from, _ := types.Sender(signer, tx)
 
 	fields := map[string]interface{}{
@@ -1689,14 +1839,10 @@ func marshalReceipt(ctx context.Context, receipt *types.Receipt, blockHash commo
 	if receipt.ContractAddress != (common.Address{}) {
 		fields["contractAddress"] = receipt.ContractAddress
 	}
-	if backend.ChainConfig().IsArbitrum() {
+	if config.IsArbitrum() {
 		fields["gasUsedForL1"] = hexutil.Uint64(receipt.GasUsedForL1)
 
-		header, err := backend.HeaderByHash(ctx, blockHash)
-		if err != nil {
-			return nil, err
-		}
-		if backend.ChainConfig().IsArbitrumNitro(header.Number) {
+		if config.IsArbitrumNitro(header.Number) {
 			fields["effectiveGasPrice"] = hexutil.Uint64(header.BaseFee.Uint64())
 			fields["l1BlockNumber"] = hexutil.Uint64(types.DeserializeHeaderExtraInformation(header).L1BlockNumber)
 		} else {
@@ -1709,21 +1855,8 @@ func marshalReceipt(ctx context.Context, receipt *types.Receipt, blockHash commo
 				fields["l1BlockNumber"] = hexutil.Uint64(arbTx.L1BlockNumber)
 			}
 		}
-
-		// If blockMetadata exists for the block containing this tx, then we will determine if it was timeboosted or not
-		// and add that info to the receipt object
-		blockMetadata, err := backend.BlockMetadataByNumber(ctx, blockNumber)
-		if err != nil {
-			return nil, err
-		}
-		if blockMetadata != nil {
-			fields["timeboosted"], err = blockMetadata.IsTxTimeboosted(txIndex)
-			if err != nil {
-				log.Error("Error checking if a tx was timeboosted", "txIndex", txIndex, "txHash", tx.Hash(), "err", err)
-			}
-		}
 	}
-	return fields, nil
+	return fields
 }
 
 // sign is a helper function that signs a transaction with the private key of the given address.
@@ -498,6 +498,9 @@ func (b testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber)
 	if number == rpc.PendingBlockNumber && b.pending != nil {
 		return b.pending.Header(), nil
 	}
+	if number == rpc.FinalizedBlockNumber {
+		return b.chain.CurrentFinalBlock(), nil
+	}
 	return b.chain.GetHeaderByNumber(uint64(number)), nil
 }
 func (b testBackend) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
@@ -940,6 +943,136 @@ func TestEstimateGas(t *testing.T) {
 	}
 }
 
+func TestEstimateGasDetailed(t *testing.T) {
+	// Not parallel, the posting gas hook is replaced for the test.
+	var (
+		accounts = newAccounts(1)
+		genesis  = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		contract = common.Address{0xc0, 0xde}
+		baseFee  = big.NewInt(params.GWei)
+	)
+	api := NewBlockChainAPI(newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+	}))
+
+	defer func(hook func(*core.Message, *types.Header, *state.StateDB) (uint64, error)) {
+		core.RPCPostingGasHook = hook
+	}(core.RPCPostingGasHook)
+	var postedBaseFees []*big.Int
+	core.RPCPostingGasHook = func(msg *core.Message, header *types.Header, statedb *state.StateDB) (uint64, error) {
+		postedBaseFees = append(postedBaseFees, header.BaseFee)
+		return 1000 + 16*uint64(len(msg.Data)), nil
+	}
+
+	var (
+		latest = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		call   = TransactionArgs{
+			From:  &accounts[0].addr,
+			To:    &contract,
+			Input: &hexutil.Bytes{0x01, 0x02},
+		}
+		// The contract only exists through the state override: PUSH1 42, PUSH0, SSTORE
+		code      = hexutil.Bytes(common.FromHex("602a5f55"))
+		overrides = override.StateOverride{
+			contract: override.OverrideAccount{Code: &code},
+		}
+		blockOverrides = override.BlockOverrides{BaseFeePerGas: (*hexutil.Big)(baseFee)}
+	)
+	want, err := api.EstimateGas(context.Background(), call, &latest, &overrides, &blockOverrides)
+	if err != nil {
+		t.Fatalf("failed to estimate gas: %v", err)
+	}
+	details, err := api.EstimateGasDetailed(context.Background(), call, &latest, &overrides, &blockOverrides)
+	if err != nil {
+		t.Fatalf("failed to estimate detailed gas: %v", err)
+	}
+	if details.Gas != want {
+		t.Errorf("gas mismatch: have %d, want %d", details.Gas, want)
+	}
+	if details.GasForL1 != 1032 {
+		t.Errorf("gas for L1 mismatch: have %d, want %d", details.GasForL1, 1032)
+	}
+	if details.GasForL2+details.GasForL1 != details.Gas {
+		t.Errorf("gas components don't add up: %d + %d != %d", details.GasForL2, details.GasForL1, details.Gas)
+	}
+	if details.BaseFee.ToInt().Cmp(baseFee) != 0 {
+		t.Errorf("base fee mismatch: have %v, want %v", details.BaseFee, baseFee)
+	}
+	// Both the gas cap and the reported split must be priced with the override.
+	for i, posted := range postedBaseFees {
+		if posted.Cmp(baseFee) != 0 {
+			t.Errorf("posting cost %d priced with base fee %v, want %v", i, posted, baseFee)
+		}
+	}
+	if details.MultiGas.Total == 0 || details.MultiGas.StorageGrowth == 0 {
+		t.Errorf("missing multigas dimensions: %+v", details.MultiGas)
+	}
+
+	// Without the state override there's no contract to execute.
+	details, err = api.EstimateGasDetailed(context.Background(), call, &latest, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to estimate detailed gas: %v", err)
+	}
+	if details.MultiGas.StorageGrowth != 0 {
+		t.Errorf("unexpected storage growth without contract: %+v", details.MultiGas)
+	}
+}
+
+// archiveFallbackBackend lacks the state of all blocks, redirecting requests
+// to an archive node.
+type archiveFallbackBackend struct {
+	Backend
+	client *detailsFallbackClient
+}
+
+func (b archiveFallbackBackend) StateAndHeaderByNumberOrHash(context.Context, rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
+	return nil, nil, &types.ErrUseArchiveFallback{BlockNum: 1}
+}
+
+func (b archiveFallbackBackend) ArchiveFallbackClient(uint64) types.FallbackClient {
+	return b.client
+}
+
+// detailsFallbackClient serves eth_estimateGasDetailed like an archive node.
+type detailsFallbackClient struct {
+	method string
+	args   []interface{}
+}
+
+func (c *detailsFallbackClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	c.method, c.args = method, args
+	return json.Unmarshal([]byte(`{"gas":"0x5300","gasForL2":"0x5208","gasForL1":"0xf8","baseFee":"0x3b9aca00"}`), result)
+}
+
+func TestEstimateGasDetailedFallback(t *testing.T) {
+	t.Parallel()
+
+	var (
+		client  = new(detailsFallbackClient)
+		backend = archiveFallbackBackend{Backend: newTestBackend(t, 1, &core.Genesis{Config: params.MergedTestChainConfig, Alloc: types.GenesisAlloc{}}, beacon.New(ethash.NewFaker()), nil), client: client}
+		api     = NewBlockChainAPI(backend)
+		number  = rpc.BlockNumberOrHashWithNumber(1)
+	)
+	details, err := api.EstimateGasDetailed(context.Background(), TransactionArgs{}, &number, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to estimate detailed gas: %v", err)
+	}
+	if client.method != "eth_estimateGasDetailed" {
+		t.Errorf("wrong forwarded method %q", client.method)
+	}
+	if len(client.args) != 4 {
+		t.Errorf("wrong number of forwarded arguments: %d", len(client.args))
+	}
+	if details.Gas != 0x5300 || details.GasForL2 != 0x5208 || details.GasForL1 != 0xf8 {
+		t.Errorf("wrong forwarded estimate: %+v", details)
+	}
+}
+
 func TestCall(t *testing.T) {
 	t.Parallel()
 
@@ -1216,6 +1349,29 @@ func TestCall(t *testing.T) {
 			},
 			want: "0x0000000000000000000000000000000000000000000000000000000000000000",
 		},
+		{
+			name:        "l1-block-number-override",
+			blockNumber: rpc.LatestBlockNumber,
+			call: TransactionArgs{
+				From: &accounts[1].addr,
+				// NUMBER PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN
+				Input: hex2Bytes("4360005260206000f3"),
+			},
+			blockOverrides: override.BlockOverrides{
+				Number:        (*hexutil.Big)(big.NewInt(11)),
+				L1BlockNumber: newUint64(1234),
+			},
+			want: "0x00000000000000000000000000000000000000000000000000000000000004d2",
+		},
+		{
+			name:        "unsupported arbos version override",
+			blockNumber: rpc.LatestBlockNumber,
+			call:        TransactionArgs{},
+			blockOverrides: override.BlockOverrides{
+				ArbOSVersion: newUint64(params.MaxArbosVersionSupported + 1),
+			},
+			expectErr: fmt.Errorf(`block override "arbOSVersion" %d exceeds the maximum supported version %d`, params.MaxArbosVersionSupported+1, params.MaxArbosVersionSupported),
+		},
 		{
 			name:        "unsupported block override beaconRoot",
 			blockNumber: rpc.LatestBlockNumber,
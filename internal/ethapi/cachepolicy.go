@@ -0,0 +1,150 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// finalizedParam tells which parameter of a cacheable method locates the data
+// its result depends on.
+type finalizedParam int
+
+const (
+	blockHashParam finalizedParam = iota
+	blockNumberParam
+	blockNumberOrHashParam
+	txHashParam
+	logFilterParam
+)
+
+// cacheableMethods are the methods whose results can be cached once the block
+// identified by their first parameter is finalized.
+var cacheableMethods = map[string]finalizedParam{
+	"eth_getBlockByHash":                   blockHashParam,
+	"eth_getBlockByNumber":                 blockNumberParam,
+	"eth_getHeaderByHash":                  blockHashParam,
+	"eth_getHeaderByNumber":                blockNumberParam,
+	"eth_getBlockReceipts":                 blockNumberOrHashParam,
+	"eth_getBlockTransactionCountByHash":   blockHashParam,
+	"eth_getBlockTransactionCountByNumber": blockNumberParam,
+	"eth_getTransactionByHash":             txHashParam,
+	"eth_getTransactionReceipt":            txHashParam,
+	"eth_getLogs":                          logFilterParam,
+	"debug_traceBlockByHash":               blockHashParam,
+	"debug_traceBlockByNumber":             blockNumberParam,
+	"debug_traceTransaction":               txHashParam,
+}
+
+// cachePolicy is an rpc.CachePolicy allowing to cache the results of methods
+// which only depend on finalized blocks.
+type cachePolicy struct {
+	b Backend
+}
+
+// NewCachePolicy creates a response cache policy for the chain of the backend.
+func NewCachePolicy(b Backend) rpc.CachePolicy {
+	return &cachePolicy{b}
+}
+
+// Cacheable implements rpc.CachePolicy.
+func (p *cachePolicy) Cacheable(method string) bool {
+	_, ok := cacheableMethods[method]
+	return ok
+}
+
+// Immutable implements rpc.CachePolicy.
+func (p *cachePolicy) Immutable(ctx context.Context, method string, params []json.RawMessage) bool {
+	kind, ok := cacheableMethods[method]
+	if !ok || len(params) == 0 {
+		return false
+	}
+	switch kind {
+	case blockHashParam:
+		var hash common.Hash
+		return json.Unmarshal(params[0], &hash) == nil && p.hashFinalized(ctx, hash)
+	case blockNumberParam:
+		var number rpc.BlockNumber
+		return json.Unmarshal(params[0], &number) == nil && p.numberFinalized(ctx, number)
+	case blockNumberOrHashParam:
+		var blockNrOrHash rpc.BlockNumberOrHash
+		if err := json.Unmarshal(params[0], &blockNrOrHash); err != nil {
+			return false
+		}
+		if hash, ok := blockNrOrHash.Hash(); ok {
+			return p.hashFinalized(ctx, hash)
+		}
+		number, _ := blockNrOrHash.Number()
+		return p.numberFinalized(ctx, number)
+	case txHashParam:
+		var hash common.Hash
+		if err := json.Unmarshal(params[0], &hash); err != nil {
+			return false
+		}
+		found, _, _, number, _ := p.b.GetTransaction(hash)
+		return found && p.numberFinalized(ctx, rpc.BlockNumber(number))
+	case logFilterParam:
+		var crit struct {
+			BlockHash *common.Hash     `json:"blockHash"`
+			FromBlock *rpc.BlockNumber `json:"fromBlock"`
+			ToBlock   *rpc.BlockNumber `json:"toBlock"`
+		}
+		if err := json.Unmarshal(params[0], &crit); err != nil {
+			return false
+		}
+		if crit.BlockHash != nil {
+			return p.hashFinalized(ctx, *crit.BlockHash)
+		}
+		// Omitted range bounds default to the latest block.
+		return crit.FromBlock != nil && *crit.FromBlock >= 0 && crit.ToBlock != nil && p.numberFinalized(ctx, *crit.ToBlock)
+	}
+	return false
+}
+
+// finalized returns the number of the latest finalized block, or zero if the
+// chain has no finalized block yet.
+func (p *cachePolicy) finalized(ctx context.Context) uint64 {
+	header, err := p.b.HeaderByNumber(ctx, rpc.FinalizedBlockNumber)
+	if err != nil || header == nil {
+		return 0
+	}
+	return header.Number.Uint64()
+}
+
+// numberFinalized reports whether the block with the given number is final.
+// Block tags are never final, as they refer to a different block over time.
+func (p *cachePolicy) numberFinalized(ctx context.Context, number rpc.BlockNumber) bool {
+	if number < 0 {
+		return false
+	}
+	finalized := p.finalized(ctx)
+	return finalized > 0 && uint64(number) <= finalized
+}
+
+// hashFinalized reports whether the block with the given hash is final.
+func (p *cachePolicy) hashFinalized(ctx context.Context, hash common.Hash) bool {
+	header, err := p.b.HeaderByHash(ctx, hash)
+	if err != nil || header == nil {
+		return false
+	}
+	finalized := p.finalized(ctx)
+	return finalized > 0 && header.Number.Uint64() <= finalized
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestCachePolicy(t *testing.T) {
+	t.Parallel()
+
+	genesis := &core.Genesis{Config: params.TestChainConfig, Alloc: types.GenesisAlloc{}}
+	backend := newTestBackend(t, 10, genesis, beacon.New(ethash.NewFaker()), nil)
+	policy := NewCachePolicy(backend)
+
+	hash := func(n uint64) string {
+		return fmt.Sprintf("%q", backend.chain.GetHeaderByNumber(n).Hash().Hex())
+	}
+	immutable := func(method string, params ...string) bool {
+		raw := make([]json.RawMessage, len(params))
+		for i, p := range params {
+			raw[i] = json.RawMessage(p)
+		}
+		return policy.Immutable(context.Background(), method, raw)
+	}
+	tests := []struct {
+		method string
+		params []string
+		want   bool
+	}{
+		{"eth_getBlockByNumber", []string{`"0x5"`, `false`}, true},
+		{"eth_getBlockByNumber", []string{`"0x6"`, `false`}, false},
+		{"eth_getBlockByNumber", []string{`"finalized"`, `false`}, false},
+		{"eth_getBlockByHash", []string{hash(4), `true`}, true},
+		{"eth_getBlockByHash", []string{hash(8), `true`}, false},
+		{"eth_getBlockReceipts", []string{hash(2)}, true},
+		{"eth_getBlockReceipts", []string{`"0x9"`}, false},
+		{"eth_getLogs", []string{`{"fromBlock":"0x1","toBlock":"0x5"}`}, true},
+		{"eth_getLogs", []string{`{"fromBlock":"0x1","toBlock":"0x7"}`}, false},
+		{"eth_getLogs", []string{`{"fromBlock":"0x1"}`}, false},
+		{"eth_getLogs", []string{`{"fromBlock":"earliest","toBlock":"0x2"}`}, false},
+		{"eth_getLogs", []string{`{"blockHash":` + hash(3) + `}`}, true},
+		{"debug_traceBlockByNumber", []string{`"0x3"`, `{}`}, true},
+		{"eth_getBalance", []string{`"0x0000000000000000000000000000000000000000"`, `"0x1"`}, false},
+	}
+
+	// Nothing is immutable until a block is finalized.
+	for _, test := range tests {
+		if immutable(test.method, test.params...) {
+			t.Errorf("%s%v immutable without finalized block", test.method, test.params)
+		}
+	}
+	backend.chain.SetFinalized(backend.chain.GetHeaderByNumber(5))
+	for _, test := range tests {
+		if have := immutable(test.method, test.params...); have != test.want {
+			t.Errorf("%s%v: immutable = %t, want %t", test.method, test.params, have, test.want)
+		}
+		if have := policy.Cacheable(test.method); have != (test.method != "eth_getBalance") {
+			t.Errorf("%s: wrong cacheability %t", test.method, have)
+		}
+	}
+}
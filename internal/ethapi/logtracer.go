@@ -147,5 +147,9 @@ func (t *tracer) reset(txHash common.Hash, txIdx uint) {
 }
 
 func (t *tracer) Logs() []*types.Log {
+	// Arbitrum: transactions ended by the StartTxHook never enter the EVM.
+	if len(t.logs) == 0 {
+		return nil
+	}
 	return t.logs[0]
 }
@@ -27,6 +27,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/holiman/uint256"
 )
 
@@ -134,6 +135,10 @@ type BlockOverrides struct {
 	BlobBaseFee   *hexutil.Big
 	BeaconRoot    *common.Hash
 	Withdrawals   *types.Withdrawals
+
+	// Arbitrum
+	L1BlockNumber *hexutil.Uint64 // L1 block number returned by NUMBER and used by BLOCKHASH
+	ArbOSVersion  *hexutil.Uint64 // ArbOS version gating forks and precompiles
 }
 
 // Apply overrides the given header fields into the given block context.
@@ -147,6 +152,16 @@ func (o *BlockOverrides) Apply(blockCtx *vm.BlockContext) error {
 	if o.Withdrawals != nil {
 		return errors.New(`block override "withdrawals" is not supported for this RPC method`)
 	}
+	if o.ArbOSVersion != nil {
+		if uint64(*o.ArbOSVersion) > params.MaxArbosVersionSupported {
+			return fmt.Errorf(`block override "arbOSVersion" %d exceeds the maximum supported version %d`, uint64(*o.ArbOSVersion), params.MaxArbosVersionSupported)
+		}
+		blockCtx.ArbOSVersion = uint64(*o.ArbOSVersion)
+	}
+	if o.L1BlockNumber != nil {
+		l1BlockNumber := uint64(*o.L1BlockNumber)
+		blockCtx.L1BlockNumberOverride = &l1BlockNumber
+	}
 	if o.Number != nil {
 		blockCtx.BlockNumber = o.Number.ToInt()
 	}
@@ -208,5 +223,26 @@ func (o *BlockOverrides) MakeHeader(header *types.Header) *types.Header {
 	if o.BaseFeePerGas != nil {
 		h.BaseFee = o.BaseFeePerGas.ToInt()
 	}
+	o.ApplyHeaderInfo(h)
 	return h
 }
+
+// ApplyHeaderInfo overrides the L1 block number and ArbOS version encoded in
+// the Arbitrum extra information of the given header. Headers that don't carry
+// such information (e.g. ones lacking a base fee) are left untouched.
+func (o *BlockOverrides) ApplyHeaderInfo(header *types.Header) {
+	if o == nil || (o.L1BlockNumber == nil && o.ArbOSVersion == nil) {
+		return
+	}
+	if header.BaseFee == nil || len(header.Extra) != 32 || header.Difficulty == nil || header.Difficulty.Cmp(common.Big1) != 0 {
+		return
+	}
+	info := types.DeserializeHeaderExtraInformation(header)
+	if o.L1BlockNumber != nil {
+		info.L1BlockNumber = uint64(*o.L1BlockNumber)
+	}
+	if o.ArbOSVersion != nil {
+		info.ArbOSFormatVersion = uint64(*o.ArbOSVersion)
+	}
+	info.UpdateHeaderWithInfo(header)
+}
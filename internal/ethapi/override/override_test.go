@@ -26,6 +26,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/triedb"
 )
 
@@ -120,6 +121,43 @@ func TestStateOverrideMovePrecompile(t *testing.T) {
 	}
 }
 
+func TestBlockOverridesArbitrum(t *testing.T) {
+	var (
+		l1BlockNumber = hexutil.Uint64(1234)
+		arbOSVersion  = hexutil.Uint64(params.ArbosVersion_32)
+		overrides     = &BlockOverrides{L1BlockNumber: &l1BlockNumber, ArbOSVersion: &arbOSVersion}
+	)
+	// Overrides are plumbed into the block context.
+	var blockCtx vm.BlockContext
+	if err := overrides.Apply(&blockCtx); err != nil {
+		t.Fatalf("failed to apply overrides: %v", err)
+	}
+	if blockCtx.L1BlockNumberOverride == nil || *blockCtx.L1BlockNumberOverride != 1234 {
+		t.Errorf("L1 block number mismatch: have %v, want 1234", blockCtx.L1BlockNumberOverride)
+	}
+	if blockCtx.ArbOSVersion != params.ArbosVersion_32 {
+		t.Errorf("ArbOS version mismatch: have %d, want %d", blockCtx.ArbOSVersion, params.ArbosVersion_32)
+	}
+	tooNew := hexutil.Uint64(params.MaxArbosVersionSupported + 1)
+	if err := (&BlockOverrides{ArbOSVersion: &tooNew}).Apply(&blockCtx); err == nil {
+		t.Error("applied unsupported ArbOS version")
+	}
+
+	// Overrides are encoded into Arbitrum headers, keeping the other fields.
+	header := &types.Header{Number: common.Big1, Difficulty: common.Big1, BaseFee: common.Big1}
+	types.HeaderInfo{SendRoot: common.Hash{0x01}, SendCount: 7, L1BlockNumber: 1, ArbOSFormatVersion: params.ArbosVersion_10}.UpdateHeaderWithInfo(header)
+
+	want := types.HeaderInfo{SendRoot: common.Hash{0x01}, SendCount: 7, L1BlockNumber: 1234, ArbOSFormatVersion: params.ArbosVersion_32}
+	if have := types.DeserializeHeaderExtraInformation(overrides.MakeHeader(header)); have != want {
+		t.Errorf("header info mismatch: have %+v, want %+v", have, want)
+	}
+	// Non-Arbitrum headers are left untouched.
+	plain := &types.Header{Number: common.Big1, Difficulty: common.Big0, BaseFee: common.Big1}
+	if have := overrides.MakeHeader(plain); have.MixDigest != (common.Hash{}) || len(have.Extra) != 0 {
+		t.Errorf("non-Arbitrum header modified: mix digest %x, extra %x", have.MixDigest, have.Extra)
+	}
+}
+
 func hex2Bytes(str string) *hexutil.Bytes {
 	rpcBytes := hexutil.Bytes(common.FromHex(str))
 	return &rpcBytes
@@ -52,6 +52,48 @@ type simBlock struct {
 	BlockOverrides *override.BlockOverrides
 	StateOverrides *override.StateOverride
 	Calls          []TransactionArgs
+
+	// Arbitrum: transactions which can't be expressed as TransactionArgs, keyed
+	// by their position in Calls. The TransactionArgs at these positions are unused.
+	arbCalls map[int]*simArbitrumCall
+}
+
+func (b *simBlock) UnmarshalJSON(input []byte) error {
+	var dec struct {
+		BlockOverrides *override.BlockOverrides
+		StateOverrides *override.StateOverride
+		Calls          []json.RawMessage
+	}
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	b.BlockOverrides = dec.BlockOverrides
+	b.StateOverrides = dec.StateOverrides
+	b.Calls = make([]TransactionArgs, len(dec.Calls))
+	b.arbCalls = nil
+	for i, raw := range dec.Calls {
+		var typ struct {
+			Type *hexutil.Uint64 `json:"type"`
+		}
+		if err := json.Unmarshal(raw, &typ); err != nil {
+			return err
+		}
+		if typ.Type != nil && isSimArbitrumType(uint64(*typ.Type)) {
+			call := new(simArbitrumCall)
+			if err := json.Unmarshal(raw, call); err != nil {
+				return err
+			}
+			if b.arbCalls == nil {
+				b.arbCalls = make(map[int]*simArbitrumCall)
+			}
+			b.arbCalls[i] = call
+			continue
+		}
+		if err := json.Unmarshal(raw, &b.Calls[i]); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // simCallResult is the result of a simulated call.
@@ -61,6 +103,10 @@ type simCallResult struct {
 	GasUsed     hexutil.Uint64 `json:"gasUsed"`
 	Status      hexutil.Uint64 `json:"status"`
 	Error       *callError     `json:"error,omitempty"`
+
+	// Arbitrum: hash of the transaction which scheduled this one, e.g. the
+	// submission of a retryable which is redeemed automatically.
+	ScheduledBy *common.Hash `json:"scheduledBy,omitempty"`
 }
 
 func (r *simCallResult) MarshalJSON() ([]byte, error) {
@@ -222,6 +268,8 @@ func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header,
 			}
 		}
 	}
+	// Arbitrum: the header extra information can only be overridden once the base fee is known.
+	block.BlockOverrides.ApplyHeaderInfo(header)
 	arbOSVersion := types.DeserializeHeaderExtraInformation(header).ArbOSFormatVersion
 	parentArbOSVersion := types.DeserializeHeaderExtraInformation(parent).ArbOSFormatVersion
 	if sim.chainConfig.IsCancun(header.Number, header.Time, arbOSVersion) {
@@ -235,6 +283,10 @@ func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header,
 	if block.BlockOverrides.BlobBaseFee != nil {
 		blockContext.BlobBaseFee = block.BlockOverrides.BlobBaseFee.ToInt()
 	}
+	if block.BlockOverrides.L1BlockNumber != nil {
+		l1BlockNumber := uint64(*block.BlockOverrides.L1BlockNumber)
+		blockContext.L1BlockNumberOverride = &l1BlockNumber
+	}
 	precompiles := sim.activePrecompiles(sim.base)
 	// State overrides are applied prior to execution of a block
 	if err := block.StateOverrides.Apply(sim.state, precompiles); err != nil {
@@ -242,9 +294,9 @@ func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header,
 	}
 	var (
 		gasUsed, blobGasUsed uint64
-		txes                 = make([]*types.Transaction, len(block.Calls))
-		callResults          = make([]simCallResult, len(block.Calls))
-		receipts             = make([]*types.Receipt, len(block.Calls))
+		txes                 = make([]*types.Transaction, 0, len(block.Calls))
+		callResults          = make([]simCallResult, 0, len(block.Calls))
+		receipts             = make([]*types.Receipt, 0, len(block.Calls))
 		// Block hash will be repaired after execution.
 		tracer   = newTracer(sim.traceTransfers, blockContext.BlockNumber.Uint64(), common.Hash{}, common.Hash{}, 0)
 		vmConfig = &vm.Config{
@@ -273,27 +325,16 @@ func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header,
 		core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, evm)
 	}
 	var allLogs []*types.Log
-	for i, call := range block.Calls {
-		if err := ctx.Err(); err != nil {
-			return nil, nil, nil, err
-		}
-		if err := sim.sanitizeCall(&call, sim.state, header, blockContext, &gasUsed); err != nil {
-			return nil, nil, nil, err
-		}
-		var (
-			tx     = call.ToTransaction(types.DynamicFeeTxType)
-			txHash = tx.Hash()
-		)
-		txes[i] = tx
-		senders[txHash] = call.from()
-		tracer.reset(txHash, uint(i))
-		sim.state.SetTxContext(txHash, i)
-		// EoA check is always skipped, even in validation mode.
-		msg := call.ToMessage(header.BaseFee, 0, nil, nil, core.NewMessageCommitContext(nil), !sim.validate, true)
+	// apply executes a single transaction of the block and records its result.
+	apply := func(tx *types.Transaction, msg *core.Message, scheduledBy *common.Hash) (*core.ExecutionResult, error) {
+		txHash := tx.Hash()
+		txes = append(txes, tx)
+		senders[txHash] = msg.From
+		tracer.reset(txHash, uint(len(txes)-1))
+		sim.state.SetTxContext(txHash, len(txes)-1)
 		result, err := applyMessageWithEVM(ctx, evm, msg, sim.state, timeout, sim.gp, sim.b, header, blockContext)
 		if err != nil {
-			txErr := txValidationError(err)
-			return nil, nil, nil, txErr
+			return nil, txValidationError(err)
 		}
 		// Update the state with pending changes.
 		var root []byte
@@ -303,10 +344,11 @@ func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header,
 			root = sim.state.IntermediateRoot(sim.chainConfig.IsEIP158(blockContext.BlockNumber)).Bytes()
 		}
 		gasUsed += result.UsedGas
-		receipts[i] = core.MakeReceipt(evm, result, sim.state, blockContext.BlockNumber, common.Hash{}, tx, gasUsed, root)
-		blobGasUsed += receipts[i].BlobGasUsed
+		receipt := core.MakeReceipt(evm, result, sim.state, blockContext.BlockNumber, common.Hash{}, tx, gasUsed, root)
+		receipts = append(receipts, receipt)
+		blobGasUsed += receipt.BlobGasUsed
 		logs := tracer.Logs()
-		callRes := simCallResult{ReturnValue: result.Return(), Logs: logs, GasUsed: hexutil.Uint64(result.UsedGas)}
+		callRes := simCallResult{ReturnValue: result.Return(), Logs: logs, GasUsed: hexutil.Uint64(result.UsedGas), ScheduledBy: scheduledBy}
 		if result.Failed() {
 			callRes.Status = hexutil.Uint64(types.ReceiptStatusFailed)
 			if errors.Is(result.Err, vm.ErrExecutionReverted) {
@@ -320,7 +362,63 @@ func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header,
 			callRes.Status = hexutil.Uint64(types.ReceiptStatusSuccessful)
 			allLogs = append(allLogs, callRes.Logs...)
 		}
-		callResults[i] = callRes
+		callResults = append(callResults, callRes)
+		return result, nil
+	}
+	for i, call := range block.Calls {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, nil, err
+		}
+		var (
+			tx  *types.Transaction
+			msg *core.Message
+		)
+		if arbCall := block.arbCalls[i]; arbCall != nil {
+			var err error
+			if tx, err = sim.arbitrumTransaction(arbCall, i, sim.state, header, blockContext, gasUsed); err != nil {
+				return nil, nil, nil, err
+			}
+			if msg, err = core.TransactionToMessage(tx, types.NewArbitrumSigner(nil), header.BaseFee, core.NewMessageCommitContext(nil)); err != nil {
+				return nil, nil, nil, err
+			}
+		} else {
+			if err := sim.sanitizeCall(&call, sim.state, header, blockContext, &gasUsed); err != nil {
+				return nil, nil, nil, err
+			}
+			tx = call.ToTransaction(types.DynamicFeeTxType)
+			// EoA check is always skipped, even in validation mode.
+			msg = call.ToMessage(header.BaseFee, 0, nil, nil, core.NewMessageCommitContext(nil), !sim.validate, true)
+		}
+		result, err := apply(tx, msg, nil)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		// Arbitrum: execute the transactions scheduled by the call within the
+		// same block, e.g. the auto-redeem of a submitted retryable.
+		type scheduledTx struct {
+			tx          *types.Transaction
+			scheduledBy common.Hash
+		}
+		var scheduled []scheduledTx
+		for _, next := range result.ScheduledTxes {
+			scheduled = append(scheduled, scheduledTx{next, tx.Hash()})
+		}
+		for len(scheduled) > 0 {
+			next := scheduled[0]
+			scheduled = scheduled[1:]
+
+			msg, err := core.TransactionToMessage(next.tx, types.NewArbitrumSigner(nil), header.BaseFee, core.NewMessageCommitContext(nil))
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			result, err := apply(next.tx, msg, &next.scheduledBy)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			for _, tx := range result.ScheduledTxes {
+				scheduled = append(scheduled, scheduledTx{tx, next.tx.Hash()})
+			}
+		}
 	}
 	header.GasUsed = gasUsed
 	if sim.chainConfig.IsCancun(header.Number, header.Time, parentArbOSVersion) {
@@ -417,6 +515,9 @@ func (sim *simulator) sanitizeChain(blocks []simBlock) ([]simBlock, error) {
 		if block.BlockOverrides.Withdrawals == nil {
 			block.BlockOverrides.Withdrawals = &types.Withdrawals{}
 		}
+		if v := block.BlockOverrides.ArbOSVersion; v != nil && uint64(*v) > params.MaxArbosVersionSupported {
+			return nil, &invalidParamsError{fmt.Sprintf("arbOSVersion %d exceeds the maximum supported version %d", uint64(*v), params.MaxArbosVersionSupported)}
+		}
 		diff := new(big.Int).Sub(block.BlockOverrides.Number.ToInt(), prevNumber)
 		if diff.Cmp(common.Big0) <= 0 {
 			return nil, &invalidBlockNumberError{fmt.Sprintf("block numbers must be in order: %d <= %d", block.BlockOverrides.Number.ToInt().Uint64(), prevNumber)}
@@ -0,0 +1,188 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// isSimArbitrumType reports whether calls of the given transaction type are
+// simulated as Arbitrum transactions instead of plain message calls.
+func isSimArbitrumType(typ uint64) bool {
+	switch typ {
+	case types.ArbitrumDepositTxType, types.ArbitrumUnsignedTxType, types.ArbitrumSubmitRetryableTxType:
+		return true
+	}
+	return false
+}
+
+// simArbitrumCall is an Arbitrum transaction to be simulated by eth_simulateV1.
+// Unset fields are filled with defaults, similar to TransactionArgs.
+type simArbitrumCall struct {
+	Type         hexutil.Uint64  `json:"type"`
+	ChainID      *hexutil.Big    `json:"chainId"`
+	RequestId    *common.Hash    `json:"requestId"`
+	From         *common.Address `json:"from"`
+	To           *common.Address `json:"to"`
+	Nonce        *hexutil.Uint64 `json:"nonce"`
+	Gas          *hexutil.Uint64 `json:"gas"`
+	MaxFeePerGas *hexutil.Big    `json:"maxFeePerGas"`
+	Value        *hexutil.Big    `json:"value"`
+	Data         *hexutil.Bytes  `json:"data"`
+	Input        *hexutil.Bytes  `json:"input"`
+
+	// Fields of ArbitrumSubmitRetryableTx
+	L1BaseFee        *hexutil.Big    `json:"l1BaseFee"`
+	DepositValue     *hexutil.Big    `json:"depositValue"`
+	RetryTo          *common.Address `json:"retryTo"`
+	RetryValue       *hexutil.Big    `json:"retryValue"`
+	RetryData        *hexutil.Bytes  `json:"retryData"`
+	Beneficiary      *common.Address `json:"beneficiary"`
+	MaxSubmissionFee *hexutil.Big    `json:"maxSubmissionFee"`
+	RefundTo         *common.Address `json:"refundTo"`
+}
+
+func (call *simArbitrumCall) from() common.Address {
+	if call.From == nil {
+		return common.Address{}
+	}
+	return *call.From
+}
+
+func (call *simArbitrumCall) data() []byte {
+	if call.Input != nil {
+		return *call.Input
+	}
+	if call.Data != nil {
+		return *call.Data
+	}
+	return nil
+}
+
+// bigOrZero returns the value of the given field, or zero if it's unset.
+func bigOrZero(v *hexutil.Big) *big.Int {
+	if v == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(v.ToInt())
+}
+
+// arbitrumTransaction assembles the Arbitrum transaction of the index'th call
+// in the simulated block, filling in the defaults of unset fields.
+func (sim *simulator) arbitrumTransaction(call *simArbitrumCall, index int, state vm.StateDB, header *types.Header, blockContext vm.BlockContext, gasUsed uint64) (*types.Transaction, error) {
+	if !sim.chainConfig.IsArbitrum() {
+		return nil, &invalidParamsError{fmt.Sprintf("transaction type %#x requires an Arbitrum chain", uint64(call.Type))}
+	}
+	chainID := sim.chainConfig.ChainID
+	if call.ChainID != nil {
+		if have := call.ChainID.ToInt(); have.Cmp(chainID) != 0 {
+			return nil, &invalidParamsError{fmt.Sprintf("chainId does not match node's (have=%v, want=%v)", have, chainID)}
+		}
+	}
+	// The request id identifies the L1 message, it has to be unique for the
+	// transaction hashes (and retryable ticket ids) to be distinct.
+	requestId := call.RequestId
+	if requestId == nil {
+		var index64 [8]byte
+		binary.BigEndian.PutUint64(index64[:], uint64(index))
+		id := crypto.Keccak256Hash(header.Number.Bytes(), index64[:])
+		requestId = &id
+	}
+	// Let the call run wild unless explicitly specified.
+	gas := blockContext.GasLimit - gasUsed
+	if call.Gas != nil {
+		gas = uint64(*call.Gas)
+	}
+	if gasUsed+gas > blockContext.GasLimit {
+		return nil, &blockGasLimitReachedError{fmt.Sprintf("block gas limit reached: %d >= %d", gasUsed, blockContext.GasLimit)}
+	}
+	gasFeeCap := new(big.Int)
+	if call.MaxFeePerGas != nil {
+		gasFeeCap = call.MaxFeePerGas.ToInt()
+	} else if header.BaseFee != nil {
+		gasFeeCap = new(big.Int).Set(header.BaseFee)
+	}
+	switch call.Type {
+	case types.ArbitrumDepositTxType:
+		if call.To == nil {
+			return nil, &invalidParamsError{"missing field 'to' of deposit transaction"}
+		}
+		return types.NewTx(&types.ArbitrumDepositTx{
+			ChainId:     chainID,
+			L1RequestId: *requestId,
+			From:        call.from(),
+			To:          *call.To,
+			Value:       bigOrZero(call.Value),
+		}), nil
+
+	case types.ArbitrumUnsignedTxType:
+		nonce := state.GetNonce(call.from())
+		if call.Nonce != nil {
+			nonce = uint64(*call.Nonce)
+		}
+		return types.NewTx(&types.ArbitrumUnsignedTx{
+			ChainId:   chainID,
+			From:      call.from(),
+			Nonce:     nonce,
+			GasFeeCap: gasFeeCap,
+			Gas:       gas,
+			To:        call.To,
+			Value:     bigOrZero(call.Value),
+			Data:      call.data(),
+		}), nil
+
+	case types.ArbitrumSubmitRetryableTxType:
+		var (
+			beneficiary = call.from()
+			refundTo    = call.from()
+			retryData   []byte
+		)
+		if call.Beneficiary != nil {
+			beneficiary = *call.Beneficiary
+		}
+		if call.RefundTo != nil {
+			refundTo = *call.RefundTo
+		}
+		if call.RetryData != nil {
+			retryData = *call.RetryData
+		}
+		return types.NewTx(&types.ArbitrumSubmitRetryableTx{
+			ChainId:          chainID,
+			RequestId:        *requestId,
+			From:             call.from(),
+			L1BaseFee:        bigOrZero(call.L1BaseFee),
+			DepositValue:     bigOrZero(call.DepositValue),
+			GasFeeCap:        gasFeeCap,
+			Gas:              gas,
+			RetryTo:          call.RetryTo,
+			RetryValue:       bigOrZero(call.RetryValue),
+			Beneficiary:      beneficiary,
+			MaxSubmissionFee: bigOrZero(call.MaxSubmissionFee),
+			FeeRefundAddr:    refundTo,
+			RetryData:        retryData,
+		}), nil
+	}
+	return nil, &invalidParamsError{fmt.Sprintf("unsupported transaction type %#x", uint64(call.Type))}
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestSimulateDecodeArbitrumCalls(t *testing.T) {
+	var block simBlock
+	err := json.Unmarshal([]byte(`{
+		"calls": [
+			{"from": "0x00000000000000000000000000000000000000aa", "to": "0x00000000000000000000000000000000000000bb"},
+			{"type": "0x64", "from": "0x00000000000000000000000000000000000000aa", "to": "0x00000000000000000000000000000000000000bb", "value": "0x1"},
+			{"type": "0x69", "from": "0x00000000000000000000000000000000000000aa", "retryTo": "0x00000000000000000000000000000000000000bb", "retryData": "0x01"}
+		]
+	}`), &block)
+	if err != nil {
+		t.Fatalf("failed to decode block: %v", err)
+	}
+	if len(block.Calls) != 3 {
+		t.Fatalf("call count mismatch: have %d, want 3", len(block.Calls))
+	}
+	if block.Calls[0].To == nil || *block.Calls[0].To != common.HexToAddress("0xbb") {
+		t.Fatalf("plain call not decoded: %+v", block.Calls[0])
+	}
+	if _, ok := block.arbCalls[0]; ok {
+		t.Fatal("plain call decoded as Arbitrum transaction")
+	}
+	if call := block.arbCalls[1]; call == nil || call.Type != types.ArbitrumDepositTxType || call.Value.ToInt().Uint64() != 1 {
+		t.Fatalf("deposit not decoded: %+v", call)
+	}
+	if call := block.arbCalls[2]; call == nil || call.Type != types.ArbitrumSubmitRetryableTxType || *call.RetryTo != common.HexToAddress("0xbb") {
+		t.Fatalf("retryable submission not decoded: %+v", call)
+	}
+}
+
+// testRetryableHook emulates the auto-redeem of retryables, scheduling a retry
+// for every submitted retryable.
+type testRetryableHook struct {
+	vm.TxProcessingHook
+	msg *core.Message
+}
+
+func (h *testRetryableHook) submission() *types.ArbitrumSubmitRetryableTx {
+	if h.msg.Tx == nil {
+		return nil
+	}
+	tx, _ := h.msg.Tx.GetInner().(*types.ArbitrumSubmitRetryableTx)
+	return tx
+}
+
+func (h *testRetryableHook) StartTxHook() (bool, uint64, error, []byte) {
+	if h.submission() != nil {
+		return true, 0, nil, nil
+	}
+	return h.TxProcessingHook.StartTxHook()
+}
+
+func (h *testRetryableHook) ScheduledTxes() types.Transactions {
+	sub := h.submission()
+	if sub == nil {
+		return nil
+	}
+	return types.Transactions{types.NewTx(&types.ArbitrumRetryTx{
+		ChainId:             sub.ChainId,
+		From:                sub.From,
+		GasFeeCap:           new(big.Int),
+		Gas:                 100000,
+		To:                  sub.RetryTo,
+		Value:               sub.RetryValue,
+		Data:                sub.RetryData,
+		TicketId:            h.msg.Tx.Hash(),
+		RefundTo:            sub.FeeRefundAddr,
+		MaxRefund:           new(big.Int),
+		SubmissionFeeRefund: new(big.Int),
+	})}
+}
+
+func TestSimulateRetryableRedeem(t *testing.T) {
+	var (
+		config  = *params.TestChainConfig
+		sender  = common.HexToAddress("0xaa")
+		storage = common.HexToAddress("0xbb")
+	)
+	config.ArbitrumChainParams.EnableArbOS = true
+
+	// Stores the first calldata word in slot 0 if calldata is given, otherwise
+	// returns slot 0.
+	code := common.FromHex("3615600c57600035600055005b60005460005260206000f3")
+	genesis := &core.Genesis{
+		Config: &config,
+		Alloc:  types.GenesisAlloc{storage: {Code: code}},
+	}
+	api := NewBlockChainAPI(newTestBackend(t, 0, genesis, ethash.NewFaker(), nil))
+
+	prev := core.ReadyEVMForL2
+	core.ReadyEVMForL2 = func(evm *vm.EVM, msg *core.Message) {
+		base := evm.ProcessingHook
+		if hook, ok := base.(*testRetryableHook); ok {
+			base = hook.TxProcessingHook
+		}
+		evm.ProcessingHook = &testRetryableHook{TxProcessingHook: base, msg: msg}
+	}
+	defer func() { core.ReadyEVMForL2 = prev }()
+
+	var opts simOpts
+	err := json.Unmarshal([]byte(`{
+		"blockStateCalls": [{
+			"calls": [
+				{"type": "0x69", "from": "0x00000000000000000000000000000000000000aa", "retryTo": "0x00000000000000000000000000000000000000bb", "retryData": "0x000000000000000000000000000000000000000000000000000000000000002a"},
+				{"from": "0x00000000000000000000000000000000000000aa", "to": "0x00000000000000000000000000000000000000bb"}
+			]
+		}]
+	}`), &opts)
+	if err != nil {
+		t.Fatalf("failed to decode options: %v", err)
+	}
+	results, err := api.SimulateV1(context.Background(), opts, nil)
+	if err != nil {
+		t.Fatalf("failed to simulate: %v", err)
+	}
+	var (
+		block = results[0].Block
+		calls = results[0].Calls
+	)
+	if len(calls) != 3 || len(block.Transactions()) != 3 {
+		t.Fatalf("result count mismatch: have %d calls and %d txs, want 3", len(calls), len(block.Transactions()))
+	}
+	var (
+		submission = block.Transactions()[0]
+		retry      = block.Transactions()[1]
+	)
+	if submission.Type() != types.ArbitrumSubmitRetryableTxType || retry.Type() != types.ArbitrumRetryTxType {
+		t.Fatalf("transaction type mismatch: have %#x and %#x", submission.Type(), retry.Type())
+	}
+	if calls[0].ScheduledBy != nil || calls[2].ScheduledBy != nil {
+		t.Fatal("top-level calls reported as scheduled")
+	}
+	if calls[1].ScheduledBy == nil || *calls[1].ScheduledBy != submission.Hash() {
+		t.Fatalf("retry not attributed to its submission: %v", calls[1].ScheduledBy)
+	}
+	if calls[1].Status != hexutil.Uint64(types.ReceiptStatusSuccessful) {
+		t.Fatalf("retry failed: %v", calls[1].Error)
+	}
+	if have := new(big.Int).SetBytes(calls[2].ReturnValue); have.Uint64() != 42 {
+		t.Fatalf("retry effect not visible to later calls: have %v, want 42", have)
+	}
+	if from := results[0].senders[retry.Hash()]; from != sender {
+		t.Fatalf("retry sender mismatch: have %v, want %v", from, sender)
+	}
+}
+
+func TestSimulateArbitrumCallRequiresArbitrum(t *testing.T) {
+	genesis := &core.Genesis{Config: params.TestChainConfig, Alloc: types.GenesisAlloc{}}
+	api := NewBlockChainAPI(newTestBackend(t, 0, genesis, ethash.NewFaker(), nil))
+
+	var opts simOpts
+	err := json.Unmarshal([]byte(`{
+		"blockStateCalls": [{
+			"calls": [{"type": "0x64", "from": "0x00000000000000000000000000000000000000aa", "to": "0x00000000000000000000000000000000000000bb"}]
+		}]
+	}`), &opts)
+	if err != nil {
+		t.Fatalf("failed to decode options: %v", err)
+	}
+	_, err = api.SimulateV1(context.Background(), opts, nil)
+	if _, ok := err.(*invalidParamsError); !ok {
+		t.Fatalf("expected invalid params error, have %v", err)
+	}
+}
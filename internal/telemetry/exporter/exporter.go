@@ -0,0 +1,203 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package exporter creates the OpenTelemetry tracer providers exporting the
+// spans recorded through the telemetry package.
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Config configures the span exporter, see node.TracingConfig.
+type Config struct {
+	Endpoint    string
+	SampleRatio float64
+	ServiceName string
+}
+
+var errorHandlerOnce sync.Once
+
+// NewTracerProvider creates a tracer provider exporting to the configured
+// endpoint, or returns nil if tracing is disabled. The provider is not
+// installed globally; the caller passes it to the components it traces and
+// shuts it down when done, flushing the pending spans.
+func NewTracerProvider(config Config) (*sdktrace.TracerProvider, error) {
+	if config.Endpoint == "" {
+		return nil, nil
+	}
+	exporter, name, err := newExporter(config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	service := config.ServiceName
+	if service == "" {
+		service = "geth"
+	}
+	errorHandlerOnce.Do(func() {
+		otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+			log.Warn("Tracing error", "err", err)
+		}))
+	})
+	log.Info("Enabled tracing", "endpoint", name, "ratio", config.SampleRatio)
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", service))),
+	), nil
+}
+
+// newExporter creates the span exporter for the endpoint, also returning the
+// endpoint without credentials for logging.
+func newExporter(endpoint string) (sdktrace.SpanExporter, string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid tracing endpoint: %w", err)
+	}
+	switch u.Scheme {
+	case "file":
+		path := u.Path
+		if path == "" {
+			path = u.Opaque
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			return nil, "", err
+		}
+		exporter, err := otlptrace.New(context.Background(), &fileClient{file: f})
+		if err != nil {
+			f.Close()
+			return nil, "", err
+		}
+		return exporter, path, nil
+	case "http", "https":
+		if u.Path == "" || u.Path == "/" {
+			u.Path = "/v1/traces"
+		}
+		exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(u.String()))
+		if err != nil {
+			return nil, "", err
+		}
+		return exporter, u.Redacted(), nil
+	default:
+		return nil, "", fmt.Errorf("unsupported tracing endpoint %q", endpoint)
+	}
+}
+
+// fileClient appends the exported spans to a file, one OTLP/JSON encoded
+// ExportTraceServiceRequest per line, like the file exporter of the
+// OpenTelemetry collector.
+type fileClient struct {
+	lock sync.Mutex
+	file *os.File
+}
+
+// Start implements otlptrace.Client.
+func (c *fileClient) Start(ctx context.Context) error {
+	return nil
+}
+
+// Stop implements otlptrace.Client.
+func (c *fileClient) Stop(ctx context.Context) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.file.Close()
+}
+
+// UploadTraces implements otlptrace.Client.
+func (c *fileClient) UploadTraces(ctx context.Context, spans []*tracepb.ResourceSpans) error {
+	data, err := marshalOTLPJSON(&coltracepb.ExportTraceServiceRequest{ResourceSpans: spans})
+	if err != nil {
+		return err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err = c.file.Write(append(data, '\n'))
+	return err
+}
+
+// marshalOTLPJSON encodes the request as OTLP/JSON. It differs from the
+// standard protobuf JSON mapping in encoding enums as integers and trace and
+// span IDs as hex instead of base64 strings.
+func marshalOTLPJSON(req *coltracepb.ExportTraceServiceRequest) ([]byte, error) {
+	data, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		fields map[string]interface{}
+		dec    = json.NewDecoder(bytes.NewReader(data))
+	)
+	dec.UseNumber()
+	if err := dec.Decode(&fields); err != nil {
+		return nil, err
+	}
+	if err := hexIDs(fields); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// hexIDs re-encodes the trace and span IDs within the decoded JSON value as hex.
+func hexIDs(value interface{}) error {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			switch key {
+			case "traceId", "spanId", "parentSpanId":
+				id, ok := field.(string)
+				if !ok {
+					return fmt.Errorf("invalid %s %v", key, field)
+				}
+				raw, err := base64.StdEncoding.DecodeString(id)
+				if err != nil {
+					return fmt.Errorf("invalid %s %q: %w", key, id, err)
+				}
+				value[key] = hex.EncodeToString(raw)
+			default:
+				if err := hexIDs(field); err != nil {
+					return err
+				}
+			}
+		}
+	case []interface{}:
+		for _, elem := range value {
+			if err := hexIDs(elem); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/internal/telemetry"
+	"go.opentelemetry.io/otel"
+)
+
+// exportedRequest is the part of the OTLP/JSON encoding of the exported
+// requests checked by the test.
+type exportedRequest struct {
+	ResourceSpans []struct {
+		Resource struct {
+			Attributes []exportedAttribute
+		}
+		ScopeSpans []struct {
+			Spans []exportedSpan
+		}
+	}
+}
+
+type exportedAttribute struct {
+	Key   string
+	Value struct{ StringValue string }
+}
+
+type exportedSpan struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string
+	Kind         int
+	Attributes   []exportedAttribute
+	Status       struct {
+		Code    int
+		Message string
+	}
+}
+
+func TestFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans.jsonl")
+	tp, err := NewTracerProvider(Config{Endpoint: "file://" + path, SampleRatio: 1, ServiceName: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	global := otel.GetTracerProvider()
+
+	// Continue a trace started by a remote caller.
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+	header := make(http.Header)
+	header.Set("traceparent", "00-"+traceID+"-"+spanID+"-01")
+	ctx := telemetry.WithTracerProvider(telemetry.Extract(context.Background(), header), tp)
+
+	ctx, _, endServer := telemetry.StartServerSpan(ctx, "eth_call", telemetry.StringAttribute("rpc.method", "eth_call"))
+	_, _, endInner := telemetry.StartSpan(ctx, "inner", telemetry.Int64Attribute("depth", 3), telemetry.BoolAttribute("live", true))
+	callErr := errors.New("execution reverted")
+	endInner(&callErr)
+	endServer(nil)
+
+	// Outgoing requests carry the local span as parent.
+	out := make(http.Header)
+	telemetry.Inject(ctx, out)
+	if tp := out.Get("traceparent"); !strings.HasPrefix(tp, "00-"+traceID+"-") || strings.Contains(tp, spanID) {
+		t.Errorf("wrong outgoing traceparent %q", tp)
+	}
+	if err := tp.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if otel.GetTracerProvider() != global {
+		t.Error("global tracer provider replaced")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		spans    []exportedSpan
+		resource []exportedAttribute
+		dec      = json.NewDecoder(bytes.NewReader(data))
+	)
+	for dec.More() {
+		var req exportedRequest
+		if err := dec.Decode(&req); err != nil {
+			t.Fatalf("invalid export %q: %v", data, err)
+		}
+		for _, rs := range req.ResourceSpans {
+			resource = rs.Resource.Attributes
+			for _, ss := range rs.ScopeSpans {
+				spans = append(spans, ss.Spans...)
+			}
+		}
+	}
+	if len(spans) != 2 {
+		t.Fatalf("wrong number of spans: %d", len(spans))
+	}
+	inner, server := spans[0], spans[1]
+	if server.Name != "eth_call" || server.TraceID != traceID || server.ParentSpanID != spanID || server.Kind != 2 {
+		t.Errorf("server span not continuing the remote trace: %+v", server)
+	}
+	if inner.Name != "inner" || inner.TraceID != traceID || inner.ParentSpanID != server.SpanID || inner.Kind != 1 {
+		t.Errorf("inner span not a child of the server span: %+v", inner)
+	}
+	if inner.Status.Code != 2 || inner.Status.Message != callErr.Error() {
+		t.Errorf("wrong inner span status: %+v", inner.Status)
+	}
+	if len(inner.Attributes) != 2 || inner.Attributes[0].Key != "depth" || inner.Attributes[1].Key != "live" {
+		t.Errorf("wrong inner span attributes: %+v", inner.Attributes)
+	}
+	if len(resource) != 1 || resource[0].Key != "service.name" || resource[0].Value.StringValue != "test" {
+		t.Errorf("wrong resource: %+v", resource)
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	tp, err := NewTracerProvider(Config{})
+	if tp != nil || err != nil {
+		t.Fatalf("tracer provider created without endpoint: %v %v", tp, err)
+	}
+	if _, err := NewTracerProvider(Config{Endpoint: "udp://localhost:4318"}); err == nil {
+		t.Fatal("no error for unsupported endpoint")
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package telemetry implements request-scoped tracing with OpenTelemetry.
+//
+// Spans are created through the tracer provider carried by the context, see
+// WithTracerProvider, or the global tracer provider otherwise, which does
+// nothing unless the process installs one. Trace context is carried across
+// process boundaries in W3C traceparent headers.
+//
+// The package only depends on the OpenTelemetry API. Tracer providers
+// exporting the spans are created by the exporter package.
+package telemetry
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/ethereum/go-ethereum"
+
+// propagator reads and writes W3C trace context headers. It is used even if
+// tracing is disabled locally, so that traces started by callers continue in
+// the requests sent to upstream nodes.
+var propagator = propagation.TraceContext{}
+
+// Attribute is a key-value pair attached to a span.
+type Attribute = attribute.KeyValue
+
+// StringAttribute creates a string-valued span attribute.
+func StringAttribute(key, value string) Attribute {
+	return attribute.String(key, value)
+}
+
+// Int64Attribute creates an integer-valued span attribute.
+func Int64Attribute(key string, value int64) Attribute {
+	return attribute.Int64(key, value)
+}
+
+// BoolAttribute creates a boolean span attribute.
+func BoolAttribute(key string, value bool) Attribute {
+	return attribute.Bool(key, value)
+}
+
+// StartSpan starts an internal span as a child of the span in ctx. The returned
+// function ends the span and must be called with a pointer to the error of the
+// traced operation, which may point to nil.
+func StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, trace.Span, func(*error)) {
+	return startSpan(ctx, name, trace.SpanKindInternal, attrs)
+}
+
+// StartServerSpan starts a span for serving a request from a remote caller.
+func StartServerSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, trace.Span, func(*error)) {
+	return startSpan(ctx, name, trace.SpanKindServer, attrs)
+}
+
+// StartClientSpan starts a span for a request sent to a remote server.
+func StartClientSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, trace.Span, func(*error)) {
+	return startSpan(ctx, name, trace.SpanKindClient, attrs)
+}
+
+type tracerProviderKey struct{}
+
+// WithTracerProvider returns a copy of ctx in which spans are created by the
+// given tracer provider instead of the global one.
+func WithTracerProvider(ctx context.Context, tp trace.TracerProvider) context.Context {
+	return context.WithValue(ctx, tracerProviderKey{}, tp)
+}
+
+// tracerProvider returns the tracer provider creating the spans in ctx.
+func tracerProvider(ctx context.Context) trace.TracerProvider {
+	if tp, ok := ctx.Value(tracerProviderKey{}).(trace.TracerProvider); ok {
+		return tp
+	}
+	return otel.GetTracerProvider()
+}
+
+func startSpan(ctx context.Context, name string, kind trace.SpanKind, attrs []Attribute) (context.Context, trace.Span, func(*error)) {
+	ctx, span := tracerProvider(ctx).Tracer(instrumentationName).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+	end := func(err *error) {
+		if err != nil && *err != nil {
+			span.RecordError(*err)
+			span.SetStatus(codes.Error, (*err).Error())
+		}
+		span.End()
+	}
+	return ctx, span, end
+}
+
+// Extract returns a copy of ctx carrying the remote span context found in the
+// trace headers of an incoming request.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// Inject adds the trace headers for the span in ctx to an outgoing request.
+func Inject(ctx context.Context, header http.Header) {
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
@@ -181,6 +181,13 @@ func (api *adminAPI) StartHTTP(host *string, port *int, cors *string, apis *stri
 		rpcEndpointConfig: rpcEndpointConfig{
 			batchItemLimit:         api.node.config.BatchRequestLimit,
 			batchResponseSizeLimit: api.node.config.BatchResponseMaxSize,
+			batchConcurrency:       api.node.config.BatchRequestConcurrency,
+			quota:                  api.node.quota,
+			accessLog:              api.node.config.RPCAccessLog,
+			tracerProvider:         api.node.tracerProvider(),
+			apiFilter:              api.node.apiFilter,
+			responseCache:          api.node.responseCache,
+			middleware:             api.node.rpcMiddleware,
 		},
 	}
 	if cors != nil {
@@ -259,6 +266,13 @@ func (api *adminAPI) StartWS(host *string, port *int, allowedOrigins *string, ap
 		rpcEndpointConfig: rpcEndpointConfig{
 			batchItemLimit:         api.node.config.BatchRequestLimit,
 			batchResponseSizeLimit: api.node.config.BatchResponseMaxSize,
+			batchConcurrency:       api.node.config.BatchRequestConcurrency,
+			quota:                  api.node.quota,
+			accessLog:              api.node.config.RPCAccessLog,
+			tracerProvider:         api.node.tracerProvider(),
+			apiFilter:              api.node.apiFilter,
+			responseCache:          api.node.responseCache,
+			middleware:             api.node.rpcMiddleware,
 		},
 	}
 	if apis != nil {
@@ -297,6 +311,38 @@ func (api *adminAPI) StopWS() (bool, error) {
 	return true, nil
 }
 
+// SetRPCModules changes the modules served by the running "http" or "ws" endpoint.
+// An empty list enables all public modules. If persist is set, the new module list
+// is also written to the config file.
+func (api *adminAPI) SetRPCModules(transport string, modules []string, persist *bool) (bool, error) {
+	if err := api.node.SetRPCModules(transport, modules); err != nil {
+		return false, err
+	}
+	if persist != nil && *persist {
+		field := "HTTPModules"
+		if transport == "ws" {
+			field = "WSModules"
+		}
+		if err := api.node.persistConfig(field); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// SetMethodAllowList changes the methods callable via HTTP and WebSocket. An empty
+// list allows all methods of the served modules. If persist is set, the new list
+// is also written to the config file.
+func (api *adminAPI) SetMethodAllowList(methods []string, persist *bool) (bool, error) {
+	api.node.SetMethodAllowList(methods)
+	if persist != nil && *persist {
+		if err := api.node.persistConfig("RPCAllowMethods"); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 // Peers retrieves all the information we know about each individual peer at the
 // protocol granularity.
 func (api *adminAPI) Peers() ([]*p2p.PeerInfo, error) {
@@ -22,6 +22,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"testing"
 
@@ -298,6 +299,104 @@ func TestStartRPC(t *testing.T) {
 	}
 }
 
+// This test checks that admin_setRPCModules and admin_setMethodAllowList change
+// the methods available via HTTP and WebSocket without restarting the endpoints.
+func TestSetRPCModules(t *testing.T) {
+	stack, err := New(&Config{
+		HTTPHost:    "127.0.0.1",
+		HTTPModules: []string{"test"},
+		WSHost:      "127.0.0.1",
+		WSModules:   []string{"test"},
+	})
+	if err != nil {
+		t.Fatal("can't create node:", err)
+	}
+	defer stack.Close()
+	stack.RegisterAPIs(apis())
+	if err := stack.Start(); err != nil {
+		t.Fatal("can't start node:", err)
+	}
+	api := &adminAPI{stack}
+
+	var (
+		persisted *Config
+		changed   []string
+	)
+	stack.SetConfigWriter(func(cfg *Config, fields []string) error {
+		c := *cfg
+		persisted, changed = &c, fields
+		return nil
+	})
+	callable := func(url, method string) bool {
+		t.Helper()
+		c, err := rpc.Dial(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer c.Close()
+		return c.Call(nil, method) == nil
+	}
+	httpURL, wsURL := stack.HTTPEndpoint(), stack.WSEndpoint()
+
+	if !callable(httpURL, "test_greet") || !callable(wsURL, "test_greet") {
+		t.Fatal("test module not available initially")
+	}
+	if _, err := api.SetRPCModules("http", []string{"web3"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if callable(httpURL, "test_greet") || !callable(httpURL, "web3_clientVersion") {
+		t.Error("HTTP modules not changed")
+	}
+	if !callable(wsURL, "test_greet") || callable(wsURL, "web3_clientVersion") {
+		t.Error("WS modules changed along with HTTP")
+	}
+	persist := true
+	if _, err := api.SetRPCModules("ws", nil, &persist); err != nil {
+		t.Fatal(err)
+	}
+	if !callable(wsURL, "test_greet") || !callable(wsURL, "web3_clientVersion") {
+		t.Error("WS modules not changed")
+	}
+	if persisted == nil || len(persisted.WSModules) != 0 || len(persisted.HTTPModules) != 1 {
+		t.Errorf("wrong persisted modules: %+v", persisted)
+	}
+	if !slices.Equal(changed, []string{"WSModules"}) {
+		t.Errorf("wrong persisted fields: %v", changed)
+	}
+	if _, err := api.SetRPCModules("http", []string{"nonexistent"}, nil); err == nil {
+		t.Error("no error for unavailable module")
+	}
+
+	// Restrict the methods of both transports.
+	if _, err := api.SetMethodAllowList([]string{"test_greet"}, &persist); err != nil {
+		t.Fatal(err)
+	}
+	if !callable(wsURL, "test_greet") || callable(wsURL, "web3_clientVersion") || callable(httpURL, "web3_clientVersion") {
+		t.Error("allow-list not applied")
+	}
+	if !callable(httpURL, "rpc_modules") {
+		t.Error("metadata API not available")
+	}
+	if len(persisted.RPCAllowMethods) != 1 || !slices.Equal(changed, []string{"RPCAllowMethods"}) {
+		t.Errorf("wrong persisted allow-list: %v (fields %v)", persisted.RPCAllowMethods, changed)
+	}
+	if _, err := api.SetMethodAllowList(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !callable(wsURL, "web3_clientVersion") {
+		t.Error("allow-list not removed")
+	}
+
+	// The API filter set by embedders behaves like the allow-list.
+	stack.ApplyAPIFilter(map[string]bool{"web3_clientVersion": true, "test_greet": false})
+	if callable(httpURL, "test_greet") || !callable(wsURL, "web3_clientVersion") {
+		t.Error("API filter not applied")
+	}
+	if methods := stack.Config().RPCAllowMethods; len(methods) != 1 || methods[0] != "web3_clientVersion" {
+		t.Errorf("wrong allow-list after applying API filter: %v", methods)
+	}
+}
+
 // checkReachable checks if the TCP endpoint in rawurl is open.
 func checkReachable(rawurl string) bool {
 	u, err := url.Parse(rawurl)
@@ -24,6 +24,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -137,6 +138,11 @@ type Config struct {
 	// HTTPPathPrefix specifies a path prefix on which http-rpc is to be served.
 	HTTPPathPrefix string `toml:",omitempty"`
 
+	// HTTPUnencryptedHTTP2 enables HTTP/2 without TLS (h2c) on the HTTP RPC
+	// interface, allowing many concurrent requests and event streams to share
+	// a single connection.
+	HTTPUnencryptedHTTP2 bool `toml:",omitempty"`
+
 	// AuthAddr is the listening address on which authenticated APIs are provided.
 	AuthAddr string `toml:",omitempty"`
 
@@ -200,6 +206,14 @@ type Config struct {
 	// Requests using ip address directly are not affected
 	GraphQLVirtualHosts []string `toml:",omitempty"`
 
+	// GRPCJWTSecret is the path to the hex-encoded jwt secret authenticating the
+	// calls of the gRPC gateway. The gateway is unauthenticated if it is empty.
+	GRPCJWTSecret string `toml:",omitempty"`
+
+	// GRPCLongLivedJWT accepts JWTs with an expiry on the gRPC gateway until they
+	// expire, instead of requiring tokens to be issued within the last minute.
+	GRPCLongLivedJWT bool `toml:",omitempty"`
+
 	// Logger is a custom logger to use with the p2p.Server.
 	Logger log.Logger `toml:",omitempty"`
 
@@ -214,14 +228,61 @@ type Config struct {
 	// BatchResponseMaxSize is the maximum number of bytes returned from a batched rpc call.
 	BatchResponseMaxSize int `toml:",omitempty"`
 
-	// JWTSecret is the path to the hex-encoded jwt secret.
+	// BatchRequestConcurrency is the number of requests of a batch processed in
+	// parallel. Zero or one processes them sequentially.
+	BatchRequestConcurrency int `toml:",omitempty"`
+
+	// RPCQuota configures the per-client call quota of the HTTP and WebSocket
+	// endpoints. Clients are identified by their JWT subject if authenticated,
+	// or by their IP address otherwise.
+	RPCQuota rpc.QuotaConfig `toml:",omitempty"`
+
+	// RPCAccessLog configures the access log of the HTTP and WebSocket endpoints.
+	RPCAccessLog rpc.AccessLogConfig `toml:",omitempty"`
+
+	// RPCResponseCacheSize is the memory allowance (in megabytes) for caching
+	// the results of HTTP and WebSocket calls which only depend on finalized
+	// blocks. Zero disables the cache.
+	RPCResponseCacheSize int `toml:",omitempty"`
+
+	// RPCAllowMethods is a list of methods, e.g. "eth_call", callable via the
+	// HTTP and WebSocket interfaces. If the list is empty, all methods of the
+	// exposed modules are callable.
+	RPCAllowMethods []string `toml:",omitempty"`
+
+	// Tracing configures the export of OpenTelemetry spans recorded while
+	// serving RPC requests.
+	Tracing TracingConfig `toml:",omitempty"`
+
+	// JWTSecret is the path to the hex-encoded jwt secrets, one per line. Tokens
+	// signed with any of them are accepted, which allows rotating secrets.
 	JWTSecret string `toml:",omitempty"`
 
+	// AuthLongLivedJWT accepts JWTs with an expiry on the authenticated endpoints
+	// until they expire, instead of requiring tokens to be issued within the last
+	// minute. WebSocket connections are closed when their token expires. Engine
+	// API clients issue fresh tokens and don't need this.
+	AuthLongLivedJWT bool `toml:",omitempty"`
+
 	// EnablePersonal enables the deprecated personal namespace.
 	EnablePersonal bool `toml:"-"`
 
 	DBEngine string `toml:",omitempty"`
 
+	// AncientRemote is the location of the object store the sealed chain
+	// freezer segments are offloaded to. If empty, all ancient data is kept
+	// on local disk. State histories are always stored locally.
+	AncientRemote string `toml:",omitempty"`
+
+	// ReplicaOf is the data directory of a primary node. If set, the chain
+	// databases are not opened from the local data directory, but the ones of
+	// the primary are followed read-only.
+	ReplicaOf string `toml:",omitempty"`
+
+	// ReplicaInterval is the interval in which a replica catches up with the
+	// databases of its primary.
+	ReplicaInterval time.Duration `toml:",omitempty"`
+
 	// HTTPBodyLimit is the maximum number of bytes allowed in the HTTP request body.
 	HTTPBodyLimit int `toml:",omitempty"`
 
@@ -229,6 +290,23 @@ type Config struct {
 	WSReadLimit int64 `toml:",omitempty"`
 }
 
+// TracingConfig configures the export of OpenTelemetry spans.
+type TracingConfig struct {
+	// Endpoint receives the recorded spans. For http and https URLs the spans
+	// are posted to an OTLP/HTTP collector, file URLs name a file to which the
+	// spans are appended as lines of OTLP/JSON. Tracing is disabled if the
+	// endpoint is empty.
+	Endpoint string `toml:",omitempty"`
+
+	// SampleRatio is the fraction of locally started traces that are recorded,
+	// between 0 and 1. Traces continued from a caller follow the caller's
+	// sampling decision.
+	SampleRatio float64 `toml:",omitempty"`
+
+	// ServiceName identifies this process in the exported spans.
+	ServiceName string `toml:",omitempty"`
+}
+
 // IPCEndpoint resolves an IPC endpoint based on a configured value, taking into
 // account the set data folders as well as the designated platform we're currently
 // running on.
@@ -339,6 +417,13 @@ func (c *Config) name() string {
 	return c.Name
 }
 
+// resolvePrimaryPath resolves path in the instance directory of the primary
+// node which is followed in replica mode.
+func (c *Config) resolvePrimaryPath(path string) string {
+	primary := &Config{Name: c.name(), DataDir: c.ReplicaOf}
+	return primary.ResolvePath(path)
+}
+
 // These resources are resolved differently for "geth" instances.
 var isOldGethResource = map[string]bool{
 	"chaindata":          true,
@@ -22,6 +22,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/ethdb/objstore"
 	"github.com/ethereum/go-ethereum/ethdb/pebble"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -32,11 +33,16 @@ type OpenOptions struct {
 	Type              string // "leveldb" | "pebble"
 	Directory         string // the datadir
 	AncientsDirectory string // the ancients-dir
+	AncientRemote     string // the object store location of sealed ancient segments
 	Namespace         string // the namespace for database relevant metrics
 	Cache             int    // the capacity(in megabytes) of the data caching
 	Handles           int    // number of files to be open simultaneously
 	ReadOnly          bool
 
+	// Secondary opens the database read-only next to a primary process which
+	// is concurrently writing to it. Only pebble is supported.
+	Secondary bool
+
 	// Ephemeral means that filesystem sync operations should be avoided:
 	// data integrity in the face of a crash is not important. This option
 	// should typically be used in tests.
@@ -51,6 +57,9 @@ type OpenOptions struct {
 // The passed o.AncientDir indicates the path of root ancient directory where
 // the chain freezer can be opened.
 func OpenDatabase(o OpenOptions) (ethdb.Database, error) {
+	if o.Secondary {
+		return openSecondaryDatabase(o)
+	}
 	kvdb, err := openKeyValueDatabase(o)
 	if err != nil {
 		return nil, err
@@ -58,7 +67,36 @@ func OpenDatabase(o OpenOptions) (ethdb.Database, error) {
 	if len(o.AncientsDirectory) == 0 {
 		return kvdb, nil
 	}
-	frdb, err := rawdb.NewDatabaseWithFreezer(kvdb, o.AncientsDirectory, o.Namespace, o.ReadOnly)
+	var remote objstore.Store
+	if o.AncientRemote != "" {
+		if remote, err = objstore.Open(o.AncientRemote); err != nil {
+			kvdb.Close()
+			return nil, err
+		}
+		log.Info("Offloading ancient chain segments to remote store", "location", o.AncientRemote)
+	}
+	frdb, err := rawdb.NewDatabaseWithRemoteFreezer(kvdb, o.AncientsDirectory, o.Namespace, o.ReadOnly, remote)
+	if err != nil {
+		kvdb.Close()
+		return nil, err
+	}
+	return frdb, nil
+}
+
+// openSecondaryDatabase opens a pebble database and the attached chain freezer
+// as secondary instances, following a primary process writing to them.
+func openSecondaryDatabase(o OpenOptions) (ethdb.Database, error) {
+	if existingDb := rawdb.PreexistingDatabase(o.Directory); existingDb != rawdb.DBPebble {
+		return nil, fmt.Errorf("secondary database requires pebble, found %q", existingDb)
+	}
+	kvdb, err := pebble.NewSecondary(o.Directory, o.Cache, o.Handles, o.Namespace, o.PebbleExtraOptions)
+	if err != nil {
+		return nil, err
+	}
+	if len(o.AncientsDirectory) == 0 {
+		return rawdb.NewDatabase(kvdb), nil
+	}
+	frdb, err := rawdb.NewSecondaryDatabaseWithFreezer(kvdb, o.AncientsDirectory, o.Namespace)
 	if err != nil {
 		kvdb.Close()
 		return nil, err
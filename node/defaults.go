@@ -21,6 +21,7 @@ import (
 	"os/user"
 	"path/filepath"
 	"runtime"
+	"time"
 
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/nat"
@@ -69,12 +70,14 @@ var DefaultConfig = Config{
 	BatchRequestLimit:    1000,
 	BatchResponseMaxSize: 25 * 1000 * 1000,
 	GraphQLVirtualHosts:  []string{"localhost"},
+	Tracing:              TracingConfig{SampleRatio: 1},
 	P2P: p2p.Config{
 		ListenAddr: ":30303",
 		MaxPeers:   50,
 		NAT:        nat.Any(),
 	},
-	DBEngine: "", // Use whatever exists, will default to Pebble if non-existent and supported
+	DBEngine:        "", // Use whatever exists, will default to Pebble if non-existent and supported
+	ReplicaInterval: 5 * time.Second,
 }
 
 // DefaultDataDir is the default data directory to use for the databases and other
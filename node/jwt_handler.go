@@ -17,36 +17,97 @@
 package node
 
 import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/golang-jwt/jwt/v4"
 )
 
 const jwtExpiryTimeout = 60 * time.Second
 
+// jwtClaims are the claims of the JWTs accepted by the node. Besides the
+// registered claims, a token can restrict the client to the listed namespaces
+// and methods. A token without either list permits all calls.
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	Namespaces []string `json:"namespaces,omitempty"`
+	Methods    []string `json:"methods,omitempty"`
+}
+
+// scope returns the auth scope of the token, or nil if the token permits all calls.
+func (c *jwtClaims) scope() ([]string, error) {
+	if len(c.Namespaces) == 0 && len(c.Methods) == 0 {
+		return nil, nil
+	}
+	scope := make([]string, 0, len(c.Namespaces)+len(c.Methods))
+	for _, namespace := range c.Namespaces {
+		if namespace == "" || strings.Contains(namespace, "_") {
+			return nil, fmt.Errorf("invalid namespace %q", namespace)
+		}
+		scope = append(scope, namespace)
+	}
+	for _, method := range c.Methods {
+		if namespace, name, _ := strings.Cut(method, "_"); namespace == "" || name == "" {
+			return nil, fmt.Errorf("invalid method %q", method)
+		}
+		scope = append(scope, method)
+	}
+	return scope, nil
+}
+
 type jwtHandler struct {
-	keyFunc func(token *jwt.Token) (interface{}, error)
-	next    http.Handler
+	secrets   [][]byte
+	longLived bool
+	next      http.Handler
 }
 
-// newJWTHandler creates a http.Handler with jwt authentication support.
-func newJWTHandler(secret []byte, next http.Handler) http.Handler {
+// newJWTHandler creates a http.Handler with jwt authentication support. Tokens
+// signed with any of the secrets are accepted, which allows rotating secrets.
+//
+// Tokens must be issued within jwtExpiryTimeout of the request. If longLived is
+// set, tokens with an expiry are instead accepted until they expire, and requests
+// and WebSocket connections authenticated by them are ended at that time.
+func newJWTHandler(secrets [][]byte, longLived bool, next http.Handler) http.Handler {
 	return &jwtHandler{
-		keyFunc: func(token *jwt.Token) (interface{}, error) {
-			return secret, nil
-		},
-		next: next,
+		secrets:   secrets,
+		longLived: longLived,
+		next:      next,
 	}
 }
 
-// ServeHTTP implements http.Handler
-func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
+// parse verifies the signature of the token against each secret in turn.
+func (handler *jwtHandler) parse(strToken string) (*jwt.Token, *jwtClaims, error) {
 	var (
-		strToken string
-		claims   jwt.RegisteredClaims
+		token  *jwt.Token
+		claims *jwtClaims
+		err    error
 	)
+	for _, secret := range handler.secrets {
+		keyFunc := func(*jwt.Token) (interface{}, error) { return secret, nil }
+		claims = new(jwtClaims)
+		// We explicitly set only HS256 allowed, and also disables the
+		// claim-check: the RegisteredClaims internally requires 'iat' to
+		// be no later than 'now', but we allow for a bit of drift.
+		token, err = jwt.ParseWithClaims(strToken, claims, keyFunc,
+			jwt.WithValidMethods([]string{"HS256"}),
+			jwt.WithoutClaimsValidation())
+		if !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+			break
+		}
+	}
+	return token, claims, err
+}
+
+// ServeHTTP implements http.Handler
+func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
+	var strToken string
 	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
 		strToken = strings.TrimPrefix(auth, "Bearer ")
 	}
@@ -54,27 +115,82 @@ func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 		http.Error(out, "missing token", http.StatusUnauthorized)
 		return
 	}
-	// We explicitly set only HS256 allowed, and also disables the
-	// claim-check: the RegisteredClaims internally requires 'iat' to
-	// be no later than 'now', but we allow for a bit of drift.
-	token, err := jwt.ParseWithClaims(strToken, &claims, handler.keyFunc,
-		jwt.WithValidMethods([]string{"HS256"}),
-		jwt.WithoutClaimsValidation())
+	token, claims, err := handler.parse(strToken)
+	if err != nil {
+		http.Error(out, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	scope, scopeErr := claims.scope()
 
+	// Tokens must be fresh, unless the endpoint accepts long-lived tokens, which
+	// are valid until they expire.
+	longLived := handler.longLived && claims.ExpiresAt != nil
 	switch {
-	case err != nil:
-		http.Error(out, err.Error(), http.StatusUnauthorized)
 	case !token.Valid:
 		http.Error(out, "invalid token", http.StatusUnauthorized)
 	case !claims.VerifyExpiresAt(time.Now(), false): // optional
 		http.Error(out, "token is expired", http.StatusUnauthorized)
 	case claims.IssuedAt == nil:
 		http.Error(out, "missing issued-at", http.StatusUnauthorized)
-	case time.Since(claims.IssuedAt.Time) > jwtExpiryTimeout:
+	case !longLived && time.Since(claims.IssuedAt.Time) > jwtExpiryTimeout:
 		http.Error(out, "stale token", http.StatusUnauthorized)
 	case time.Until(claims.IssuedAt.Time) > jwtExpiryTimeout:
 		http.Error(out, "future token", http.StatusUnauthorized)
+	case scopeErr != nil:
+		http.Error(out, "invalid scope: "+scopeErr.Error(), http.StatusUnauthorized)
 	default:
-		handler.next.ServeHTTP(out, r)
+		ctx := r.Context()
+		if claims.Subject != "" {
+			ctx = rpc.ContextWithAuthSubject(ctx, claims.Subject)
+		}
+		if scope != nil {
+			ctx = rpc.ContextWithAuthScope(ctx, scope)
+		}
+		// Requests authenticated by a long-lived token, including streams of
+		// server-sent events and WebSocket connections, end when it expires.
+		if longLived {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(ctx, claims.ExpiresAt.Time)
+			defer cancel()
+			if isWebsocket(r) {
+				out = &expiringResponseWriter{ResponseWriter: out, expiry: claims.ExpiresAt.Time}
+			}
+		}
+		handler.next.ServeHTTP(out, r.WithContext(ctx))
 	}
 }
+
+// expiringResponseWriter closes the connection hijacked for a WebSocket when the
+// token authenticating it expires.
+type expiringResponseWriter struct {
+	http.ResponseWriter
+	expiry time.Time
+}
+
+// Hijack implements http.Hijacker.
+func (w *expiringResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, brw, err := http.NewResponseController(w.ResponseWriter).Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	expiring := &expiringConn{Conn: conn}
+	expiring.timer = time.AfterFunc(time.Until(w.expiry), func() { conn.Close() })
+	return expiring, brw, nil
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController.
+func (w *expiringResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// expiringConn is a connection closed by a timer, which it stops when closed
+// before.
+type expiringConn struct {
+	net.Conn
+	timer *time.Timer
+}
+
+func (c *expiringConn) Close() error {
+	c.timer.Stop()
+	return c.Conn.Close()
+}
@@ -17,6 +17,7 @@
 package node
 
 import (
+	"context"
 	crand "crypto/rand"
 	"errors"
 	"fmt"
@@ -37,11 +38,15 @@ import (
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/ethereum/go-ethereum/ethdb/pebble"
+	"github.com/ethereum/go-ethereum/ethdb/replica"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/internal/telemetry/exporter"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/gofrs/flock"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Node is a container on which services can be registered.
@@ -72,8 +77,14 @@ type Node struct {
 	inprocHandler *rpc.Server // In-process RPC request handler to process the API requests
 
 	databases map[*closeTrackingDB]struct{} // All open databases
-
-	apiFilter map[string]bool // Whitelisting API methods
+	replicas  []*replica.Database           // Databases following a primary node
+
+	apiFilter     map[string]bool               // Whitelisting API methods
+	quota         rpc.QuotaLimiter              // Per-client call quota of the HTTP and WS endpoints
+	responseCache *rpc.ResponseCache            // Cache of immutable call results of the public HTTP and WS endpoints
+	tracing       *sdktrace.TracerProvider      // Exports the spans of the RPC servers, nil if disabled
+	configWriter  func(*Config, []string) error // Persists runtime changes of the configuration
+	rpcMiddleware []rpc.Middleware              // Interceptors of the calls of the public HTTP and WS endpoints
 }
 
 const (
@@ -112,6 +123,7 @@ func New(conf *Config) (*Node, error) {
 	}
 	server := rpc.NewServer()
 	server.SetBatchLimits(conf.BatchRequestLimit, conf.BatchResponseMaxSize)
+	server.SetBatchConcurrency(conf.BatchRequestConcurrency)
 	node := &Node{
 		config:        conf,
 		inprocHandler: server,
@@ -120,8 +132,12 @@ func New(conf *Config) (*Node, error) {
 		stop:          make(chan struct{}),
 		server:        &p2p.Server{Config: conf.P2P},
 		databases:     make(map[*closeTrackingDB]struct{}),
+		quota:         rpc.NewQuotaLimiter(conf.RPCQuota),
+		responseCache: rpc.NewResponseCache(uint64(conf.RPCResponseCacheSize) * 1024 * 1024),
 	}
 
+	node.apiFilter = allowListFilter(conf.RPCAllowMethods)
+
 	// Register built-in APIs.
 	node.rpcAPIs = append(node.rpcAPIs, node.apis()...)
 
@@ -158,11 +174,17 @@ func New(conf *Config) (*Node, error) {
 
 	// Configure RPC servers.
 	node.http = newHTTPServer(node.log, conf.HTTPTimeouts)
+	node.http.h2c = conf.HTTPUnencryptedHTTP2
 	node.httpAuth = newHTTPServer(node.log, conf.HTTPTimeouts)
 	node.ws = newHTTPServer(node.log, rpc.DefaultHTTPTimeouts)
 	node.wsAuth = newHTTPServer(node.log, rpc.DefaultHTTPTimeouts)
 	node.ipc = newIPCServer(node.log, conf.IPCEndpoint())
 
+	// Start exporting the spans recorded while serving requests.
+	if node.tracing, err = exporter.NewTracerProvider(exporter.Config(conf.Tracing)); err != nil {
+		node.closeDataDir()
+		return nil, err
+	}
 	return node, nil
 }
 
@@ -254,6 +276,12 @@ func (n *Node) doClose(errs []error) error {
 		}
 	}
 
+	if n.tracing != nil {
+		if err := n.tracing.Shutdown(context.Background()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	// Release instance directory lock.
 	n.closeDataDir()
 
@@ -341,17 +369,42 @@ func (n *Node) closeDataDir() {
 }
 
 // ObtainJWTSecret loads the jwt-secret from the provided config. If the file is not
-// present, it generates a new secret and stores to the given location.
+// present, it generates a new secret and stores to the given location. If the file
+// holds multiple secrets, the first one is returned.
 func ObtainJWTSecret(fileName string) ([]byte, error) {
+	secrets, err := ObtainJWTSecrets(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return secrets[0], nil
+}
+
+// ObtainJWTSecrets loads the jwt-secrets from the provided config, one per line.
+// Listing several secrets allows rotating them: tokens signed with any of them are
+// accepted. If the file is not present, it generates a new secret and stores to the
+// given location.
+func ObtainJWTSecrets(fileName string) ([][]byte, error) {
 	// try reading from file
 	if data, err := os.ReadFile(fileName); err == nil {
-		jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
-		if len(jwtSecret) == 32 {
+		var jwtSecrets [][]byte
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			jwtSecret := common.FromHex(line)
+			if len(jwtSecret) != 32 {
+				log.Error("Invalid JWT secret", "path", fileName, "length", len(jwtSecret))
+				return nil, errors.New("invalid JWT secret")
+			}
 			log.Info("Loaded JWT secret file", "path", fileName, "crc32", fmt.Sprintf("%#x", crc32.ChecksumIEEE(jwtSecret)))
-			return jwtSecret, nil
+			jwtSecrets = append(jwtSecrets, jwtSecret)
+		}
+		if len(jwtSecrets) == 0 {
+			log.Error("Invalid JWT secret", "path", fileName, "length", 0)
+			return nil, errors.New("invalid JWT secret")
 		}
-		log.Error("Invalid JWT secret", "path", fileName, "length", len(jwtSecret))
-		return nil, errors.New("invalid JWT secret")
+		return jwtSecrets, nil
 	}
 	// Need to generate one
 	jwtSecret := make([]byte, 32)
@@ -359,30 +412,144 @@ func ObtainJWTSecret(fileName string) ([]byte, error) {
 	// if we're in --dev mode, don't bother saving, just show it
 	if fileName == "" {
 		log.Info("Generated ephemeral JWT secret", "secret", hexutil.Encode(jwtSecret))
-		return jwtSecret, nil
+		return [][]byte{jwtSecret}, nil
 	}
 	if err := os.WriteFile(fileName, []byte(hexutil.Encode(jwtSecret)), 0600); err != nil {
 		return nil, err
 	}
 	log.Info("Generated JWT secret", "path", fileName)
-	return jwtSecret, nil
+	return [][]byte{jwtSecret}, nil
 }
 
-// ApplyAPIFilter is the first step in whitelisting given rpc methods inside apiFilter
+// ApplyAPIFilter is the first step in whitelisting given rpc methods inside apiFilter.
+// It is equivalent to calling SetMethodAllowList with the allowed methods.
 func (n *Node) ApplyAPIFilter(apiFilter map[string]bool) {
-	n.apiFilter = apiFilter
+	var methods []string
+	for method, allowed := range apiFilter {
+		if allowed {
+			methods = append(methods, method)
+		}
+	}
+	slices.Sort(methods)
+	n.SetMethodAllowList(methods)
+}
+
+// SetRPCModules changes the modules served by the running HTTP ("http") or
+// WebSocket ("ws") endpoint. An empty list enables all public modules. The change
+// applies to new requests.
+func (n *Node) SetRPCModules(transport string, modules []string) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if bad, available := checkModuleAvailability(modules, n.rpcAPIs); len(bad) > 0 {
+		return fmt.Errorf("unavailable modules %v, available: %v", bad, available)
+	}
+	switch transport {
+	case "http":
+		if !n.http.setRPCModules(modules) {
+			return errHTTPRPCDisabled
+		}
+		n.config.HTTPModules = modules
+	case "ws":
+		if !n.ws.setWSModules(modules) && !n.http.setWSModules(modules) {
+			return errors.New("WebSocket RPC not running")
+		}
+		n.config.WSModules = modules
+	default:
+		return fmt.Errorf("unknown transport %q", transport)
+	}
+	return nil
+}
+
+// SetMethodAllowList changes the methods callable via the HTTP and WebSocket
+// endpoints. An empty list allows all methods. The change applies to new requests
+// and to endpoints started later on.
+func (n *Node) SetMethodAllowList(methods []string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.apiFilter = allowListFilter(methods)
+	n.config.RPCAllowMethods = methods
+	n.http.applyAPIFilter(n.apiFilter)
+	n.ws.applyAPIFilter(n.apiFilter)
+}
+
+// CheckHTTPCall checks whether the HTTP endpoint admits a call of the JSON-RPC
+// method by peer, see rpc.Server.CheckCall. Handlers registered on the HTTP server
+// which serve the API through another protocol use it to apply the module list,
+// method allow-list and call quota of the endpoint.
+func (n *Node) CheckHTTPCall(peer rpc.PeerInfo, method string) error {
+	srv := n.http.rpcServer()
+	if srv == nil {
+		return errHTTPRPCDisabled
+	}
+	return srv.CheckCall(peer, method)
+}
+
+// CheckHTTPSubscription is like CheckHTTPCall for a subscription, see
+// rpc.Server.CheckSubscription.
+func (n *Node) CheckHTTPSubscription(peer rpc.PeerInfo, namespace, name string) error {
+	srv := n.http.rpcServer()
+	if srv == nil {
+		return errHTTPRPCDisabled
+	}
+	return srv.CheckSubscription(peer, namespace, name)
+}
+
+// SetConfigWriter sets the function persisting configuration changes made at
+// runtime, e.g. through the admin API. It is called with the configuration and
+// the names of the fields to persist.
+func (n *Node) SetConfigWriter(write func(cfg *Config, changed []string) error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.configWriter = write
+}
+
+// persistConfig writes the given fields of the current configuration using the
+// config writer.
+func (n *Node) persistConfig(changed ...string) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.configWriter == nil {
+		return errors.New("no config file to persist to")
+	}
+	return n.configWriter(n.config, changed)
+}
+
+// allowListFilter converts a list of allowed methods into an API filter. It
+// returns nil, allowing all methods, for an empty list.
+func allowListFilter(methods []string) map[string]bool {
+	if len(methods) == 0 {
+		return nil
+	}
+	filter := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		filter[method] = true
+	}
+	return filter
 }
 
-// obtainJWTSecret loads the jwt-secret, either from the provided config,
+// SetResponseCachePolicy sets the policy deciding which call results of the
+// HTTP and WebSocket endpoints are cached. It does nothing if the response
+// cache is disabled.
+func (n *Node) SetResponseCachePolicy(policy rpc.CachePolicy) {
+	if n.responseCache != nil {
+		n.responseCache.SetPolicy(policy)
+	}
+}
+
+// obtainJWTSecrets loads the jwt-secrets, either from the provided config,
 // or from the default location. If neither of those are present, it generates
 // a new secret and stores to the default location.
-func (n *Node) obtainJWTSecret(cliParam string) ([]byte, error) {
+func (n *Node) obtainJWTSecrets(cliParam string) ([][]byte, error) {
 	fileName := cliParam
 	if len(fileName) == 0 {
 		// no path provided, use default
 		fileName = n.ResolvePath(datadirJWTKey)
 	}
-	return ObtainJWTSecret(fileName)
+	return ObtainJWTSecrets(fileName)
 }
 
 // startRPC is a helper method to configure all the various RPC endpoints during node
@@ -407,7 +574,13 @@ func (n *Node) startRPC() error {
 	rpcConfig := rpcEndpointConfig{
 		batchItemLimit:         n.config.BatchRequestLimit,
 		batchResponseSizeLimit: n.config.BatchResponseMaxSize,
+		batchConcurrency:       n.config.BatchRequestConcurrency,
 		apiFilter:              n.apiFilter,
+		quota:                  n.quota,
+		accessLog:              n.config.RPCAccessLog,
+		tracerProvider:         n.tracerProvider(),
+		responseCache:          n.responseCache,
+		middleware:             n.rpcMiddleware,
 	}
 	if n.config.HTTPBodyLimit != 0 {
 		rpcConfig.httpBodyLimit = n.config.HTTPBodyLimit
@@ -450,17 +623,23 @@ func (n *Node) startRPC() error {
 		return nil
 	}
 
-	initAuth := func(port int, secret []byte) error {
+	initAuth := func(port int, secrets [][]byte) error {
 		// Enable auth via HTTP
 		server := n.httpAuth
 		if err := server.setListenAddr(n.config.AuthAddr, port); err != nil {
 			return err
 		}
+		// The quota and the access log identify authenticated clients by the
+		// subject of their JWT.
 		sharedConfig := rpcEndpointConfig{
-			jwtSecret:              secret,
+			jwtSecrets:             secrets,
+			jwtLongLived:           n.config.AuthLongLivedJWT,
 			batchItemLimit:         engineAPIBatchItemLimit,
 			batchResponseSizeLimit: engineAPIBatchResponseSizeLimit,
 			httpBodyLimit:          engineAPIBodyLimit,
+			quota:                  n.quota,
+			accessLog:              n.config.RPCAccessLog,
+			tracerProvider:         n.tracerProvider(),
 		}
 		if n.config.HTTPBodyLimit != 0 {
 			sharedConfig.httpBodyLimit = n.config.HTTPBodyLimit
@@ -513,11 +692,11 @@ func (n *Node) startRPC() error {
 	}
 	// Configure authenticated API
 	if len(openAPIs) != len(allAPIs) {
-		jwtSecret, err := n.obtainJWTSecret(n.config.JWTSecret)
+		jwtSecrets, err := n.obtainJWTSecrets(n.config.JWTSecret)
 		if err != nil {
 			return err
 		}
-		if err := initAuth(n.config.AuthPort, jwtSecret); err != nil {
+		if err := initAuth(n.config.AuthPort, jwtSecrets); err != nil {
 			return err
 		}
 	}
@@ -530,6 +709,15 @@ func (n *Node) startRPC() error {
 	return nil
 }
 
+// tracerProvider returns the tracer provider of the RPC servers, or nil if
+// tracing is disabled.
+func (n *Node) tracerProvider() trace.TracerProvider {
+	if n.tracing == nil {
+		return nil
+	}
+	return n.tracing
+}
+
 func (n *Node) wsServerForPort(port int, authenticated bool) *httpServer {
 	httpServer, wsServer := n.http, n.ws
 	if authenticated {
@@ -643,6 +831,18 @@ func (n *Node) RegisterHandler(name, path string, handler http.Handler) {
 	n.http.handlerNames[path] = name
 }
 
+// RegisterRPCMiddleware adds middleware intercepting the method calls of the HTTP
+// and WebSocket endpoints. Middleware registered first sees calls first.
+func (n *Node) RegisterRPCMiddleware(middleware ...rpc.Middleware) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.state != initializingState {
+		panic("can't register RPC middleware on running/stopped node")
+	}
+	n.rpcMiddleware = append(n.rpcMiddleware, middleware...)
+}
+
 // Attach creates an RPC client attached to an in-process API handler.
 func (n *Node) Attach() *rpc.Client {
 	return rpc.DialInProc(n.inprocHandler)
@@ -793,13 +993,26 @@ func (n *Node) OpenDatabaseWithFreezerWithExtraOptions(name string, cache, handl
 	}
 	var db ethdb.Database
 	var err error
-	if n.config.DataDir == "" {
+	switch {
+	case n.config.DataDir == "":
 		db, err = rawdb.NewDatabaseWithFreezer(memorydb.New(), "", namespace, readonly)
-	} else {
+	case n.config.ReplicaOf != "":
+		db, err = n.openReplica(OpenOptions{
+			Directory:          n.config.resolvePrimaryPath(name),
+			AncientsDirectory:  n.resolvePrimaryAncient(name, ancient),
+			Namespace:          namespace,
+			Cache:              cache,
+			Handles:            handles,
+			ReadOnly:           true,
+			Secondary:          true,
+			PebbleExtraOptions: pebbleExtraOptions,
+		})
+	default:
 		db, err = OpenDatabase(OpenOptions{
 			Type:               n.config.DBEngine,
 			Directory:          n.ResolvePath(name),
 			AncientsDirectory:  n.ResolveAncient(name, ancient),
+			AncientRemote:      n.config.AncientRemote,
 			Namespace:          namespace,
 			Cache:              cache,
 			Handles:            handles,
@@ -829,6 +1042,50 @@ func (n *Node) ResolveAncient(name string, ancient string) string {
 	return ancient
 }
 
+// resolvePrimaryAncient returns the absolute path of the root ancient directory
+// of the primary node followed in replica mode.
+func (n *Node) resolvePrimaryAncient(name string, ancient string) string {
+	switch {
+	case ancient == "":
+		ancient = filepath.Join(n.config.resolvePrimaryPath(name), "ancient")
+	case !filepath.IsAbs(ancient):
+		ancient = n.config.resolvePrimaryPath(ancient)
+	}
+	return ancient
+}
+
+// openReplica opens a database of the primary node as a replica, catching up
+// with the writes of the primary in the configured interval.
+func (n *Node) openReplica(o OpenOptions) (ethdb.Database, error) {
+	db, err := replica.New(func() (ethdb.Database, error) {
+		return OpenDatabase(o)
+	})
+	if err != nil {
+		return nil, err
+	}
+	interval := n.config.ReplicaInterval
+	if interval <= 0 {
+		interval = DefaultConfig.ReplicaInterval
+	}
+	n.log.Info("Following primary database", "path", o.Directory, "ancient", o.AncientsDirectory, "interval", interval)
+	db.Follow(interval)
+	n.replicas = append(n.replicas, db)
+	return db, nil
+}
+
+// SubscribeReplicaCatchUp subscribes to the events posted after any database
+// opened in replica mode caught up with its primary.
+func (n *Node) SubscribeReplicaCatchUp(ch chan<- replica.CatchUpEvent) event.Subscription {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	subs := make([]event.Subscription, 0, len(n.replicas))
+	for _, db := range n.replicas {
+		subs = append(subs, db.SubscribeCatchUp(ch))
+	}
+	return event.JoinSubscriptions(subs...)
+}
+
 // closeTrackingDB wraps the Close method of a database. When the database is closed by the
 // service, the wrapper removes it from the node's database map. This ensures that Node
 // won't auto-close the database if it is closed by the service that opened it.
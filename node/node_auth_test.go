@@ -197,6 +197,79 @@ func TestAuthEndpoints(t *testing.T) {
 	}
 }
 
+// TestAuthEndpointQuota tests that the call quota of the authenticated endpoints
+// is tracked per JWT subject.
+func TestAuthEndpointQuota(t *testing.T) {
+	var secret [32]byte
+	if _, err := crand.Read(secret[:]); err != nil {
+		t.Fatalf("failed to create jwt secret: %v", err)
+	}
+	jwtPath := filepath.Join(t.TempDir(), "jwt_secret")
+	if err := os.WriteFile(jwtPath, []byte(hexutil.Encode(secret[:])), 0600); err != nil {
+		t.Fatalf("failed to prepare jwt secret file: %v", err)
+	}
+	conf := &Config{
+		AuthAddr:  "127.0.0.1",
+		AuthPort:  0,
+		JWTSecret: jwtPath,
+		RPCQuota:  rpc.QuotaConfig{Rate: 0.001, Burst: 2},
+	}
+	node, err := New(conf)
+	if err != nil {
+		t.Fatalf("could not create a new node: %v", err)
+	}
+	node.RegisterAPIs([]rpc.API{{
+		Namespace:     "engine",
+		Service:       helloRPC("hello engine"),
+		Authenticated: true,
+	}})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start test node: %v", err)
+	}
+	defer node.Close()
+
+	call := func(endpoint, subject string) error {
+		cl, err := rpc.DialOptions(context.Background(), endpoint, rpc.WithHTTPAuth(subjectAuth(secret, subject)))
+		if err != nil {
+			return err
+		}
+		defer cl.Close()
+		var x string
+		return cl.CallContext(context.Background(), &x, "engine_helloWorld")
+	}
+	for _, endpoint := range []string{node.HTTPAuthEndpoint(), node.WSAuthEndpoint()} {
+		// Use a fresh subject per endpoint, as the endpoints share the quota.
+		subject := "alice-" + endpoint
+		for i := 0; i < 2; i++ {
+			if err := call(endpoint, subject); err != nil {
+				t.Fatalf("%s: call %d failed: %v", endpoint, i, err)
+			}
+		}
+		if err := call(endpoint, subject); err == nil {
+			t.Fatalf("%s: call beyond the quota succeeded", endpoint)
+		}
+		// Another subject has a quota of its own.
+		if err := call(endpoint, "bob-"+endpoint); err != nil {
+			t.Fatalf("%s: call of other subject failed: %v", endpoint, err)
+		}
+	}
+}
+
+func subjectAuth(secret [32]byte, subject string) rpc.HTTPAuth {
+	return func(header http.Header) error {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"iat": &jwt.NumericDate{Time: time.Now()},
+			"sub": subject,
+		})
+		s, err := token.SignedString(secret[:])
+		if err != nil {
+			return fmt.Errorf("failed to create JWT token: %w", err)
+		}
+		header.Set("Authorization", "Bearer "+s)
+		return nil
+	}
+}
+
 func noneAuth(secret [32]byte) rpc.HTTPAuth {
 	return func(header http.Header) error {
 		token := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{
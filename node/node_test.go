@@ -17,18 +17,24 @@
 package node
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/replica"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/rpc"
 
@@ -167,6 +173,55 @@ func TestNodeCloseClosesDB(t *testing.T) {
 	}
 }
 
+// This test checks that a replica node follows the chain database of a primary.
+func TestNodeOpenReplicaDatabase(t *testing.T) {
+	dir := t.TempDir()
+
+	conf := testNodeConfig()
+	conf.DataDir = dir
+	primary, _ := New(conf)
+	defer primary.Close()
+
+	pdb, err := primary.OpenDatabaseWithFreezer("chaindata", 0, 0, "", "", false)
+	if err != nil {
+		t.Fatal("can't open primary DB:", err)
+	}
+	if err := pdb.Put([]byte("key"), []byte("value")); err != nil {
+		t.Fatal("can't Put on primary DB:", err)
+	}
+	if err := pdb.Sync(); err != nil {
+		t.Fatal("can't sync primary DB:", err)
+	}
+	conf = testNodeConfig()
+	conf.DataDir = t.TempDir()
+	conf.ReplicaOf = dir
+	conf.ReplicaInterval = time.Hour
+	follower, _ := New(conf)
+	defer follower.Close()
+
+	rdb, err := follower.OpenDatabaseWithFreezer("chaindata", 0, 0, "", "", false)
+	if err != nil {
+		t.Fatal("can't open replica DB:", err)
+	}
+	if _, err := rdb.Ancients(); err != nil {
+		t.Fatal("can't read replica ancients:", err)
+	}
+	// The key may not have been flushed by the primary yet, but it must become
+	// visible after closing the primary and catching up.
+	pdb.Close()
+
+	ch := make(chan replica.CatchUpEvent, 1)
+	sub := follower.SubscribeReplicaCatchUp(ch)
+	defer sub.Unsubscribe()
+	if err := follower.replicas[0].CatchUp(); err != nil {
+		t.Fatal("can't catch up with primary:", err)
+	}
+	<-ch
+	if value, err := rdb.Get([]byte("key")); err != nil || string(value) != "value" {
+		t.Fatalf("unexpected replica value: %q, %v", value, err)
+	}
+}
+
 // This test checks that OpenDatabase can be used from within a Lifecycle Start method.
 func TestNodeOpenDatabaseFromLifecycleStart(t *testing.T) {
 	stack, _ := New(testNodeConfig())
@@ -604,6 +659,40 @@ func doHTTPRequest(t *testing.T, req *http.Request) *http.Response {
 	return resp
 }
 
+// Tests that multiple JWT secrets can be loaded from a file, one per line.
+func TestObtainJWTSecrets(t *testing.T) {
+	var (
+		file   = filepath.Join(t.TempDir(), "jwtsecret")
+		first  = bytes.Repeat([]byte{1}, 32)
+		second = bytes.Repeat([]byte{2}, 32)
+	)
+	content := hexutil.Encode(first) + "\n\n" + hexutil.Encode(second) + "\n"
+	if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	secrets, err := ObtainJWTSecrets(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(secrets, [][]byte{first, second}) {
+		t.Fatalf("wrong secrets %x", secrets)
+	}
+	secret, err := ObtainJWTSecret(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(secret, first) {
+		t.Fatalf("wrong secret %x", secret)
+	}
+
+	if err := os.WriteFile(file, []byte(content+"0x1234\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ObtainJWTSecrets(file); err == nil {
+		t.Fatal("expected error for invalid secret")
+	}
+}
+
 func containsProtocol(stackProtocols []p2p.Protocol, protocol p2p.Protocol) bool {
 	for _, a := range stackProtocols {
 		if reflect.DeepEqual(a, protocol) {
@@ -34,6 +34,9 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/rs/cors"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
 // httpConfig is the JSON-RPC/HTTP configuration.
@@ -54,12 +57,19 @@ type wsConfig struct {
 }
 
 type rpcEndpointConfig struct {
-	jwtSecret              []byte // optional JWT secret
+	jwtSecrets             [][]byte // optional JWT secrets
+	jwtLongLived           bool     // accept JWTs with an expiry until they expire
 	batchItemLimit         int
 	batchResponseSizeLimit int
+	batchConcurrency       int
 	apiFilter              map[string]bool
 	httpBodyLimit          int
 	wsReadLimit            int64
+	quota                  rpc.QuotaLimiter // optional per-client call quota
+	accessLog              rpc.AccessLogConfig
+	tracerProvider         trace.TracerProvider // optional, the global tracer provider is used if nil
+	responseCache          *rpc.ResponseCache   // optional cache of immutable results
+	middleware             []rpc.Middleware     // interceptors of method calls
 }
 
 type rpcHandler struct {
@@ -90,6 +100,8 @@ type httpServer struct {
 	host     string
 	port     int
 
+	h2c bool // serve HTTP/2 without TLS
+
 	handlerNames map[string]string
 }
 
@@ -142,6 +154,9 @@ func (h *httpServer) start() error {
 
 	// Initialize the server.
 	h.server = &http.Server{Handler: h}
+	if h.h2c {
+		h.server.Handler = h2c.NewHandler(h, &http2.Server{})
+	}
 	if h.timeouts != (rpc.HTTPTimeouts{}) {
 		CheckTimeouts(&h.timeouts)
 		h.server.ReadTimeout = h.timeouts.ReadTimeout
@@ -175,7 +190,7 @@ func (h *httpServer) start() error {
 	}
 	// Log http endpoint.
 	h.log.Info("HTTP server started",
-		"endpoint", listener.Addr(), "auth", (h.httpConfig.jwtSecret != nil),
+		"endpoint", listener.Addr(), "auth", (h.httpConfig.jwtSecrets != nil),
 		"prefix", h.httpConfig.prefix,
 		"cors", strings.Join(h.httpConfig.CorsAllowedOrigins, ","),
 		"vhosts", strings.Join(h.httpConfig.Vhosts, ","),
@@ -313,11 +328,17 @@ func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 	// Create RPC server and handler.
 	srv := rpc.NewServer()
 	srv.SetBatchLimits(config.batchItemLimit, config.batchResponseSizeLimit)
+	srv.SetBatchConcurrency(config.batchConcurrency)
 	if config.httpBodyLimit > 0 {
 		srv.SetHTTPBodyLimit(config.httpBodyLimit)
 	}
+	srv.SetQuotaLimiter(config.quota)
+	srv.SetAccessLog(config.accessLog)
+	srv.SetTracerProvider(config.tracerProvider)
+	srv.SetResponseCache(config.responseCache)
+	srv.Use(config.middleware...)
 	srv.ApplyAPIFilter(config.apiFilter)
-	if err := RegisterApis(apis, config.Modules, srv); err != nil {
+	if err := mountApis(apis, config.Modules, srv); err != nil {
 		return err
 	}
 
@@ -328,7 +349,7 @@ func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 
 	h.httpConfig = config
 	h.httpHandler.Store(&rpcHandler{
-		Handler: NewHTTPHandlerStack(httpHandler, config.CorsAllowedOrigins, config.Vhosts, config.jwtSecret),
+		Handler: NewHTTPHandlerStackWithSecrets(httpHandler, config.CorsAllowedOrigins, config.Vhosts, config.jwtSecrets, config.jwtLongLived),
 		server:  srv,
 	})
 	return nil
@@ -355,16 +376,22 @@ func (h *httpServer) enableWS(apis []rpc.API, config wsConfig) error {
 	// Create RPC server and handler.
 	srv := rpc.NewServer()
 	srv.SetBatchLimits(config.batchItemLimit, config.batchResponseSizeLimit)
+	srv.SetBatchConcurrency(config.batchConcurrency)
 	if config.httpBodyLimit > 0 {
 		srv.SetHTTPBodyLimit(config.httpBodyLimit)
 	}
+	srv.SetQuotaLimiter(config.quota)
+	srv.SetAccessLog(config.accessLog)
+	srv.SetTracerProvider(config.tracerProvider)
+	srv.SetResponseCache(config.responseCache)
+	srv.Use(config.middleware...)
 	srv.ApplyAPIFilter(config.apiFilter)
-	if err := RegisterApis(apis, config.Modules, srv); err != nil {
+	if err := mountApis(apis, config.Modules, srv); err != nil {
 		return err
 	}
 	h.wsConfig = config
 	h.wsHandler.Store(&rpcHandler{
-		Handler: NewWSHandlerStack(srv.WebsocketHandler(config.Origins, config.wsReadLimit), config.jwtSecret),
+		Handler: NewWSHandlerStackWithSecrets(srv.WebsocketHandler(config.Origins, config.wsReadLimit), config.jwtSecrets, config.jwtLongLived),
 		server:  srv,
 	})
 	return nil
@@ -392,6 +419,60 @@ func (h *httpServer) disableWS() bool {
 	return ws != nil
 }
 
+// setRPCModules changes the modules served over HTTP. It returns false if JSON-RPC
+// over HTTP is not enabled.
+func (h *httpServer) setRPCModules(modules []string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	handler := h.httpHandler.Load().(*rpcHandler)
+	if handler == nil {
+		return false
+	}
+	handler.server.SetModules(modules)
+	h.httpConfig.Modules = modules
+	return true
+}
+
+// setWSModules changes the modules served over WebSocket. It returns false if
+// JSON-RPC over WebSocket is not enabled.
+func (h *httpServer) setWSModules(modules []string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	handler := h.wsHandler.Load().(*rpcHandler)
+	if handler == nil {
+		return false
+	}
+	handler.server.SetModules(modules)
+	h.wsConfig.Modules = modules
+	return true
+}
+
+// applyAPIFilter changes the methods allowed over HTTP and WebSocket.
+func (h *httpServer) applyAPIFilter(apiFilter map[string]bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if handler := h.httpHandler.Load().(*rpcHandler); handler != nil {
+		handler.server.ApplyAPIFilter(apiFilter)
+	}
+	if handler := h.wsHandler.Load().(*rpcHandler); handler != nil {
+		handler.server.ApplyAPIFilter(apiFilter)
+	}
+	h.httpConfig.apiFilter = apiFilter
+	h.wsConfig.apiFilter = apiFilter
+}
+
+// rpcServer returns the JSON-RPC server of the HTTP endpoint, or nil if JSON-RPC
+// over HTTP is disabled.
+func (h *httpServer) rpcServer() *rpc.Server {
+	if handler := h.httpHandler.Load().(*rpcHandler); handler != nil {
+		return handler.server
+	}
+	return nil
+}
+
 // rpcAllowed returns true when JSON-RPC over HTTP is enabled.
 func (h *httpServer) rpcAllowed() bool {
 	return h.httpHandler.Load().(*rpcHandler) != nil
@@ -410,23 +491,46 @@ func isWebsocket(r *http.Request) bool {
 
 // NewHTTPHandlerStack returns wrapped http-related handlers
 func NewHTTPHandlerStack(srv http.Handler, cors []string, vhosts []string, jwtSecret []byte) http.Handler {
+	return NewHTTPHandlerStackWithSecrets(srv, cors, vhosts, jwtSecretList(jwtSecret), false)
+}
+
+// NewHTTPHandlerStackWithSecrets returns wrapped http-related handlers. If any
+// JWT secrets are given, requests must carry a token signed with one of them.
+// Tokens must be freshly issued, unless longLivedJWT is set, which accepts tokens
+// with an expiry until they expire.
+func NewHTTPHandlerStackWithSecrets(srv http.Handler, cors []string, vhosts []string, jwtSecrets [][]byte, longLivedJWT bool) http.Handler {
 	// Wrap the CORS-handler within a host-handler
 	handler := newCorsHandler(srv, cors)
 	handler = newVHostHandler(vhosts, handler)
-	if len(jwtSecret) != 0 {
-		handler = newJWTHandler(jwtSecret, handler)
+	if len(jwtSecrets) != 0 {
+		handler = newJWTHandler(jwtSecrets, longLivedJWT, handler)
 	}
 	return newGzipHandler(handler)
 }
 
 // NewWSHandlerStack returns a wrapped ws-related handler.
 func NewWSHandlerStack(srv http.Handler, jwtSecret []byte) http.Handler {
-	if len(jwtSecret) != 0 {
-		return newJWTHandler(jwtSecret, srv)
+	return NewWSHandlerStackWithSecrets(srv, jwtSecretList(jwtSecret), false)
+}
+
+// NewWSHandlerStackWithSecrets returns a wrapped ws-related handler. The JWT
+// settings are the same as for NewHTTPHandlerStackWithSecrets. Connections
+// authenticated by a long-lived token are closed when it expires.
+func NewWSHandlerStackWithSecrets(srv http.Handler, jwtSecrets [][]byte, longLivedJWT bool) http.Handler {
+	if len(jwtSecrets) != 0 {
+		return newJWTHandler(jwtSecrets, longLivedJWT, srv)
 	}
 	return srv
 }
 
+// jwtSecretList returns the single secret as a list, or nil if it's empty.
+func jwtSecretList(secret []byte) [][]byte {
+	if len(secret) == 0 {
+		return nil
+	}
+	return [][]byte{secret}
+}
+
 func newCorsHandler(srv http.Handler, allowedOrigins []string) http.Handler {
 	// disable CORS support if user has not specified a custom CORS configuration
 	if len(allowedOrigins) == 0 {
@@ -576,6 +680,11 @@ func (w *gzipResponseWriter) Flush() {
 	}
 }
 
+// Unwrap returns the underlying response writer, for use by http.ResponseController.
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.resp
+}
+
 func (w *gzipResponseWriter) close() {
 	if w.gz == nil {
 		return
@@ -665,3 +774,19 @@ func RegisterApis(apis []rpc.API, modules []string, srv *rpc.Server) error {
 	}
 	return nil
 }
+
+// mountApis registers all of the APIs exposed by the services and enables the given
+// modules. Unlike RegisterApis, the disabled modules remain registered, so they can be
+// enabled later on without restarting the server.
+func mountApis(apis []rpc.API, modules []string, srv *rpc.Server) error {
+	if bad, available := checkModuleAvailability(modules, apis); len(bad) > 0 {
+		log.Error("Unavailable modules in HTTP API list", "unavailable", bad, "available", available)
+	}
+	for _, api := range apis {
+		if err := srv.RegisterName(api.Namespace, api.Service); err != nil {
+			return err
+		}
+	}
+	srv.SetModules(modules)
+	return nil
+}
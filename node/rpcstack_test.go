@@ -18,8 +18,12 @@ package node
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -34,6 +38,7 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
 )
 
 const testMethod = "rpc_modules"
@@ -331,7 +336,10 @@ func (testClaim) Valid() error {
 }
 
 func TestJWT(t *testing.T) {
-	var secret = []byte("secret")
+	var (
+		secret  = []byte("secret")
+		rotated = []byte("rotated secret")
+	)
 	issueToken := func(secret []byte, method jwt.SigningMethod, input map[string]interface{}) string {
 		if method == nil {
 			method = jwt.SigningMethodHS256
@@ -339,7 +347,7 @@ func TestJWT(t *testing.T) {
 		ss, _ := jwt.NewWithClaims(method, testClaim(input)).SignedString(secret)
 		return ss
 	}
-	cfg := rpcEndpointConfig{jwtSecret: []byte("secret")}
+	cfg := rpcEndpointConfig{jwtSecrets: [][]byte{[]byte("secret"), rotated}}
 	httpcfg := &httpConfig{rpcEndpointConfig: cfg}
 	wscfg := &wsConfig{Origins: []string{"*"}, rpcEndpointConfig: cfg}
 	srv := createAndStartServer(t, httpcfg, true, wscfg, nil)
@@ -368,6 +376,18 @@ func TestJWT(t *testing.T) {
 				"bar": "baz",
 			}))
 		},
+		// rotated secret
+		func() string {
+			return fmt.Sprintf("Bearer %v", issueToken(rotated, nil, testClaim{"iat": time.Now().Unix()}))
+		},
+		// scoped token
+		func() string {
+			return fmt.Sprintf("Bearer %v", issueToken(secret, nil, testClaim{
+				"iat":        time.Now().Unix(),
+				"sub":        "team",
+				"namespaces": []string{"rpc"},
+			}))
+		},
 	}
 	for i, tokenFn := range expOk {
 		token := tokenFn()
@@ -397,6 +417,33 @@ func TestJWT(t *testing.T) {
 		func() string {
 			return fmt.Sprintf("Bearer %v", issueToken(secret, nil, testClaim{"iat": time.Now().Unix(), "exp": time.Now().Unix()}))
 		},
+		// long-lived token, not accepted by default
+		func() string {
+			return fmt.Sprintf("Bearer %v", issueToken(secret, nil, testClaim{
+				"iat": time.Now().Unix() - 3600,
+				"exp": time.Now().Unix() + 3600,
+			}))
+		},
+		// expired long-lived token
+		func() string {
+			return fmt.Sprintf("Bearer %v", issueToken(secret, nil, testClaim{
+				"iat": time.Now().Unix() - 3600,
+				"exp": time.Now().Unix() - 1,
+			}))
+		},
+		// invalid scope
+		func() string {
+			return fmt.Sprintf("Bearer %v", issueToken(secret, nil, testClaim{
+				"iat":        time.Now().Unix(),
+				"namespaces": []string{"rpc_modules"},
+			}))
+		},
+		func() string {
+			return fmt.Sprintf("Bearer %v", issueToken(secret, nil, testClaim{
+				"iat":     time.Now().Unix(),
+				"methods": []string{"rpc"},
+			}))
+		},
 		// missing mandatory iat
 		func() string {
 			return fmt.Sprintf("Bearer %v", issueToken(secret, nil, testClaim{}))
@@ -449,6 +496,94 @@ func TestJWT(t *testing.T) {
 	srv.stop()
 }
 
+// TestJWTLongLived checks that an endpoint accepting long-lived tokens accepts them
+// until they expire, and closes WebSocket connections when their token expires.
+func TestJWTLongLived(t *testing.T) {
+	secret := []byte("secret")
+	issueToken := func(claims testClaim) string {
+		ss, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+		return "Bearer " + ss
+	}
+	cfg := rpcEndpointConfig{jwtSecrets: [][]byte{secret}, jwtLongLived: true}
+	srv := createAndStartServer(t, &httpConfig{rpcEndpointConfig: cfg}, true, &wsConfig{Origins: []string{"*"}, rpcEndpointConfig: cfg}, nil)
+	defer srv.stop()
+	wsURL := fmt.Sprintf("ws://%v", srv.listenAddr())
+	htURL := fmt.Sprintf("http://%v", srv.listenAddr())
+
+	now := time.Now().Unix()
+	longLived := issueToken(testClaim{"iat": now - 3600, "exp": now + 3600})
+	if resp := rpcRequest(t, htURL, testMethod, "Authorization", longLived); resp.StatusCode != http.StatusOK {
+		t.Errorf("long-lived token not accepted: %v", resp.StatusCode)
+	}
+	// Tokens without expiry must still be fresh.
+	stale := issueToken(testClaim{"iat": now - 3600})
+	if resp := rpcRequest(t, htURL, testMethod, "Authorization", stale); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("stale token without expiry accepted: %v", resp.StatusCode)
+	}
+
+	// The WebSocket connection is closed when the token expires.
+	expiring := issueToken(testClaim{"iat": now - 3600, "exp": now + 2})
+	client, err := rpc.DialOptions(context.Background(), wsURL, rpc.WithHeader("Authorization", expiring))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if err := client.Call(nil, "rpc_modules"); err != nil {
+		t.Fatalf("call before expiry failed: %v", err)
+	}
+	time.Sleep(time.Until(time.Unix(now+2, 0)) + 500*time.Millisecond)
+	if err := client.Call(nil, "rpc_modules"); err == nil {
+		t.Fatal("connection still open after the token expired")
+	}
+}
+
+// TestJWTScope checks that calls are restricted to the namespaces and methods
+// listed in the JWT.
+func TestJWTScope(t *testing.T) {
+	secret := []byte("secret")
+	cfg := rpcEndpointConfig{jwtSecrets: [][]byte{secret}}
+	srv := createAndStartServer(t, &httpConfig{rpcEndpointConfig: cfg}, false, &wsConfig{}, nil)
+	defer srv.stop()
+	url := "http://" + srv.listenAddr()
+
+	tests := []struct {
+		claims  testClaim
+		allowed bool
+	}{
+		{testClaim{}, true},
+		{testClaim{"namespaces": []string{"rpc"}}, true},
+		{testClaim{"methods": []string{testMethod}}, true},
+		{testClaim{"namespaces": []string{"eth"}, "methods": []string{testMethod}}, true},
+		{testClaim{"namespaces": []string{"eth"}}, false},
+		{testClaim{"methods": []string{"rpc_other"}}, false},
+	}
+	for i, test := range tests {
+		test.claims["iat"] = time.Now().Unix()
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, test.claims).SignedString(secret)
+		resp := rpcRequest(t, url, testMethod, "Authorization", "Bearer "+token)
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("test %d: unexpected status %v", i, resp.StatusCode)
+		}
+		var result struct {
+			Error *struct{ Code int }
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			t.Fatalf("test %d: invalid response %q: %v", i, body, err)
+		}
+		switch {
+		case test.allowed && result.Error != nil:
+			t.Errorf("test %d: expected call to be allowed, got %s", i, body)
+		case !test.allowed && (result.Error == nil || result.Error.Code != -32006):
+			t.Errorf("test %d: expected call to be denied, got %s", i, body)
+		}
+	}
+}
+
 func TestGzipHandler(t *testing.T) {
 	type gzipTest struct {
 		name    string
@@ -593,6 +728,65 @@ func TestHTTPWriteTimeout(t *testing.T) {
 	})
 }
 
+// TestHTTPEventStream checks that subscriptions over server-sent events work over
+// HTTP/2 and outlive the write timeout of the server.
+func TestHTTPEventStream(t *testing.T) {
+	timeouts := rpc.DefaultHTTPTimeouts
+	timeouts.WriteTimeout = time.Second
+	srv := newHTTPServer(testlog.Logger(t, log.LvlDebug), timeouts)
+	srv.h2c = true
+	assert.NoError(t, srv.enableRPC(apis(), httpConfig{Modules: []string{"test"}}))
+	assert.NoError(t, srv.setListenAddr("localhost", 0))
+	assert.NoError(t, srv.start())
+	defer srv.stop()
+
+	var proto string
+	transport := &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return new(net.Dialer).DialContext(ctx, network, addr)
+		},
+	}
+	httpClient := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		resp, err := transport.RoundTrip(r)
+		if err == nil {
+			proto = resp.Proto
+		}
+		return resp, err
+	})}
+	client, err := rpc.DialOptions(context.Background(), "http://"+srv.listenAddr(), rpc.WithHTTPClient(httpClient), rpc.WithHTTPSubscriptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ticks := make(chan int)
+	sub, err := client.Subscribe(context.Background(), "test", ticks, "ticks", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+	for i := 0; i < 3; i++ {
+		select {
+		case tick := <-ticks:
+			if tick != i {
+				t.Fatalf("wrong tick: have %d, want %d", tick, i)
+			}
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for tick %d", i)
+		}
+	}
+	if proto != "HTTP/2.0" {
+		t.Fatalf("wrong protocol %q", proto)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
 func apis() []rpc.API {
 	return []rpc.API{
 		{
@@ -611,3 +805,23 @@ func (s *testService) Greet() string {
 func (s *testService) Sleep() {
 	time.Sleep(1500 * time.Millisecond)
 }
+
+// Ticks sends n notifications, one every 600ms.
+func (s *testService) Ticks(ctx context.Context, n int) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	go func() {
+		for i := 0; i < n; i++ {
+			select {
+			case <-time.After(600 * time.Millisecond):
+				notifier.Notify(sub.ID, i)
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
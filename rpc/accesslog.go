@@ -0,0 +1,90 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"math/rand"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// AccessLogConfig configures the access log of a Server.
+type AccessLogConfig struct {
+	// SampleRate is the fraction of method calls written to the access log,
+	// between 0 and 1. Zero disables the access log.
+	SampleRate float64 `toml:",omitempty"`
+
+	// Logger receives the access log entries. If nil, the root logger is used.
+	Logger log.Logger `toml:"-"`
+}
+
+// batchCounter hands out the IDs identifying the calls of a batch in the
+// access log.
+var batchCounter atomic.Uint64
+
+// accessLog writes a structured entry for a sample of the served method calls.
+type accessLog struct {
+	logger     log.Logger
+	sampleRate float64
+}
+
+func newAccessLog(config AccessLogConfig) *accessLog {
+	if config.SampleRate <= 0 {
+		return nil
+	}
+	logger := config.Logger
+	if logger == nil {
+		logger = log.Root()
+	}
+	return &accessLog{logger: logger, sampleRate: config.SampleRate}
+}
+
+// write logs the call msg answered by resp if it is sampled.
+func (l *accessLog) write(cp *callProc, msg, resp *jsonrpcMessage, elapsed time.Duration) {
+	if l.sampleRate < 1 && rand.Float64() >= l.sampleRate {
+		return
+	}
+	peer := PeerInfoFromContext(cp.ctx)
+	ip := peer.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	code := 0
+	if resp.Error != nil {
+		code = resp.Error.Code
+	}
+	ctx := []any{
+		"method", msg.Method,
+		"reqid", idForLog{msg.ID},
+		"params", len(msg.Params),
+		"response", len(resp.Result),
+		"duration", elapsed,
+		"code", code,
+		"transport", peer.Transport,
+		"ip", ip,
+	}
+	if peer.AuthSubject != "" {
+		ctx = append(ctx, "subject", peer.AuthSubject)
+	}
+	if cp.batchID != 0 {
+		ctx = append(ctx, "batch", cp.batchID)
+	}
+	l.logger.Info("RPC access", ctx...)
+}
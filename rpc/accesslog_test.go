@@ -0,0 +1,113 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) entries(t *testing.T) []map[string]any {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var entries []map[string]any
+	scanner := bufio.NewScanner(bytes.NewReader(b.buf.Bytes()))
+	for scanner.Scan() {
+		var entry map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid log entry %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAccessLog(t *testing.T) {
+	var (
+		out    = new(syncBuffer)
+		server = newTestServer()
+	)
+	defer server.Stop()
+	server.SetAccessLog(AccessLogConfig{SampleRate: 1, Logger: log.NewLogger(log.JSONHandler(out))})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.ServeHTTP(w, r.WithContext(ContextWithAuthSubject(r.Context(), "indexer")))
+	}))
+	defer ts.Close()
+	client, err := DialHTTP(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if err := client.Call(nil, "test_echo", "x", 1); err != nil {
+		t.Fatal(err)
+	}
+	batch := []BatchElem{{Method: "test_null"}, {Method: "test_returnError"}}
+	if err := client.BatchCallContext(context.Background(), batch); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := out.entries(t)
+	if len(entries) != 3 {
+		t.Fatalf("wrong number of log entries: have %d, want 3", len(entries))
+	}
+	for i, want := range []struct {
+		method string
+		code   float64
+		batch  bool
+	}{
+		{"test_echo", 0, false},
+		{"test_null", 0, true},
+		{"test_returnError", 444, true},
+	} {
+		entry := entries[i]
+		if entry["method"] != want.method || entry["code"] != want.code {
+			t.Errorf("entry %d: wrong method or code: %v", i, entry)
+		}
+		if entry["subject"] != "indexer" || entry["ip"] != "127.0.0.1" {
+			t.Errorf("entry %d: wrong caller: %v", i, entry)
+		}
+		if _, ok := entry["batch"]; ok != want.batch {
+			t.Errorf("entry %d: wrong batch ID presence: %v", i, entry)
+		}
+	}
+	if entries[1]["batch"] != entries[2]["batch"] {
+		t.Errorf("batch IDs differ: %v != %v", entries[1]["batch"], entries[2]["batch"])
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+)
+
+// maxCacheKeyLength is the maximum length of the method name and normalized
+// parameters of a cached call. Keys are not accounted against the cache size,
+// so calls with larger parameters are not cached.
+const maxCacheKeyLength = 1024
+
+// CachePolicy decides which method results can be served from a ResponseCache.
+type CachePolicy interface {
+	// Cacheable reports whether results of the method may ever be cached. It is
+	// called for every method call and should be cheap.
+	Cacheable(method string) bool
+
+	// Immutable reports whether the result of the call with the given positional
+	// parameters can never change, e.g. because it only depends on finalized
+	// blocks. It is called before the method runs, so that a result computed
+	// before the data it depends on became immutable is never cached.
+	Immutable(ctx context.Context, method string, params []json.RawMessage) bool
+}
+
+// ResponseCache holds the results of method calls proven immutable by its
+// policy. Calls are identified by their method name and parameters, which are
+// normalized so that different encodings of the same call share an entry.
+//
+// A ResponseCache can be shared by multiple servers.
+type ResponseCache struct {
+	cache   *lru.SizeConstrainedCache[string, json.RawMessage]
+	maxItem int
+	policy  atomic.Pointer[CachePolicy]
+}
+
+// NewResponseCache creates a cache holding up to maxSize bytes of results. It
+// returns nil if maxSize is zero. Nothing is cached until a policy is set.
+func NewResponseCache(maxSize uint64) *ResponseCache {
+	if maxSize == 0 {
+		return nil
+	}
+	return &ResponseCache{
+		cache: lru.NewSizeConstrainedCache[string, json.RawMessage](maxSize),
+		// A single large result shouldn't flush the whole cache.
+		maxItem: int(maxSize / 16),
+	}
+}
+
+// SetPolicy sets the policy deciding which results are cached.
+func (c *ResponseCache) SetPolicy(policy CachePolicy) {
+	c.policy.Store(&policy)
+}
+
+// lookup returns the cached result of the call. If the result is not cached
+// but the policy deems it immutable, it returns the key under which to store it.
+func (c *ResponseCache) lookup(ctx context.Context, msg *jsonrpcMessage) (key string, result json.RawMessage) {
+	policy := c.policy.Load()
+	if policy == nil || !(*policy).Cacheable(msg.Method) {
+		return "", nil
+	}
+	key, ok := cacheKey(msg.Method, msg.Params)
+	if !ok {
+		return "", nil
+	}
+	if result, ok := c.cache.Get(key); ok {
+		responseCacheHitMeter.Mark(1)
+		return key, result
+	}
+	responseCacheMissMeter.Mark(1)
+
+	var params []json.RawMessage
+	if len(msg.Params) > 0 {
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return "", nil
+		}
+	}
+	if !(*policy).Immutable(ctx, msg.Method, params) {
+		return "", nil
+	}
+	return key, nil
+}
+
+// add stores the result of a call found immutable by lookup under its key.
+func (c *ResponseCache) add(key string, result json.RawMessage) {
+	if len(result) > c.maxItem {
+		return
+	}
+	c.cache.Add(key, result)
+}
+
+// cacheKey returns the cache key of a call. Parameters are normalized by
+// dropping trailing nulls, whitespace and the case of hex strings, and by
+// sorting object keys.
+func cacheKey(method string, rawParams json.RawMessage) (string, bool) {
+	var params []any
+	if len(rawParams) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(rawParams))
+		dec.UseNumber()
+		if err := dec.Decode(&params); err != nil {
+			return "", false
+		}
+	}
+	for len(params) > 0 && params[len(params)-1] == nil {
+		params = params[:len(params)-1]
+	}
+	for i := range params {
+		params[i] = normalizeParam(params[i])
+	}
+	enc, err := json.Marshal(params)
+	if err != nil {
+		return "", false
+	}
+	key := method + string(enc)
+	if len(key) > maxCacheKeyLength {
+		return "", false
+	}
+	return key, true
+}
+
+func normalizeParam(v any) any {
+	switch v := v.(type) {
+	case string:
+		if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+			return strings.ToLower(v)
+		}
+		return v
+	case []any:
+		for i := range v {
+			v[i] = normalizeParam(v[i])
+		}
+		return v
+	case map[string]any:
+		for k := range v {
+			v[k] = normalizeParam(v[k])
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// counterService returns a different result for every call.
+type counterService struct {
+	calls atomic.Int64
+}
+
+func (s *counterService) Next(block string, full *bool) int64 {
+	return s.calls.Add(1)
+}
+
+// latestPolicy caches the results of counter_next unless called for "latest".
+type latestPolicy struct{}
+
+func (latestPolicy) Cacheable(method string) bool {
+	return method == "counter_next"
+}
+
+func (latestPolicy) Immutable(ctx context.Context, method string, params []json.RawMessage) bool {
+	var block string
+	return len(params) > 0 && json.Unmarshal(params[0], &block) == nil && block != "latest"
+}
+
+func TestResponseCache(t *testing.T) {
+	server := NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("counter", new(counterService)); err != nil {
+		t.Fatal(err)
+	}
+	cache := NewResponseCache(1024 * 1024)
+	server.SetResponseCache(cache)
+	client := DialInProc(server)
+	defer client.Close()
+
+	call := func(args ...any) int64 {
+		t.Helper()
+		var result int64
+		if err := client.Call(&result, "counter_next", args...); err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	// Nothing is cached without a policy.
+	if call("0x1") == call("0x1") {
+		t.Fatal("result cached without policy")
+	}
+	cache.SetPolicy(latestPolicy{})
+
+	first := call("0xAB")
+	if v := call("0xab", nil); v != first {
+		t.Errorf("equivalent call not served from cache: %d != %d", v, first)
+	}
+	if v := call("0xab", true); v == first {
+		t.Error("call with different parameters served from cache")
+	}
+	if call("latest") == call("latest") {
+		t.Error("mutable result cached")
+	}
+}
+
+// finalizingService finalizes its data while computing the first result.
+type finalizingService struct {
+	counterService
+	final atomic.Bool
+}
+
+func (s *finalizingService) Next(block string) int64 {
+	defer s.final.Store(true)
+	return s.counterService.Next(block, nil)
+}
+
+// finalPolicy caches the results of counter_next once the service is final.
+type finalPolicy struct {
+	service *finalizingService
+}
+
+func (p finalPolicy) Cacheable(method string) bool {
+	return method == "counter_next"
+}
+
+func (p finalPolicy) Immutable(ctx context.Context, method string, params []json.RawMessage) bool {
+	return p.service.final.Load()
+}
+
+func TestResponseCacheImmutableBeforeCall(t *testing.T) {
+	server := NewServer()
+	defer server.Stop()
+	service := new(finalizingService)
+	if err := server.RegisterName("counter", service); err != nil {
+		t.Fatal(err)
+	}
+	cache := NewResponseCache(1024 * 1024)
+	cache.SetPolicy(finalPolicy{service})
+	server.SetResponseCache(cache)
+	client := DialInProc(server)
+	defer client.Close()
+
+	call := func() int64 {
+		t.Helper()
+		var result int64
+		if err := client.Call(&result, "counter_next", "0x1"); err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	// The first result was computed before the data became final.
+	first, second := call(), call()
+	if first == second {
+		t.Fatal("result computed before becoming immutable was cached")
+	}
+	if third := call(); third != second {
+		t.Errorf("immutable result not served from cache: %d != %d", third, second)
+	}
+}
+
+func TestResponseCacheKey(t *testing.T) {
+	for _, test := range []struct {
+		a, b  string
+		equal bool
+	}{
+		{`["0xAB", true]`, `[ "0xab",true ]`, true},
+		{`["0x1"]`, `["0x1", null, null]`, true},
+		{`[{"toBlock":"0x2","fromBlock":"0x1"}]`, `[{"fromBlock":"0x1","toBlock":"0x2"}]`, true},
+		{`[{"topics":[["0xAA"]]}]`, `[{"topics":[["0xaa"]]}]`, true},
+		{`[1.0]`, `[1]`, false},
+		{`["Latest"]`, `["latest"]`, false},
+	} {
+		a, ok := cacheKey("eth_test", json.RawMessage(test.a))
+		if !ok {
+			t.Fatalf("no key for %s", test.a)
+		}
+		b, ok := cacheKey("eth_test", json.RawMessage(test.b))
+		if !ok {
+			t.Fatalf("no key for %s", test.b)
+		}
+		if (a == b) != test.equal {
+			t.Errorf("keys of %s and %s: equal = %t, want %t", test.a, test.b, a == b, test.equal)
+		}
+	}
+	if _, ok := cacheKey("eth_test", json.RawMessage(`{"a":1}`)); ok {
+		t.Error("key for non-array params")
+	}
+	if _, ok := cacheKey("eth_test", json.RawMessage(`["`+strings.Repeat("a", maxCacheKeyLength)+`"]`)); ok {
+		t.Error("key for oversized params")
+	}
+}
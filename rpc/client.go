@@ -28,7 +28,9 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/internal/telemetry"
 	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -86,6 +88,7 @@ type BatchElem struct {
 type Client struct {
 	idgen    func() ID // for subscriptions
 	isHTTP   bool      // connection type: http, ws or ipc
+	isSSE    bool      // connection is dedicated to a single subscription over server-sent events
 	services *serviceRegistry
 
 	idCounter atomic.Uint32
@@ -96,6 +99,12 @@ type Client struct {
 	// config fields
 	batchItemLimit       int
 	batchResponseMaxSize int
+	quota                QuotaLimiter
+	accessLog            *accessLog
+	tracerProvider       trace.TracerProvider
+	responseCache        *ResponseCache
+	batchConcurrency     int
+	middleware           []Middleware
 
 	// writeConn is used for writing to the connection on the caller's goroutine. It should
 	// only be accessed outside of dispatch, with the write lock held. The write lock is
@@ -125,9 +134,20 @@ type clientConn struct {
 
 func (c *Client) newClientConn(conn ServerCodec) *clientConn {
 	ctx := context.Background()
+	if wc, ok := conn.(*websocketCodec); ok && wc.traceCtx != nil {
+		ctx = wc.traceCtx
+	}
+	if c.tracerProvider != nil {
+		ctx = telemetry.WithTracerProvider(ctx, c.tracerProvider)
+	}
 	ctx = context.WithValue(ctx, clientContextKey{}, c)
 	ctx = context.WithValue(ctx, peerInfoContextKey{}, conn.peerInfo())
 	handler := newHandler(ctx, conn, c.idgen, c.services, c.batchItemLimit, c.batchResponseMaxSize)
+	handler.quota = c.quota
+	handler.accessLog = c.accessLog
+	handler.responseCache = c.responseCache
+	handler.batchConcurrency = c.batchConcurrency
+	handler.middleware = c.middleware
 	return &clientConn{conn, handler}
 }
 
@@ -255,6 +275,12 @@ func initClient(conn ServerCodec, services *serviceRegistry, cfg *clientConfig)
 		idgen:                cfg.idgen,
 		batchItemLimit:       cfg.batchItemLimit,
 		batchResponseMaxSize: cfg.batchResponseLimit,
+		quota:                cfg.quota,
+		accessLog:            cfg.accessLog,
+		tracerProvider:       cfg.tracerProvider,
+		responseCache:        cfg.responseCache,
+		batchConcurrency:     cfg.batchConcurrency,
+		middleware:           cfg.middleware,
 		writeConn:            conn,
 		close:                make(chan struct{}),
 		closing:              make(chan struct{}),
@@ -523,7 +549,10 @@ func (c *Client) Subscribe(ctx context.Context, namespace string, channel interf
 		panic("channel given to Subscribe must not be nil")
 	}
 	if c.isHTTP {
-		return nil, ErrNotificationsUnsupported
+		if !c.writeConn.(*httpConn).sse {
+			return nil, ErrNotificationsUnsupported
+		}
+		return c.subscribeSSE(ctx, namespace, channel, args...)
 	}
 
 	msg, err := c.newMessage(namespace+subscribeMethodSuffix, args...)
@@ -535,6 +564,7 @@ func (c *Client) Subscribe(ctx context.Context, namespace string, channel interf
 		resp: make(chan []*jsonrpcMessage, 1),
 		sub:  newClientSubscription(c, namespace, chanVal),
 	}
+	op.sub.ownClient = c.isSSE
 
 	// Send the subscription request.
 	// The arrival and validity of the response is signaled on sub.quit.
@@ -551,7 +581,7 @@ func (c *Client) Subscribe(ctx context.Context, namespace string, channel interf
 // transport. When this returns false, Subscribe and related methods will return
 // ErrNotificationsUnsupported.
 func (c *Client) SupportsSubscriptions() bool {
-	return !c.isHTTP
+	return !c.isHTTP || c.writeConn.(*httpConn).sse
 }
 
 func (c *Client) newMessage(method string, paramsIn ...interface{}) (*jsonrpcMessage, error) {
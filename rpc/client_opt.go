@@ -20,6 +20,7 @@ import (
 	"net/http"
 
 	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // ClientOption is a configuration option for the RPC client.
@@ -33,6 +34,9 @@ type clientConfig struct {
 	httpHeaders http.Header
 	httpAuth    HTTPAuth
 
+	// httpSubscriptions enables subscriptions over server-sent events.
+	httpSubscriptions bool
+
 	// WebSocket options
 	wsDialer           *websocket.Dialer
 	wsMessageSizeLimit *int64 // wsMessageSizeLimit nil = default, 0 = no limit
@@ -41,6 +45,12 @@ type clientConfig struct {
 	idgen              func() ID
 	batchItemLimit     int
 	batchResponseLimit int
+	batchConcurrency   int
+	quota              QuotaLimiter
+	accessLog          *accessLog
+	tracerProvider     trace.TracerProvider
+	responseCache      *ResponseCache
+	middleware         []Middleware
 }
 
 func (cfg *clientConfig) initHeaders() {
@@ -114,6 +124,19 @@ func WithHTTPAuth(a HTTPAuth) ClientOption {
 	})
 }
 
+// WithHTTPSubscriptions enables subscriptions for HTTP clients. Every subscription
+// is made over a dedicated HTTP request, to which the server responds with a stream
+// of server-sent events. If the connection breaks, the client resumes the stream
+// without losing notifications, as long as the server still buffers them.
+//
+// Note that the timeout of the http.Client configured by WithHTTPClient also
+// applies to these streams.
+func WithHTTPSubscriptions() ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.httpSubscriptions = true
+	})
+}
+
 // A HTTPAuth function is called by the client whenever a HTTP request is sent.
 // The function must be safe for concurrent use.
 //
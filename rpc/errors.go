@@ -55,12 +55,22 @@ var (
 	_ Error = new(invalidMessageError)
 	_ Error = new(invalidParamsError)
 	_ Error = new(internalServerError)
+	_ Error = new(limitExceededError)
+	_ Error = new(notPermittedError)
+)
+
+// Error codes shared with other transports serving the RPC methods, which need
+// to translate the errors of rejected calls.
+const (
+	ErrcodeMethodNotFound = -32601
+	ErrcodeLimitExceeded  = -32005
 )
 
 const (
 	errcodeDefault          = -32000
 	errcodeTimeout          = -32002
 	errcodeResponseTooLarge = -32003
+	errcodeNotPermitted     = -32006
 	errcodePanic            = -32603
 	errcodeMarshalError     = -32603
 
@@ -75,7 +85,7 @@ const (
 
 type methodNotFoundError struct{ method string }
 
-func (e *methodNotFoundError) ErrorCode() int { return -32601 }
+func (e *methodNotFoundError) ErrorCode() int { return ErrcodeMethodNotFound }
 
 func (e *methodNotFoundError) Error() string {
 	return fmt.Sprintf("the method %s does not exist/is not available", e.method)
@@ -87,7 +97,7 @@ func (e notificationsUnsupportedError) Error() string {
 	return "notifications not supported"
 }
 
-func (e notificationsUnsupportedError) ErrorCode() int { return -32601 }
+func (e notificationsUnsupportedError) ErrorCode() int { return ErrcodeMethodNotFound }
 
 // Is checks for equivalence to another error. Here we define that all errors with code
 // -32601 (method not found) are equivalent to notificationsUnsupportedError. This is
@@ -104,14 +114,14 @@ func (e notificationsUnsupportedError) Is(other error) bool {
 	rpcErr, ok := other.(Error)
 	if ok {
 		code := rpcErr.ErrorCode()
-		return code == -32601 || code == legacyErrcodeNotificationsUnsupported
+		return code == ErrcodeMethodNotFound || code == legacyErrcodeNotificationsUnsupported
 	}
 	return false
 }
 
 type subscriptionNotFoundError struct{ namespace, subscription string }
 
-func (e *subscriptionNotFoundError) ErrorCode() int { return -32601 }
+func (e *subscriptionNotFoundError) ErrorCode() int { return ErrcodeMethodNotFound }
 
 func (e *subscriptionNotFoundError) Error() string {
 	return fmt.Sprintf("no %q subscription in %s namespace", e.subscription, e.namespace)
@@ -154,3 +164,19 @@ type internalServerError struct {
 func (e *internalServerError) ErrorCode() int { return e.code }
 
 func (e *internalServerError) Error() string { return e.message }
+
+// limitExceededError is returned when a client exceeds its request quota.
+type limitExceededError struct{ message string }
+
+func (e *limitExceededError) ErrorCode() int { return ErrcodeLimitExceeded }
+
+func (e *limitExceededError) Error() string { return e.message }
+
+// notPermittedError is returned when the JWT of a client doesn't permit a call.
+type notPermittedError struct{ method string }
+
+func (e *notPermittedError) ErrorCode() int { return errcodeNotPermitted }
+
+func (e *notPermittedError) Error() string {
+	return fmt.Sprintf("the method %s is not permitted by the auth token", e.method)
+}
@@ -28,6 +28,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/internal/telemetry"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -65,6 +66,11 @@ type handler struct {
 	allowSubscribe       bool
 	batchRequestLimit    int
 	batchResponseMaxSize int
+	quota                QuotaLimiter   // optional per-client call quota
+	accessLog            *accessLog     // optional access log
+	responseCache        *ResponseCache // optional cache of immutable results
+	batchConcurrency     int            // number of batch items processed in parallel
+	middleware           []Middleware   // interceptors of method calls, outermost first
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
@@ -73,6 +79,7 @@ type handler struct {
 type callProc struct {
 	ctx       context.Context
 	notifiers []*Notifier
+	batchID   uint64 // non-zero when processing a batch
 }
 
 func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *serviceRegistry, batchRequestLimit, batchResponseMaxSize int) *handler {
@@ -102,35 +109,50 @@ func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *
 // call. Calls need to be synchronized between the processing and timeout-triggering
 // goroutines.
 type batchCallBuffer struct {
-	mutex sync.Mutex
-	calls []*jsonrpcMessage
-	resp  []*jsonrpcMessage
-	wrote bool
+	mutex     sync.Mutex
+	calls     []*jsonrpcMessage
+	resp      []*jsonrpcMessage // responses by call index
+	done      []bool            // tracks which calls have been answered
+	next      int               // index of the next call to process
+	respBytes int               // total size of the responses so far
+	wrote     bool
 }
 
-// nextCall returns the next unprocessed message.
-func (b *batchCallBuffer) nextCall() *jsonrpcMessage {
+func newBatchCallBuffer(calls []*jsonrpcMessage) *batchCallBuffer {
+	return &batchCallBuffer{
+		calls: calls,
+		resp:  make([]*jsonrpcMessage, len(calls)),
+		done:  make([]bool, len(calls)),
+	}
+}
+
+// nextCall returns the next unprocessed message and its index. It returns nil when all
+// calls have been handed out, or the response has already been written.
+func (b *batchCallBuffer) nextCall() (int, *jsonrpcMessage) {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
-	if len(b.calls) == 0 {
-		return nil
+	if b.wrote || b.next == len(b.calls) {
+		return 0, nil
 	}
-	// The popping happens in `pushAnswer`. The in progress call is kept
-	// so we can return an error for it in case of timeout.
-	msg := b.calls[0]
-	return msg
+	// The in progress call is only marked done in pushResponse, so we can
+	// return an error for it in case of timeout.
+	b.next++
+	return b.next - 1, b.calls[b.next-1]
 }
 
-// pushResponse adds the response to last call returned by nextCall.
-func (b *batchCallBuffer) pushResponse(answer *jsonrpcMessage) {
+// pushResponse adds the response to the call with the given index. It returns the
+// total size of the responses added so far.
+func (b *batchCallBuffer) pushResponse(index int, answer *jsonrpcMessage) int {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
+	b.resp[index] = answer
+	b.done[index] = true
 	if answer != nil {
-		b.resp = append(b.resp, answer)
+		b.respBytes += len(answer.Result)
 	}
-	b.calls = b.calls[1:]
+	return b.respBytes
 }
 
 // write sends the responses.
@@ -138,7 +160,7 @@ func (b *batchCallBuffer) write(ctx context.Context, conn jsonWriter) {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
-	b.doWrite(ctx, conn, false)
+	b.doWrite(ctx, conn, nil)
 }
 
 // respondWithError sends the responses added so far. For the remaining unanswered call
@@ -147,23 +169,30 @@ func (b *batchCallBuffer) respondWithError(ctx context.Context, conn jsonWriter,
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
-	for _, msg := range b.calls {
-		if !msg.isNotification() {
-			b.resp = append(b.resp, msg.errorResponse(err))
-		}
-	}
-	b.doWrite(ctx, conn, true)
+	b.doWrite(ctx, conn, err)
 }
 
-// doWrite actually writes the response.
+// doWrite actually writes the responses in the order of the calls. Unanswered calls
+// are responded to with err.
 // This assumes b.mutex is held.
-func (b *batchCallBuffer) doWrite(ctx context.Context, conn jsonWriter, isErrorResponse bool) {
+func (b *batchCallBuffer) doWrite(ctx context.Context, conn jsonWriter, err error) {
 	if b.wrote {
 		return
 	}
 	b.wrote = true // can only write once
-	if len(b.resp) > 0 {
-		conn.writeJSON(ctx, b.resp, isErrorResponse)
+	resp := make([]*jsonrpcMessage, 0, len(b.calls))
+	for i, msg := range b.calls {
+		switch {
+		case b.done[i]:
+			if b.resp[i] != nil {
+				resp = append(resp, b.resp[i])
+			}
+		case err != nil && !msg.isNotification():
+			resp = append(resp, msg.errorResponse(err))
+		}
+	}
+	if len(resp) > 0 {
+		conn.writeJSON(ctx, resp, err != nil)
 	}
 }
 
@@ -200,11 +229,12 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 		var (
 			timer      *time.Timer
 			cancel     context.CancelFunc
-			callBuffer = &batchCallBuffer{calls: calls, resp: make([]*jsonrpcMessage, 0, len(calls))}
+			callBuffer = newBatchCallBuffer(calls)
 		)
 
 		cp.ctx, cancel = context.WithCancel(cp.ctx)
 		defer cancel()
+		cp.batchID = batchCounter.Add(1)
 
 		// Cancel the request context after timeout and send an error response. Since the
 		// currently-running method might not return immediately on timeout, we must wait
@@ -217,27 +247,48 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 			})
 		}
 
-		responseBytes := 0
-		for {
-			// No need to handle rest of calls if timed out.
-			if cp.ctx.Err() != nil {
-				break
-			}
-			msg := callBuffer.nextCall()
-			if msg == nil {
-				break
-			}
-			resp := h.handleCallMsg(cp, msg)
-			callBuffer.pushResponse(resp)
-			if resp != nil && h.batchResponseMaxSize != 0 {
-				responseBytes += len(resp.Result)
-				if responseBytes > h.batchResponseMaxSize {
+		process := func(cp *callProc) {
+			for {
+				// No need to handle rest of calls if timed out.
+				if cp.ctx.Err() != nil {
+					return
+				}
+				index, msg := callBuffer.nextCall()
+				if msg == nil {
+					return
+				}
+				resp := h.handleCallMsg(cp, msg)
+				responseBytes := callBuffer.pushResponse(index, resp)
+				if resp != nil && h.batchResponseMaxSize != 0 && responseBytes > h.batchResponseMaxSize {
 					err := &internalServerError{errcodeResponseTooLarge, errMsgResponseTooLarge}
 					callBuffer.respondWithError(cp.ctx, h.conn, err)
-					break
+					cancel() // abort the calls running concurrently
+					return
 				}
 			}
 		}
+		if workers := min(h.batchConcurrency, len(calls)); workers <= 1 {
+			process(cp)
+		} else {
+			// Each worker has its own callProc, since subscriptions created by the
+			// calls are added to it.
+			var (
+				wg    sync.WaitGroup
+				procs = make([]*callProc, workers)
+			)
+			for i := range procs {
+				procs[i] = &callProc{ctx: cp.ctx, batchID: cp.batchID}
+				wg.Add(1)
+				go func(wcp *callProc) {
+					defer wg.Done()
+					process(wcp)
+				}(procs[i])
+			}
+			wg.Wait()
+			for _, wcp := range procs {
+				cp.notifiers = append(cp.notifiers, wcp.notifiers...)
+			}
+		}
 		if timer != nil {
 			timer.Stop()
 		}
@@ -471,8 +522,15 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 
 	case msg.isCall():
 		resp := h.handleCall(ctx, msg)
+		elapsed := time.Since(start)
+		if h.accessLog != nil {
+			h.accessLog.write(ctx, msg, resp, elapsed)
+		}
 		var logctx []any
-		logctx = append(logctx, "reqid", idForLog{msg.ID}, "duration", time.Since(start))
+		logctx = append(logctx, "reqid", idForLog{msg.ID}, "duration", elapsed)
+		if subject := PeerInfoFromContext(ctx.ctx).AuthSubject; subject != "" {
+			logctx = append(logctx, "subject", subject)
+		}
 		if resp.Error != nil {
 			logctx = append(logctx, "err", resp.Error.Message)
 			if resp.Error.Data != nil {
@@ -493,9 +551,47 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 }
 
 // handleCall processes method calls.
-func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) (answer *jsonrpcMessage) {
+	ctx, span, spanEnd := telemetry.StartServerSpan(cp.ctx, msg.Method,
+		telemetry.StringAttribute("rpc.system", "jsonrpc"),
+		telemetry.StringAttribute("rpc.method", msg.Method),
+		telemetry.StringAttribute("rpc.jsonrpc.request_id", string(msg.ID)),
+	)
+	defer func() {
+		var err error
+		if answer != nil && answer.Error != nil {
+			span.SetAttributes(telemetry.Int64Attribute("rpc.jsonrpc.error_code", int64(answer.Error.Code)))
+			err = answer.Error
+		}
+		spanEnd(&err)
+	}()
+
+	if len(h.middleware) > 0 {
+		return h.runMiddleware(ctx, cp, msg)
+	}
+	return h.runCall(ctx, cp, msg)
+}
+
+// runCall executes a method call.
+func (h *handler) runCall(ctx context.Context, cp *callProc, msg *jsonrpcMessage) (answer *jsonrpcMessage) {
+	peer := PeerInfoFromContext(ctx)
+	if peer.AuthSubject != "" {
+		authSubjectMeter(peer.AuthSubject, "requests").Mark(1)
+	}
+	if !msg.isUnsubscribe() && !peer.permits(msg.Method) {
+		authDeniedMeter.Mark(1)
+		if peer.AuthSubject != "" {
+			authSubjectMeter(peer.AuthSubject, "denied").Mark(1)
+		}
+		return msg.errorResponse(&notPermittedError{method: msg.Method})
+	}
+	if h.quota != nil && !msg.isUnsubscribe() {
+		if err := h.quota.Allow(peer, msg.Method); err != nil {
+			return msg.errorResponse(err)
+		}
+	}
 	if msg.isSubscribe() {
-		return h.handleSubscribe(cp, msg)
+		return h.handleSubscribe(ctx, cp, msg)
 	}
 	var callb *callback
 	if msg.isUnsubscribe() {
@@ -516,7 +612,16 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 		return msg.errorResponse(&invalidParamsError{err.Error()})
 	}
 	start := time.Now()
-	answer := h.runMethod(cp.ctx, msg, callb, args)
+	if callb != h.unsubscribeCb {
+		rpcInflightGauge.Inc(1)
+		inflight := inflightGauge(msg.Method)
+		inflight.Inc(1)
+		defer func() {
+			rpcInflightGauge.Dec(1)
+			inflight.Dec(1)
+		}()
+	}
+	answer = h.runMethod(ctx, msg, callb, args)
 
 	// Collect the statistics for RPC calls if metrics is enabled.
 	// We only care about pure rpc call. Filter out subscription.
@@ -535,7 +640,7 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 }
 
 // handleSubscribe processes *_subscribe method calls.
-func (h *handler) handleSubscribe(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+func (h *handler) handleSubscribe(ctx context.Context, cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
 	if !h.allowSubscribe {
 		return msg.errorResponse(ErrNotificationsUnsupported)
 	}
@@ -567,18 +672,29 @@ func (h *handler) handleSubscribe(cp *callProc, msg *jsonrpcMessage) *jsonrpcMes
 	// Install notifier in context so the subscription handler can find it.
 	n := &Notifier{h: h, namespace: namespace}
 	cp.notifiers = append(cp.notifiers, n)
-	ctx := context.WithValue(cp.ctx, notifierKey{}, n)
+	ctx = context.WithValue(ctx, notifierKey{}, n)
 
 	return h.runMethod(ctx, msg, callb, args)
 }
 
 // runMethod runs the Go callback for an RPC method.
 func (h *handler) runMethod(ctx context.Context, msg *jsonrpcMessage, callb *callback, args []reflect.Value) *jsonrpcMessage {
+	var key string
+	if h.responseCache != nil {
+		var cached json.RawMessage
+		if key, cached = h.responseCache.lookup(ctx, msg); cached != nil {
+			return &jsonrpcMessage{Version: vsn, ID: msg.ID, Result: cached}
+		}
+	}
 	result, err := callb.call(ctx, msg.Method, args)
 	if err != nil {
 		return msg.errorResponse(err)
 	}
-	return msg.response(result)
+	answer := msg.response(result)
+	if key != "" && answer.Error == nil {
+		h.responseCache.add(key, answer.Result)
+	}
+	return answer
 }
 
 // unsubscribe is the callback function for all *_unsubscribe calls.
@@ -30,6 +30,8 @@ import (
 	"strconv"
 	"sync"
 	"time"
+
+	"github.com/ethereum/go-ethereum/internal/telemetry"
 )
 
 const (
@@ -48,6 +50,7 @@ type httpConn struct {
 	mu        sync.Mutex // protects headers
 	headers   http.Header
 	auth      HTTPAuth
+	sse       bool // subscriptions are made over server-sent events
 }
 
 // httpConn implements ServerCodec, but it is treated specially by Client
@@ -160,6 +163,7 @@ func newClientTransportHTTP(endpoint string, cfg *clientConfig) reconnectFunc {
 		headers: headers,
 		url:     endpoint,
 		auth:    cfg.httpAuth,
+		sse:     cfg.httpSubscriptions,
 		closeCh: make(chan interface{}),
 	}
 
@@ -206,6 +210,20 @@ func (hc *httpConn) doRequest(ctx context.Context, msg interface{}) (io.ReadClos
 	if err != nil {
 		return nil, err
 	}
+	req, err := hc.newRequest(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := hc.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// newRequest creates a POST request with the given body, carrying the headers
+// of the connection and ctx.
+func (hc *httpConn) newRequest(ctx context.Context, body []byte) (*http.Request, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hc.url, io.NopCloser(bytes.NewReader(body)))
 	if err != nil {
 		return nil, err
@@ -224,8 +242,11 @@ func (hc *httpConn) doRequest(ctx context.Context, msg interface{}) (io.ReadClos
 			return nil, err
 		}
 	}
+	return req, nil
+}
 
-	// do request
+// do sends the request, returning an HTTPError if the response status is not 2xx.
+func (hc *httpConn) do(req *http.Request) (*http.Response, error) {
 	resp, err := hc.client.Do(req)
 	if err != nil {
 		return nil, err
@@ -243,7 +264,7 @@ func (hc *httpConn) doRequest(ctx context.Context, msg interface{}) (io.ReadClos
 			Body:       body,
 		}
 	}
-	return resp.Body, nil
+	return resp, nil
 }
 
 // httpServerConn turns a HTTP connection into a Conn.
@@ -312,19 +333,34 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		return
 	}
+	// Create request-scoped context.
+	connInfo := PeerInfo{Transport: "http", RemoteAddr: r.RemoteAddr, AuthSubject: authSubjectFromContext(r.Context()), AuthScope: authScopeFromContext(r.Context())}
 	if code, err := s.validateRequest(r); err != nil {
 		http.Error(w, err.Error(), code)
 		return
 	}
+	if isSSECloseRequest(r) {
+		s.closeSSE(w, r, connInfo)
+		return
+	}
 
-	// Create request-scoped context.
-	connInfo := PeerInfo{Transport: "http", RemoteAddr: r.RemoteAddr}
 	connInfo.HTTP.Version = r.Proto
 	connInfo.HTTP.Host = r.Host
 	connInfo.HTTP.Origin = r.Header.Get("Origin")
 	connInfo.HTTP.UserAgent = r.Header.Get("User-Agent")
 	ctx := r.Context()
 	ctx = context.WithValue(ctx, peerInfoContextKey{}, connInfo)
+	ctx = telemetry.Extract(ctx, r.Header)
+	if s.tracerProvider != nil {
+		ctx = telemetry.WithTracerProvider(ctx, s.tracerProvider)
+	}
+
+	// Serve requests asking for server-sent events as a long-lived stream, which
+	// allows for subscriptions.
+	if isSSERequest(r) {
+		s.serveSSE(w, r, connInfo)
+		return
+	}
 
 	// All checks passed, create a codec that reads directly from the request body
 	// until EOF, writes the response to w, and orders the server to process a
@@ -338,7 +374,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 // validateRequest returns a non-zero response code and error message if the
 // request is invalid.
 func (s *Server) validateRequest(r *http.Request) (int, error) {
-	if r.Method == http.MethodPut || r.Method == http.MethodDelete {
+	if r.Method == http.MethodPut || (r.Method == http.MethodDelete && !isSSECloseRequest(r)) {
 		return http.StatusMethodNotAllowed, errors.New("method not allowed")
 	}
 	if r.ContentLength > int64(s.httpBodyLimit) {
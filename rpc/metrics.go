@@ -32,6 +32,22 @@ var (
 	serveTimeHistName = "rpc/duration"
 
 	rpcServingTimer = metrics.NewRegisteredTimer("rpc/duration/all", nil)
+
+	// inflightGaugeName is the prefix of the per-method in-flight request gauges.
+	inflightGaugeName = "rpc/inflight"
+
+	rpcInflightGauge = metrics.NewRegisteredGauge("rpc/inflight/all", nil)
+
+	quotaExceededMeter = metrics.NewRegisteredMeter("rpc/quota/exceeded", nil)
+
+	// authSubjectName is the prefix of the per-subject request and denial meters
+	// of clients authenticated with a JWT.
+	authSubjectName = "rpc/subject"
+
+	authDeniedMeter = metrics.NewRegisteredMeter("rpc/auth/denied", nil)
+
+	responseCacheHitMeter  = metrics.NewRegisteredMeter("rpc/cache/hit", nil)
+	responseCacheMissMeter = metrics.NewRegisteredMeter("rpc/cache/miss", nil)
 )
 
 // updateServeTimeHistogram tracks the serving time of a remote RPC call.
@@ -46,3 +62,15 @@ func updateServeTimeHistogram(method string, success bool, elapsed time.Duration
 	}
 	metrics.GetOrRegisterHistogramLazy(h, nil, sampler).Update(elapsed.Nanoseconds())
 }
+
+// inflightGauge returns the gauge tracking the number of requests of the given
+// method currently being served.
+func inflightGauge(method string) *metrics.Gauge {
+	return metrics.GetOrRegisterGauge(fmt.Sprintf("%s/%s", inflightGaugeName, method), nil)
+}
+
+// authSubjectMeter returns the meter of the given kind ("requests" or "denied")
+// of the calls made by clients authenticated as subject.
+func authSubjectMeter(subject, kind string) *metrics.Meter {
+	return metrics.GetOrRegisterMeter(fmt.Sprintf("%s/%s/%s", authSubjectName, subject, kind), nil)
+}
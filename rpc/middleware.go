@@ -0,0 +1,80 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Request is a method call passing through the middleware of a server.
+type Request struct {
+	// ID is the request ID, or nil for notifications. It identifies the call to
+	// the client and can't be changed by middleware.
+	ID json.RawMessage
+
+	Method string
+	Params json.RawMessage
+}
+
+// Response is the outcome of a method call passing through the middleware of a
+// server. Either Result or Error is set.
+//
+// Error may implement Error and DataError to control the error code and data of
+// the JSON-RPC response. Errors returned by the server implement both.
+type Response struct {
+	Result json.RawMessage
+	Error  error
+}
+
+// CallHandler processes a method call.
+type CallHandler func(ctx context.Context, req *Request) *Response
+
+// Middleware intercepts method calls by wrapping the handler of the next
+// middleware, or the server itself. Middleware can reject a call by returning a
+// response without invoking next, rewrite the request or response, and annotate
+// the call by adding values to the context passed to next, which is also the
+// context of the method.
+//
+// Middleware sees every call, including the calls of batches and subscriptions.
+// It must be safe for concurrent use and must not return a nil response.
+type Middleware func(next CallHandler) CallHandler
+
+// runMiddleware passes a method call through the middleware chain of the handler.
+func (h *handler) runMiddleware(ctx context.Context, cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	next := func(ctx context.Context, req *Request) *Response {
+		call := *msg
+		call.Method, call.Params = req.Method, req.Params
+		answer := h.runCall(ctx, cp, &call)
+		if answer.Error != nil {
+			return &Response{Error: answer.Error}
+		}
+		return &Response{Result: answer.Result}
+	}
+	for i := len(h.middleware) - 1; i >= 0; i-- {
+		next = h.middleware[i](next)
+	}
+	resp := next(ctx, &Request{ID: msg.ID, Method: msg.Method, Params: msg.Params})
+	if resp.Error != nil {
+		return msg.errorResponse(resp.Error)
+	}
+	result := resp.Result
+	if result == nil {
+		result = null
+	}
+	return &jsonrpcMessage{Version: vsn, ID: msg.ID, Result: result}
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type middlewareTagKey struct{}
+
+type middlewareTestService struct{}
+
+func (middlewareTestService) Tag(ctx context.Context) string {
+	tag, _ := ctx.Value(middlewareTagKey{}).(string)
+	return tag
+}
+
+type rejectedError struct{}
+
+func (rejectedError) Error() string  { return "rejected" }
+func (rejectedError) ErrorCode() int { return -32099 }
+
+func newMiddlewareTestClient(t *testing.T, middleware ...Middleware) *Client {
+	t.Helper()
+
+	server := newTestServer()
+	if err := server.RegisterName("mw", middlewareTestService{}); err != nil {
+		t.Fatal(err)
+	}
+	server.Use(middleware...)
+	client := DialInProc(server)
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+	return client
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		trace []string
+	)
+	record := func(name string) Middleware {
+		return func(next CallHandler) CallHandler {
+			return func(ctx context.Context, req *Request) *Response {
+				mu.Lock()
+				trace = append(trace, name+" "+req.Method)
+				mu.Unlock()
+				resp := next(ctx, req)
+				mu.Lock()
+				trace = append(trace, name+" done")
+				mu.Unlock()
+				return resp
+			}
+		}
+	}
+	client := newMiddlewareTestClient(t, record("a"), record("b"))
+
+	var result echoResult
+	if err := client.Call(&result, "test_echo", "x", 1); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"a test_echo", "b test_echo", "b done", "a done"}
+	if !reflect.DeepEqual(trace, want) {
+		t.Fatalf("wrong middleware order: got %q, want %q", trace, want)
+	}
+}
+
+func TestMiddlewareReject(t *testing.T) {
+	var reached bool
+	client := newMiddlewareTestClient(t,
+		func(next CallHandler) CallHandler {
+			return func(ctx context.Context, req *Request) *Response {
+				if req.Method == "test_echo" {
+					return &Response{Error: rejectedError{}}
+				}
+				return next(ctx, req)
+			}
+		},
+		func(next CallHandler) CallHandler {
+			return func(ctx context.Context, req *Request) *Response {
+				reached = true
+				return next(ctx, req)
+			}
+		},
+	)
+
+	var result echoResult
+	err := client.Call(&result, "test_echo", "x", 1)
+	var rpcErr Error
+	if !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != -32099 || rpcErr.Error() != "rejected" {
+		t.Fatalf("expected rejection error, got %v", err)
+	}
+	if reached {
+		t.Fatal("rejected call reached the next middleware")
+	}
+	// Other methods still go through.
+	var tag string
+	if err := client.Call(&tag, "mw_tag"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMiddlewareRewrite(t *testing.T) {
+	client := newMiddlewareTestClient(t,
+		// Redirects calls of test_shout to test_echo with a fixed argument.
+		func(next CallHandler) CallHandler {
+			return func(ctx context.Context, req *Request) *Response {
+				if req.Method == "test_shout" {
+					req.Method, req.Params = "test_echo", json.RawMessage(`["hello",7]`)
+				}
+				return next(ctx, req)
+			}
+		},
+		// Replaces the result of test_echo.
+		func(next CallHandler) CallHandler {
+			return func(ctx context.Context, req *Request) *Response {
+				resp := next(ctx, req)
+				if req.Method == "test_echo" && resp.Error == nil {
+					var res echoResult
+					if err := json.Unmarshal(resp.Result, &res); err != nil {
+						return &Response{Error: err}
+					}
+					res.String += "!"
+					resp.Result, _ = json.Marshal(res)
+				}
+				return resp
+			}
+		},
+	)
+
+	var result echoResult
+	if err := client.Call(&result, "test_shout"); err != nil {
+		t.Fatal(err)
+	}
+	want := echoResult{String: "hello!", Int: 7}
+	if !reflect.DeepEqual(result, want) {
+		t.Fatalf("wrong result: got %+v, want %+v", result, want)
+	}
+}
+
+func TestMiddlewareAnnotate(t *testing.T) {
+	client := newMiddlewareTestClient(t, func(next CallHandler) CallHandler {
+		return func(ctx context.Context, req *Request) *Response {
+			return next(context.WithValue(ctx, middlewareTagKey{}, "tagged"), req)
+		}
+	})
+
+	var tag string
+	if err := client.Call(&tag, "mw_tag"); err != nil {
+		t.Fatal(err)
+	}
+	if tag != "tagged" {
+		t.Fatalf("method didn't see context annotation, got %q", tag)
+	}
+}
+
+func TestMiddlewareBatch(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		methods = make(map[string]int)
+	)
+	client := newMiddlewareTestClient(t, func(next CallHandler) CallHandler {
+		return func(ctx context.Context, req *Request) *Response {
+			mu.Lock()
+			methods[req.Method]++
+			mu.Unlock()
+			if req.Method == "test_returnError" {
+				return &Response{Error: rejectedError{}}
+			}
+			return next(ctx, req)
+		}
+	})
+
+	batch := []BatchElem{
+		{Method: "test_echo", Args: []any{"x", 1}, Result: new(echoResult)},
+		{Method: "test_returnError", Result: new(any)},
+		{Method: "mw_tag", Result: new(string)},
+	}
+	if err := client.BatchCall(batch); err != nil {
+		t.Fatal(err)
+	}
+	if batch[0].Error != nil || batch[2].Error != nil {
+		t.Fatalf("unexpected batch errors: %v, %v", batch[0].Error, batch[2].Error)
+	}
+	var rpcErr Error
+	if !errors.As(batch[1].Error, &rpcErr) || rpcErr.ErrorCode() != -32099 {
+		t.Fatalf("expected rejection of batch element, got %v", batch[1].Error)
+	}
+	want := map[string]int{"test_echo": 1, "test_returnError": 1, "mw_tag": 1}
+	if !reflect.DeepEqual(methods, want) {
+		t.Fatalf("wrong calls seen by middleware: got %v, want %v", methods, want)
+	}
+}
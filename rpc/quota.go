@@ -0,0 +1,173 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/common/mclock"
+)
+
+// QuotaLimiter decides whether a client may execute a method call. The server
+// consults it before every call, including each call of a batch.
+type QuotaLimiter interface {
+	// Allow returns nil if the call may proceed, or the error sent back to the
+	// client otherwise.
+	Allow(peer PeerInfo, method string) error
+}
+
+// QuotaConfig configures the limiter created by NewQuotaLimiter.
+type QuotaConfig struct {
+	// Rate is the number of cost units credited to every client per second.
+	// Zero disables the quota.
+	Rate float64 `toml:",omitempty"`
+
+	// Burst is the maximum number of cost units a client can accumulate. If it
+	// is lower than Rate, Rate is used instead.
+	Burst float64 `toml:",omitempty"`
+
+	// MethodCosts overrides the cost of individual methods. Methods not listed
+	// here or in DefaultMethodCosts cost one unit.
+	MethodCosts map[string]float64 `toml:",omitempty"`
+
+	// MaxClients is the number of clients tracked at once. When exceeded, the
+	// least recently seen client is forgotten.
+	MaxClients int `toml:",omitempty"`
+}
+
+// DefaultMethodCosts contains the cost of the methods which are considerably
+// more expensive to serve than a simple state lookup.
+var DefaultMethodCosts = map[string]float64{
+	"eth_call":                 10,
+	"eth_estimateGas":          10,
+	"eth_estimateGasDetailed":  20,
+	"eth_createAccessList":     20,
+	"eth_callMany":             50,
+	"eth_simulateV1":           50,
+	"eth_getLogs":              20,
+	"eth_getFilterLogs":        20,
+	"eth_getBlockReceipts":     5,
+	"debug_traceCall":          50,
+	"debug_traceTransaction":   100,
+	"debug_traceBlock":         500,
+	"debug_traceBlockByNumber": 500,
+	"debug_traceBlockByHash":   500,
+	"debug_traceBadBlock":      500,
+	"debug_traceChain":         1000,
+}
+
+const (
+	defaultMethodCost      = 1
+	defaultQuotaMaxClients = 10000
+)
+
+// tokenBucket tracks the cost units available to a single client.
+type tokenBucket struct {
+	tokens  float64
+	updated mclock.AbsTime
+}
+
+// quotaLimiter is a QuotaLimiter maintaining a token bucket for every client.
+// Clients authenticated with a JWT are identified by its subject, all others by
+// their IP address.
+type quotaLimiter struct {
+	rate  float64
+	burst float64
+	costs map[string]float64
+	clock mclock.Clock
+
+	mu      sync.Mutex
+	buckets lru.BasicLRU[string, *tokenBucket]
+}
+
+// NewQuotaLimiter creates a token bucket based QuotaLimiter. It returns nil if
+// the quota is disabled in the configuration.
+func NewQuotaLimiter(config QuotaConfig) QuotaLimiter {
+	if config.Rate <= 0 {
+		return nil
+	}
+	return newQuotaLimiter(config, mclock.System{})
+}
+
+func newQuotaLimiter(config QuotaConfig, clock mclock.Clock) *quotaLimiter {
+	l := &quotaLimiter{
+		rate:  config.Rate,
+		burst: max(config.Burst, config.Rate),
+		costs: make(map[string]float64, len(DefaultMethodCosts)+len(config.MethodCosts)),
+		clock: clock,
+	}
+	for method, cost := range DefaultMethodCosts {
+		l.costs[method] = cost
+	}
+	for method, cost := range config.MethodCosts {
+		l.costs[method] = cost
+	}
+	maxClients := config.MaxClients
+	if maxClients <= 0 {
+		maxClients = defaultQuotaMaxClients
+	}
+	l.buckets = lru.NewBasicLRU[string, *tokenBucket](maxClients)
+	return l
+}
+
+// Allow implements QuotaLimiter. Methods costing more than the burst size are
+// charged the burst size, so they can still be called by clients with a full
+// bucket.
+func (l *quotaLimiter) Allow(peer PeerInfo, method string) error {
+	cost, ok := l.costs[method]
+	if !ok {
+		cost = defaultMethodCost
+	}
+	cost = min(cost, l.burst)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	key := quotaClientKey(peer)
+	bucket, ok := l.buckets.Get(key)
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, updated: now}
+		l.buckets.Add(key, bucket)
+	}
+	elapsed := time.Duration(now - bucket.updated).Seconds()
+	bucket.tokens = min(l.burst, bucket.tokens+elapsed*l.rate)
+	bucket.updated = now
+
+	if bucket.tokens < cost {
+		quotaExceededMeter.Mark(1)
+		wait := time.Duration((cost - bucket.tokens) / l.rate * float64(time.Second))
+		return &limitExceededError{fmt.Sprintf("rate limit exceeded for %s, retry in %v", method, wait.Round(time.Millisecond))}
+	}
+	bucket.tokens -= cost
+	return nil
+}
+
+// quotaClientKey returns the identity the quota of a client is tracked by.
+func quotaClientKey(peer PeerInfo) string {
+	if peer.AuthSubject != "" {
+		return "jwt:" + peer.AuthSubject
+	}
+	if host, _, err := net.SplitHostPort(peer.RemoteAddr); err == nil {
+		return "ip:" + host
+	}
+	return "ip:" + peer.RemoteAddr
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/mclock"
+)
+
+func TestQuotaLimiter(t *testing.T) {
+	var (
+		clock   = new(mclock.Simulated)
+		limiter = newQuotaLimiter(QuotaConfig{
+			Rate:        10,
+			Burst:       20,
+			MethodCosts: map[string]float64{"test_expensive": 15, "test_huge": 1000},
+		}, clock)
+		alice  = PeerInfo{RemoteAddr: "10.0.0.1:1234"}
+		alice2 = PeerInfo{RemoteAddr: "10.0.0.1:5678"}
+		bob    = PeerInfo{RemoteAddr: "10.0.0.2:1234"}
+		carol  = PeerInfo{RemoteAddr: "10.0.0.1:1234", AuthSubject: "carol"}
+		allow  = func(peer PeerInfo, method string) {
+			t.Helper()
+			if err := limiter.Allow(peer, method); err != nil {
+				t.Fatalf("%s by %+v rejected: %v", method, peer, err)
+			}
+		}
+		reject = func(peer PeerInfo, method string) {
+			t.Helper()
+			err := limiter.Allow(peer, method)
+			var rpcErr Error
+			if !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != ErrcodeLimitExceeded {
+				t.Fatalf("%s by %+v: want limit exceeded error, have %v", method, peer, err)
+			}
+		}
+	)
+	allow(alice, "test_expensive")
+	allow(alice2, "test_echo")
+	allow(alice, "eth_chainId")
+	reject(alice2, "test_expensive") // 3 units left, shared by all connections from the same IP
+	allow(bob, "test_expensive")     // other IPs have their own bucket
+	allow(carol, "test_expensive")   // authenticated clients are tracked by subject
+
+	clock.Run(2 * time.Second)
+	allow(alice, "test_expensive") // refilled up to the burst size
+	reject(alice, "test_huge")
+
+	clock.Run(10 * time.Second)
+	allow(alice, "test_huge") // charged the full burst
+	reject(alice, "eth_chainId")
+}
+
+func TestServerQuota(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+	server.SetQuotaLimiter(NewQuotaLimiter(QuotaConfig{Rate: 1, Burst: 2}))
+
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+	client, err := DialHTTP(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	for i := 0; i < 2; i++ {
+		if err := client.Call(nil, "test_null"); err != nil {
+			t.Fatalf("call %d failed: %v", i, err)
+		}
+	}
+	err = client.Call(nil, "test_null")
+	var rpcErr Error
+	if !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != ErrcodeLimitExceeded {
+		t.Fatalf("want limit exceeded error, have %v", err)
+	}
+
+	// Batch items are charged individually.
+	batch := []BatchElem{{Method: "test_null"}, {Method: "test_null"}}
+	if err := client.BatchCallContext(context.Background(), batch); err != nil {
+		t.Fatal(err)
+	}
+	for i, elem := range batch {
+		if !errors.As(elem.Error, &rpcErr) || rpcErr.ErrorCode() != ErrcodeLimitExceeded {
+			t.Fatalf("batch item %d: want limit exceeded error, have %v", i, elem.Error)
+		}
+	}
+}
+
+func TestServerQuotaAuthSubject(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.ServeHTTP(w, r.WithContext(ContextWithAuthSubject(r.Context(), "indexer")))
+	}))
+	defer ts.Close()
+	client, err := DialHTTP(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	var info PeerInfo
+	if err := client.Call(&info, "test_peerInfo"); err != nil {
+		t.Fatal(err)
+	}
+	if info.AuthSubject != "indexer" {
+		t.Fatalf("wrong auth subject %q", info.AuthSubject)
+	}
+}
@@ -21,10 +21,12 @@ import (
 	"errors"
 	"io"
 	"net"
+	"strings"
 	"sync"
 	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const MetadataApi = "rpc"
@@ -48,20 +50,34 @@ type Server struct {
 	services serviceRegistry
 	idgen    func() ID
 
-	mutex              sync.Mutex
-	codecs             map[ServerCodec]struct{}
-	run                atomic.Bool
-	batchItemLimit     int
-	batchResponseLimit int
-	httpBodyLimit      int
+	mutex               sync.Mutex
+	codecs              map[ServerCodec]struct{}
+	run                 atomic.Bool
+	batchItemLimit      int
+	batchResponseLimit  int
+	batchConcurrency    int
+	httpBodyLimit       int
+	quota               QuotaLimiter
+	accessLog           *accessLog
+	tracerProvider      trace.TracerProvider
+	responseCache       *ResponseCache
+	middleware          []Middleware
+	sseStreams          map[string]*sseStream // open server-sent event streams
+	sseClients          map[string]int        // number of open event streams per client
+	sseMaxStreams       int
+	sseMaxClientStreams int
 }
 
 // NewServer creates a new server instance with no registered handlers.
 func NewServer() *Server {
 	server := &Server{
-		idgen:         randomIDGenerator(),
-		codecs:        make(map[ServerCodec]struct{}),
-		httpBodyLimit: defaultBodyLimit,
+		idgen:               randomIDGenerator(),
+		codecs:              make(map[ServerCodec]struct{}),
+		sseStreams:          make(map[string]*sseStream),
+		sseClients:          make(map[string]int),
+		sseMaxStreams:       sseMaxStreams,
+		sseMaxClientStreams: sseMaxClientStreams,
+		httpBodyLimit:       defaultBodyLimit,
 	}
 	server.run.Store(true)
 	// Register the default service providing meta information about the RPC service such
@@ -82,10 +98,77 @@ func (s *Server) SetBatchLimits(itemLimit, maxResponseSize int) {
 	s.batchResponseLimit = maxResponseSize
 }
 
+// SetBatchConcurrency sets the number of items of a batch request that are processed in
+// parallel. Responses are always returned in the order of the requests. By default, the
+// items of a batch are processed one after the other.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetBatchConcurrency(workers int) {
+	s.batchConcurrency = workers
+}
+
+// ApplyAPIFilter restricts the callable methods to those in apiFilter, keyed by their
+// full name, e.g. "eth_call". Passing nil allows all methods. The filter applies to
+// new requests and may be changed while the server is running.
 func (s *Server) ApplyAPIFilter(apiFilter map[string]bool) {
+	s.services.mu.Lock()
+	defer s.services.mu.Unlock()
 	s.services.apiFilter = apiFilter
 }
 
+// SetModules restricts the served APIs to the given namespaces. Passing an empty list
+// enables all registered namespaces. The module set applies to new requests and may
+// be changed while the server is running.
+func (s *Server) SetModules(modules []string) {
+	var enabled map[string]bool
+	if len(modules) > 0 {
+		enabled = make(map[string]bool, len(modules))
+		for _, name := range modules {
+			enabled[name] = true
+		}
+	}
+	s.services.mu.Lock()
+	defer s.services.mu.Unlock()
+	s.services.modules = enabled
+}
+
+// CheckCall checks whether the server admits a call of method by peer, without
+// executing it. The auth scope of the peer must permit the method, the method must
+// be served under the current module set and API filter, and the peer must have
+// quota left, which the admitted call is charged to. Gateways serving the API
+// through other protocols use this to apply the same restrictions as the server.
+func (s *Server) CheckCall(peer PeerInfo, method string) error {
+	if !peer.permits(method) {
+		return &notPermittedError{method: method}
+	}
+	if s.services.callback(method) == nil {
+		return &methodNotFoundError{method: method}
+	}
+	return s.chargeQuota(peer, method)
+}
+
+// CheckSubscription is like CheckCall for the subscription of the given name in
+// namespace, i.e. the subscription created by calling namespace_subscribe with name
+// as the first parameter.
+func (s *Server) CheckSubscription(peer PeerInfo, namespace, name string) error {
+	method := namespace + subscribeMethodSuffix
+	if !peer.permits(method) {
+		return &notPermittedError{method: method}
+	}
+	if s.services.subscription(namespace, name) == nil {
+		return &subscriptionNotFoundError{namespace, name}
+	}
+	return s.chargeQuota(peer, method)
+}
+
+func (s *Server) chargeQuota(peer PeerInfo, method string) error {
+	if s.quota == nil {
+		return nil
+	}
+	return s.quota.Allow(peer, method)
+}
+
 // SetHTTPBodyLimit sets the size limit for HTTP requests.
 //
 // This method should be called before processing any requests via ServeHTTP.
@@ -93,6 +176,41 @@ func (s *Server) SetHTTPBodyLimit(limit int) {
 	s.httpBodyLimit = limit
 }
 
+// SetQuotaLimiter sets the limiter consulted before executing any method call.
+// Passing nil removes the limit.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetQuotaLimiter(quota QuotaLimiter) {
+	s.quota = quota
+}
+
+// SetAccessLog configures the access log written for the served method calls.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetAccessLog(config AccessLogConfig) {
+	s.accessLog = newAccessLog(config)
+}
+
+// SetTracerProvider sets the tracer provider creating the spans of the served
+// method calls. Passing nil uses the global tracer provider.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetTracerProvider(tp trace.TracerProvider) {
+	s.tracerProvider = tp
+}
+
+// SetResponseCache sets the cache serving the results of immutable method calls.
+// Passing nil disables caching.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetResponseCache(cache *ResponseCache) {
+	s.responseCache = cache
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either an RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
@@ -118,6 +236,12 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 		idgen:              s.idgen,
 		batchItemLimit:     s.batchItemLimit,
 		batchResponseLimit: s.batchResponseLimit,
+		batchConcurrency:   s.batchConcurrency,
+		quota:              s.quota,
+		accessLog:          s.accessLog,
+		tracerProvider:     s.tracerProvider,
+		responseCache:      s.responseCache,
+		middleware:         s.middleware,
 	}
 	c := initClient(codec, &s.services, cfg)
 	<-codec.closed()
@@ -153,6 +277,11 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 
 	h := newHandler(ctx, codec, s.idgen, &s.services, s.batchItemLimit, s.batchResponseLimit)
 	h.allowSubscribe = false
+	h.quota = s.quota
+	h.accessLog = s.accessLog
+	h.responseCache = s.responseCache
+	h.batchConcurrency = s.batchConcurrency
+	h.middleware = s.middleware
 	defer h.close(io.EOF, nil)
 
 	reqs, batch, err := codec.readBatch()
@@ -199,6 +328,15 @@ func (s *Server) Stop() {
 	}
 }
 
+// Use appends middleware intercepting the method calls of the server. The middleware
+// added first sees calls first and their responses last.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) Use(middleware ...Middleware) {
+	s.middleware = append(s.middleware, middleware...)
+}
+
 // RPCService gives meta information about the server.
 // e.g. gives information about the loaded modules.
 type RPCService struct {
@@ -212,7 +350,9 @@ func (s *RPCService) Modules() map[string]string {
 
 	modules := make(map[string]string)
 	for name := range s.server.services.services {
-		modules[name] = "1.0"
+		if name == MetadataApi || s.server.services.modules == nil || s.server.services.modules[name] {
+			modules[name] = "1.0"
+		}
 	}
 	return modules
 }
@@ -230,6 +370,14 @@ type PeerInfo struct {
 	// Address of client. This will usually contain the IP address and port.
 	RemoteAddr string
 
+	// Subject of the JWT the client authenticated with, if any.
+	AuthSubject string
+
+	// Namespaces and methods the JWT permits the client to call. Entries
+	// containing the namespace separator are method names, all others are
+	// namespaces. A nil scope permits all calls.
+	AuthScope []string
+
 	// Additional information for HTTP and WebSocket connections.
 	HTTP struct {
 		// Protocol version, i.e. "HTTP/1.1". This is not set for WebSocket.
@@ -243,6 +391,67 @@ type PeerInfo struct {
 
 type peerInfoContextKey struct{}
 
+type authSubjectContextKey struct{}
+
+// ContextWithAuthSubject returns a copy of ctx carrying the subject of the JWT
+// the client authenticated with. HTTP handlers wrapping the server use this to
+// identify the client in PeerInfo.
+func ContextWithAuthSubject(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, authSubjectContextKey{}, subject)
+}
+
+func authSubjectFromContext(ctx context.Context) string {
+	subject, _ := ctx.Value(authSubjectContextKey{}).(string)
+	return subject
+}
+
+type authScopeContextKey struct{}
+
+// ContextWithAuthScope returns a copy of ctx carrying the namespaces and methods
+// the JWT of the client permits it to call. The server rejects calls of all
+// other methods. See PeerInfo.AuthScope for the format of the scope.
+func ContextWithAuthScope(ctx context.Context, scope []string) context.Context {
+	return context.WithValue(ctx, authScopeContextKey{}, scope)
+}
+
+func authScopeFromContext(ctx context.Context) []string {
+	scope, _ := ctx.Value(authScopeContextKey{}).([]string)
+	return scope
+}
+
+// GatewayPeerInfo returns the PeerInfo of a client calling through a gateway served
+// next to the server, e.g. on the same HTTP server. The JWT subject and scope of the
+// client are taken from ctx.
+func GatewayPeerInfo(ctx context.Context, transport, remoteAddr string) PeerInfo {
+	return PeerInfo{
+		Transport:   transport,
+		RemoteAddr:  remoteAddr,
+		AuthSubject: authSubjectFromContext(ctx),
+		AuthScope:   authScopeFromContext(ctx),
+	}
+}
+
+// AuthScopePermits reports whether the auth scope carried by ctx permits calling
+// method. Handlers served next to the server, such as gateways, can use this to
+// enforce the scope set by ContextWithAuthScope.
+func AuthScopePermits(ctx context.Context, method string) bool {
+	return PeerInfo{AuthScope: authScopeFromContext(ctx)}.permits(method)
+}
+
+// permits reports whether the auth scope of the peer permits calling method.
+func (p PeerInfo) permits(method string) bool {
+	if p.AuthScope == nil {
+		return true
+	}
+	namespace, _, _ := strings.Cut(method, serviceMethodSeparator)
+	for _, entry := range p.AuthScope {
+		if entry == method || entry == namespace {
+			return true
+		}
+	}
+	return false
+}
+
 // PeerInfoFromContext returns information about the client's network connection.
 // Use this with the context passed to RPC method handler functions.
 //
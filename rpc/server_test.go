@@ -19,6 +19,7 @@ package rpc
 import (
 	"bufio"
 	"bytes"
+	"fmt"
 	"io"
 	"net"
 	"os"
@@ -202,3 +203,114 @@ func TestServerBatchResponseSizeLimit(t *testing.T) {
 		}
 	}
 }
+
+func TestServerBatchConcurrency(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	server.SetBatchConcurrency(10)
+	client := DialInProc(server)
+	defer client.Close()
+
+	// The sleeping calls run in parallel.
+	var (
+		batch []BatchElem
+		delay = 200 * time.Millisecond
+	)
+	for i := 0; i < 10; i++ {
+		batch = append(batch, BatchElem{Method: "test_sleep", Args: []any{delay}})
+	}
+	start := time.Now()
+	if err := client.BatchCall(batch); err != nil {
+		t.Fatal("error sending batch:", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*delay {
+		t.Fatalf("batch not processed concurrently, took %v", elapsed)
+	}
+
+	// Responses are returned in the order of the calls.
+	batch = batch[:0]
+	for i := 0; i < 50; i++ {
+		batch = append(batch, BatchElem{
+			Method: "test_echo",
+			Args:   []any{fmt.Sprint(i), i},
+			Result: new(echoResult),
+		})
+	}
+	if err := client.BatchCall(batch); err != nil {
+		t.Fatal("error sending batch:", err)
+	}
+	for i, elem := range batch {
+		if elem.Error != nil {
+			t.Fatalf("batch elem %d has unexpected error: %v", i, elem.Error)
+		}
+		if res := elem.Result.(*echoResult); res.String != fmt.Sprint(i) || res.Int != i {
+			t.Fatalf("batch elem %d has wrong result: %+v", i, res)
+		}
+	}
+}
+
+func TestServerBatchConcurrencyResponseSizeLimit(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	server.SetBatchLimits(100, 60)
+	server.SetBatchConcurrency(4)
+	var (
+		batch  []BatchElem
+		client = DialInProc(server)
+	)
+	for i := 0; i < 20; i++ {
+		batch = append(batch, BatchElem{
+			Method: "test_echo",
+			Args:   []any{"x", 1},
+			Result: new(echoResult),
+		})
+	}
+	if err := client.BatchCall(batch); err != nil {
+		t.Fatal("error sending batch:", err)
+	}
+	// Which calls complete before the limit is hit depends on scheduling. Two
+	// responses exceed the limit, and the other workers may finish one more call
+	// each before the batch is aborted.
+	var ok int
+	for i := range batch {
+		if batch[i].Error == nil {
+			ok++
+			continue
+		}
+		re, isRPCErr := batch[i].Error.(Error)
+		if !isRPCErr || re.ErrorCode() != errcodeResponseTooLarge {
+			t.Fatalf("batch elem %d has wrong error: %v", i, batch[i].Error)
+		}
+	}
+	if ok == 0 || ok > 2+3 {
+		t.Fatalf("wrong number of successful calls: %d", ok)
+	}
+}
+
+func BenchmarkBatch(b *testing.B) {
+	for _, workers := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			server := newTestServer()
+			defer server.Stop()
+			server.SetBatchConcurrency(workers)
+			client := DialInProc(server)
+			defer client.Close()
+
+			// Each call blocks briefly, like a call reading from the database.
+			batch := make([]BatchElem, 100)
+			for i := range batch {
+				batch[i] = BatchElem{Method: "test_sleep", Args: []any{50 * time.Microsecond}}
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := client.BatchCall(batch); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -39,7 +39,8 @@ type serviceRegistry struct {
 	mu       sync.Mutex
 	services map[string]service
 
-	apiFilter map[string]bool
+	apiFilter map[string]bool // allowed methods, nil allows all
+	modules   map[string]bool // enabled services, nil enables all
 }
 
 // service represents a registered object.
@@ -84,12 +85,6 @@ func (r *serviceRegistry) registerName(name string, rcvr interface{}) error {
 		r.services[name] = svc
 	}
 	for methodName, cb := range callbacks {
-		if r.apiFilter != nil {
-			key := name + "_" + methodName
-			if _, ok := r.apiFilter[key]; !ok {
-				continue
-			}
-		}
 		if cb.isSubscribe {
 			svc.subscriptions[methodName] = cb
 		} else {
@@ -107,6 +102,9 @@ func (r *serviceRegistry) callback(method string) *callback {
 	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if !r.enabled(before, after) {
+		return nil
+	}
 	return r.services[before].callbacks[after]
 }
 
@@ -114,9 +112,28 @@ func (r *serviceRegistry) callback(method string) *callback {
 func (r *serviceRegistry) subscription(service, name string) *callback {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if !r.enabled(service, name) {
+		return nil
+	}
 	return r.services[service].subscriptions[name]
 }
 
+// enabled reports whether the method of the given service is exposed by the module
+// set and API filter. The metadata service is always available. The caller must
+// hold r.mu.
+func (r *serviceRegistry) enabled(service, method string) bool {
+	if service == MetadataApi {
+		return true
+	}
+	if r.modules != nil && !r.modules[service] {
+		return false
+	}
+	if r.apiFilter != nil && !r.apiFilter[service+serviceMethodSeparator+method] {
+		return false
+	}
+	return true
+}
+
 // suitableCallbacks iterates over the methods of the given type. It determines if a method
 // satisfies the criteria for an RPC callback or a subscription callback and adds it to the
 // collection of callbacks. See server documentation for a summary of these criteria.
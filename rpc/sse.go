@@ -0,0 +1,597 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	sseContentType = "text/event-stream"
+
+	// sseBufferSize is the number of recent events kept by a stream, so they can
+	// be sent again when the client resumes the stream.
+	sseBufferSize = 1024
+
+	// sseResumeTimeout is the time a stream is kept alive after its HTTP
+	// request has ended, waiting for the client to resume it.
+	sseResumeTimeout = 30 * time.Second
+
+	// ssePingInterval is the interval in which comments are sent on idle
+	// streams, to prevent proxies from closing the connection.
+	ssePingInterval = 15 * time.Second
+
+	// sseMaxStreams is the maximum number of event streams a server keeps open,
+	// and sseMaxClientStreams the maximum number per client. Clients are told
+	// apart like by the quota, by JWT subject or IP address.
+	sseMaxStreams       = 1000
+	sseMaxClientStreams = 16
+
+	sseReconnectAttempts = 3
+	sseReconnectDelay    = 500 * time.Millisecond
+	sseCloseTimeout      = 5 * time.Second
+)
+
+var (
+	errSSEStreamGone   = errors.New("event stream is unknown or expired")
+	errSSEWriteUnsupp  = errors.New("only one request can be sent over an event stream")
+	errSSEInvalidEvent = errors.New("invalid event ID")
+	errSSETooMany      = errors.New("too many open event streams")
+)
+
+// isSSERequest reports whether the client asks for the response to be sent as
+// a stream of server-sent events.
+func isSSERequest(r *http.Request) bool {
+	return r.Method == http.MethodPost && strings.Contains(r.Header.Get("accept"), sseContentType)
+}
+
+// isSSECloseRequest reports whether the client asks for an event stream to be
+// closed. The stream is identified by the ID of any of its events.
+func isSSECloseRequest(r *http.Request) bool {
+	return r.Method == http.MethodDelete && r.Header.Get("last-event-id") != ""
+}
+
+// newSSEStreamID creates the random identifier of an event stream. As knowing
+// it is sufficient to resume a stream, it is not derived from the subscription
+// ID generator.
+func newSSEStreamID() string {
+	id := make([]byte, 16)
+	crand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// sseEvent is a message sent on an event stream.
+type sseEvent struct {
+	seq  uint64
+	data []byte
+}
+
+// sseStream is the server side of an event stream. The request which opens the
+// stream is processed like a request received on a WebSocket connection, so it
+// can create subscriptions. All messages written by the server, including the
+// response to the request, are sent as events.
+//
+// The stream outlives the HTTP request which opened it for sseResumeTimeout. A
+// client can resume a stream by sending the ID of the last event it received
+// in the Last-Event-ID header. All buffered events after it are sent again.
+type sseStream struct {
+	id      string
+	info    PeerInfo
+	initial []*jsonrpcMessage
+	batch   bool
+	readCh  chan struct{} // closed once the initial request has been read
+
+	mu     sync.Mutex
+	seq    uint64
+	events []sseEvent
+	out    http.ResponseWriter // nil while no request is attached
+	expiry *time.Timer
+
+	closeOnce sync.Once
+	closeCh   chan interface{}
+}
+
+func newSSEStream(id string, info PeerInfo, msgs []*jsonrpcMessage, batch bool) *sseStream {
+	s := &sseStream{
+		id:      id,
+		info:    info,
+		initial: msgs,
+		batch:   batch,
+		readCh:  make(chan struct{}),
+		closeCh: make(chan interface{}),
+	}
+	s.expiry = time.AfterFunc(sseResumeTimeout, s.close)
+	s.expiry.Stop()
+	return s
+}
+
+func (s *sseStream) peerInfo() PeerInfo { return s.info }
+
+func (s *sseStream) remoteAddr() string { return s.info.RemoteAddr }
+
+// readBatch returns the request which opened the stream. All later calls block
+// until the stream is closed.
+func (s *sseStream) readBatch() ([]*jsonrpcMessage, bool, error) {
+	select {
+	case <-s.readCh:
+	default:
+		close(s.readCh)
+		return s.initial, s.batch, nil
+	}
+	<-s.closeCh
+	return nil, false, io.EOF
+}
+
+// writeJSON buffers the message as a new event and sends it to the client if a
+// request is attached.
+func (s *sseStream) writeJSON(ctx context.Context, v interface{}, isError bool) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	ev := sseEvent{seq: s.seq, data: data}
+	if len(s.events) == sseBufferSize {
+		s.events = append(s.events[:0], s.events[1:]...)
+	}
+	s.events = append(s.events, ev)
+	if s.out != nil {
+		if err := s.writeEvent(ev); err != nil {
+			s.detachLocked()
+		}
+	}
+	return nil
+}
+
+func (s *sseStream) writeEvent(ev sseEvent) error {
+	if _, err := fmt.Fprintf(s.out, "id: %s-%d\ndata: %s\n\n", s.id, ev.seq, ev.data); err != nil {
+		return err
+	}
+	return http.NewResponseController(s.out).Flush()
+}
+
+// attach starts sending the events after the given sequence number to w.
+func (s *sseStream) attach(w http.ResponseWriter, after uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.closeCh:
+		return errSSEStreamGone
+	default:
+	}
+	// Refuse to resume if some of the missed events are no longer buffered.
+	if after > s.seq || (len(s.events) > 0 && s.events[0].seq > after+1) {
+		return errSSEStreamGone
+	}
+	s.out = nil // replaced by the resuming request
+	s.expiry.Stop()
+
+	// Streams are long-lived, lift the write timeout of the HTTP server.
+	rc := http.NewResponseController(w)
+	rc.SetWriteDeadline(time.Time{})
+	w.Header().Set("content-type", sseContentType)
+	w.Header().Set("cache-control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	rc.Flush()
+
+	s.out = w
+	for _, ev := range s.events {
+		if ev.seq <= after {
+			continue
+		}
+		if err := s.writeEvent(ev); err != nil {
+			s.detachLocked()
+			break
+		}
+	}
+	return nil
+}
+
+// ping sends a comment on the stream if it is attached to w.
+func (s *sseStream) ping(w http.ResponseWriter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.out != w {
+		return
+	}
+	if _, err := io.WriteString(w, ": ping\n\n"); err != nil {
+		s.detachLocked()
+		return
+	}
+	if err := http.NewResponseController(w).Flush(); err != nil {
+		s.detachLocked()
+	}
+}
+
+// detach stops sending events to w. If no other request is attached, the
+// stream is closed unless it is resumed within sseResumeTimeout.
+func (s *sseStream) detach(w http.ResponseWriter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.out == w {
+		s.detachLocked()
+	}
+}
+
+func (s *sseStream) detachLocked() {
+	s.out = nil
+	s.expiry.Reset(sseResumeTimeout)
+}
+
+func (s *sseStream) close() {
+	s.closeOnce.Do(func() { close(s.closeCh) })
+}
+
+func (s *sseStream) closed() <-chan interface{} {
+	return s.closeCh
+}
+
+// parseSSEEventID splits an event ID into the stream ID and sequence number.
+func parseSSEEventID(id string) (string, uint64, error) {
+	i := strings.LastIndexByte(id, '-')
+	if i < 0 {
+		return "", 0, errSSEInvalidEvent
+	}
+	seq, err := strconv.ParseUint(id[i+1:], 10, 64)
+	if err != nil {
+		return "", 0, errSSEInvalidEvent
+	}
+	return id[:i], seq, nil
+}
+
+// serveSSE serves a request asking for an event stream. It either opens a new
+// stream for the request body or resumes the stream given in the Last-Event-ID
+// header.
+func (s *Server) serveSSE(w http.ResponseWriter, r *http.Request, info PeerInfo) {
+	if !s.run.Load() {
+		http.Error(w, "server is stopped", http.StatusServiceUnavailable)
+		return
+	}
+	var (
+		stream *sseStream
+		after  uint64
+	)
+	if r.Header.Get("last-event-id") != "" {
+		var err error
+		if stream, after, err = s.lookupSSEStream(r, info); err != nil {
+			http.Error(w, err.Error(), http.StatusGone)
+			return
+		}
+	} else {
+		body, err := io.ReadAll(io.LimitReader(r.Body, int64(s.httpBodyLimit)))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		msgs, batch := parseMessage(body)
+		info.Transport = "sse"
+		stream = newSSEStream(newSSEStreamID(), info, msgs, batch)
+		if !s.addSSEStream(stream) {
+			http.Error(w, errSSETooMany.Error(), http.StatusTooManyRequests)
+			return
+		}
+		go func() {
+			s.ServeCodec(stream, 0)
+			s.removeSSEStream(stream)
+		}()
+	}
+	if err := stream.attach(w, after); err != nil {
+		http.Error(w, err.Error(), http.StatusGone)
+		return
+	}
+	defer stream.detach(w)
+
+	ping := time.NewTicker(ssePingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-ping.C:
+			stream.ping(w)
+		case <-r.Context().Done():
+			return
+		case <-stream.closed():
+			return
+		}
+	}
+}
+
+// addSSEStream registers a new stream, unless the server or the client opening
+// it exceeds their limit of open streams.
+func (s *Server) addSSEStream(stream *sseStream) bool {
+	key := quotaClientKey(stream.info)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(s.sseStreams) >= s.sseMaxStreams || s.sseClients[key] >= s.sseMaxClientStreams {
+		return false
+	}
+	s.sseStreams[stream.id] = stream
+	s.sseClients[key]++
+	return true
+}
+
+// removeSSEStream unregisters a stream once it has been closed.
+func (s *Server) removeSSEStream(stream *sseStream) {
+	key := quotaClientKey(stream.info)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.sseStreams, stream.id)
+	if s.sseClients[key]--; s.sseClients[key] <= 0 {
+		delete(s.sseClients, key)
+	}
+}
+
+// closeSSE closes the event stream given in the Last-Event-ID header.
+func (s *Server) closeSSE(w http.ResponseWriter, r *http.Request, info PeerInfo) {
+	stream, _, err := s.lookupSSEStream(r, info)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusGone)
+		return
+	}
+	stream.close()
+	w.WriteHeader(http.StatusOK)
+}
+
+// lookupSSEStream returns the stream of the event given in the Last-Event-ID
+// header. Streams opened by authenticated clients can only be accessed by the
+// same client.
+func (s *Server) lookupSSEStream(r *http.Request, info PeerInfo) (*sseStream, uint64, error) {
+	id, seq, err := parseSSEEventID(r.Header.Get("last-event-id"))
+	if err != nil {
+		return nil, 0, err
+	}
+	s.mutex.Lock()
+	stream := s.sseStreams[id]
+	s.mutex.Unlock()
+	if stream == nil || stream.info.AuthSubject != info.AuthSubject {
+		return nil, 0, errSSEStreamGone
+	}
+	return stream, seq, nil
+}
+
+// sseClientConn is the client side of an event stream. It sends the first
+// message written to it as the request opening the stream, and transparently
+// resumes the stream if the connection breaks.
+type sseClientConn struct {
+	hc      *httpConn
+	ctx     context.Context // canceled when the connection is closed
+	cancel  context.CancelFunc
+	ready   chan struct{} // closed when the stream has been opened
+	closeCh chan interface{}
+	once    sync.Once
+
+	// These fields are set by the first write, and only accessed by the read
+	// loop once ready is closed.
+	body    []byte
+	headers http.Header
+	resp    io.ReadCloser
+	reader  *bufio.Reader
+
+	mu     sync.Mutex // protects wrote and lastID
+	wrote  bool
+	lastID string
+}
+
+func newSSEClientConn(hc *httpConn) *sseClientConn {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &sseClientConn{
+		hc:      hc,
+		ctx:     ctx,
+		cancel:  cancel,
+		ready:   make(chan struct{}),
+		closeCh: make(chan interface{}),
+	}
+}
+
+func (c *sseClientConn) peerInfo() PeerInfo {
+	return PeerInfo{Transport: "sse", RemoteAddr: c.hc.url}
+}
+
+func (c *sseClientConn) remoteAddr() string { return c.hc.url }
+
+// close closes the stream, asking the server to end it.
+func (c *sseClientConn) close() {
+	c.once.Do(func() {
+		c.cancel()
+		close(c.closeCh)
+
+		c.mu.Lock()
+		lastID := c.lastID
+		c.mu.Unlock()
+		if lastID != "" {
+			c.requestClose(lastID)
+		}
+	})
+}
+
+// requestClose asks the server to close the stream. Otherwise the server
+// would keep it for resumption until it expires.
+func (c *sseClientConn) requestClose(lastID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), sseCloseTimeout)
+	defer cancel()
+
+	req, err := c.hc.newRequest(ctx, nil)
+	if err != nil {
+		return
+	}
+	req.Method = http.MethodDelete
+	req.Header.Set("last-event-id", lastID)
+	resp, err := c.hc.do(req)
+	if err != nil {
+		log.Debug("Failed to close RPC event stream", "url", c.hc.url, "err", err)
+		return
+	}
+	resp.Body.Close()
+}
+
+func (c *sseClientConn) closed() <-chan interface{} {
+	return c.closeCh
+}
+
+// writeJSON opens the stream with msg as the request.
+func (c *sseClientConn) writeJSON(ctx context.Context, msg interface{}, isError bool) error {
+	c.mu.Lock()
+	wrote := c.wrote
+	c.wrote = true
+	c.mu.Unlock()
+	if wrote {
+		return errSSEWriteUnsupp
+	}
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	c.body = body
+	c.headers = headersFromContext(ctx)
+	if err := c.open(ctx); err != nil {
+		return err
+	}
+	close(c.ready)
+	return nil
+}
+
+// open sends the stream request. The given context only limits the time for
+// receiving the response headers, the stream itself lives until the connection
+// is closed.
+func (c *sseClientConn) open(ctx context.Context) error {
+	req, err := c.hc.newRequest(c.ctx, c.body)
+	if err != nil {
+		return err
+	}
+	setHeaders(req.Header, c.headers)
+	req.Header.Set("accept", sseContentType)
+	if lastID := c.lastEventID(); lastID != "" {
+		req.Header.Set("last-event-id", lastID)
+	}
+	stop := context.AfterFunc(ctx, c.close)
+	defer stop()
+
+	resp, err := c.hc.do(req)
+	if err != nil {
+		return err
+	}
+	if mt, _, _ := mime.ParseMediaType(resp.Header.Get("content-type")); mt != sseContentType {
+		resp.Body.Close()
+		return ErrNotificationsUnsupported
+	}
+	c.resp, c.reader = resp.Body, bufio.NewReader(resp.Body)
+	return nil
+}
+
+func (c *sseClientConn) readBatch() ([]*jsonrpcMessage, bool, error) {
+	select {
+	case <-c.ready:
+	case <-c.closeCh:
+		return nil, false, io.EOF
+	}
+	for attempt := 0; ; attempt++ {
+		id, data, err := readSSEEvent(c.reader)
+		if err == nil {
+			if id != "" {
+				c.mu.Lock()
+				c.lastID = id
+				c.mu.Unlock()
+			}
+			msgs, batch := parseMessage(data)
+			return msgs, batch, nil
+		}
+		c.resp.Close()
+		if c.ctx.Err() != nil {
+			return nil, false, io.EOF
+		}
+		lastID := c.lastEventID()
+		if attempt == sseReconnectAttempts || lastID == "" {
+			return nil, false, err
+		}
+		log.Debug("Resuming RPC event stream", "url", c.hc.url, "id", lastID, "err", err)
+		select {
+		case <-time.After(sseReconnectDelay):
+		case <-c.closeCh:
+			return nil, false, io.EOF
+		}
+		if err := c.open(context.Background()); err != nil {
+			return nil, false, err
+		}
+	}
+}
+
+func (c *sseClientConn) lastEventID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastID
+}
+
+// readSSEEvent reads the next event carrying data from r.
+func readSSEEvent(r *bufio.Reader) (id string, data []byte, err error) {
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return "", nil, err
+		}
+		line = bytes.TrimRight(line, "\r\n")
+		switch {
+		case len(line) == 0:
+			if data != nil {
+				return id, data, nil
+			}
+		case bytes.HasPrefix(line, []byte("id:")):
+			id = string(bytes.TrimSpace(line[3:]))
+		case bytes.HasPrefix(line, []byte("data:")):
+			data = append(data, bytes.TrimPrefix(line[5:], []byte(" "))...)
+		}
+	}
+}
+
+// subscribeSSE creates a subscription over a dedicated event stream. The stream
+// is closed when the subscription ends, which also ends it on the server.
+func (c *Client) subscribeSSE(ctx context.Context, namespace string, channel interface{}, args ...interface{}) (*ClientSubscription, error) {
+	conn := newSSEClientConn(c.writeConn.(*httpConn))
+	sc := initClient(conn, new(serviceRegistry), &clientConfig{idgen: c.idgen})
+	sc.isSSE = true
+	sub, err := sc.Subscribe(ctx, namespace, channel, args...)
+	if err != nil {
+		sc.Close()
+		return nil, err
+	}
+	return sub, nil
+}
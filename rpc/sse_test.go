@@ -0,0 +1,255 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// feedService sends the values written to its channel to all subscribers.
+type feedService struct {
+	values       chan int
+	unsubscribed chan struct{}
+}
+
+func (s *feedService) Values(ctx context.Context) (*Subscription, error) {
+	notifier, supported := NotifierFromContext(ctx)
+	if !supported {
+		return nil, ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	go func() {
+		for {
+			select {
+			case v := <-s.values:
+				notifier.Notify(sub.ID, v)
+			case <-sub.Err():
+				close(s.unsubscribed)
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+func TestHTTPSubscription(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	// Without the option, subscriptions are unsupported over HTTP.
+	plain, err := DialHTTP(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer plain.Close()
+	if _, err := plain.Subscribe(context.Background(), "nftest", make(chan int), "someSubscription", 1, 0); !errors.Is(err, ErrNotificationsUnsupported) {
+		t.Fatalf("wrong error for plain HTTP client: %v", err)
+	}
+
+	client, err := DialOptions(context.Background(), ts.URL, WithHTTPSubscriptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if !client.SupportsSubscriptions() {
+		t.Fatal("client doesn't support subscriptions")
+	}
+
+	var (
+		nc    = make(chan int)
+		count = 10
+	)
+	sub, err := client.Subscribe(context.Background(), "nftest", nc, "someSubscription", count, 0)
+	if err != nil {
+		t.Fatal("can't subscribe:", err)
+	}
+	for i := 0; i < count; i++ {
+		if val := <-nc; val != i {
+			t.Fatalf("value mismatch: got %d, want %d", val, i)
+		}
+	}
+	sub.Unsubscribe()
+	select {
+	case err := <-sub.Err():
+		if err != nil {
+			t.Fatalf("Err returned a non-nil error after explicit unsubscribe: %q", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("subscription not closed within 1s after unsubscribe")
+	}
+
+	// Regular calls still work on the same client.
+	var result echoResult
+	if err := client.Call(&result, "test_echo", "x", 1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestHTTPSubscriptionResume checks that a broken event stream is resumed
+// without losing notifications.
+func TestHTTPSubscriptionResume(t *testing.T) {
+	t.Parallel()
+
+	var (
+		server  = NewServer()
+		service = &feedService{values: make(chan int), unsubscribed: make(chan struct{})}
+	)
+	defer server.Stop()
+	if err := server.RegisterName("feed", service); err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	client, err := DialOptions(context.Background(), ts.URL, WithHTTPSubscriptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	nc := make(chan int)
+	sub, err := client.Subscribe(context.Background(), "feed", nc, "values")
+	if err != nil {
+		t.Fatal("can't subscribe:", err)
+	}
+	receive := func(want int) {
+		t.Helper()
+		select {
+		case v := <-nc:
+			if v != want {
+				t.Fatalf("value mismatch: got %d, want %d", v, want)
+			}
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for %d", want)
+		}
+	}
+	service.values <- 1
+	receive(1)
+
+	// Break the connection. Notifications sent in the meantime are buffered by
+	// the server and delivered once the client has resumed the stream.
+	ts.CloseClientConnections()
+	service.values <- 2
+	service.values <- 3
+	receive(2)
+	receive(3)
+
+	// Unsubscribing closes the stream, which ends the subscription on the server.
+	sub.Unsubscribe()
+	select {
+	case <-service.unsubscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription not ended on the server")
+	}
+}
+
+func TestSSEEventID(t *testing.T) {
+	id, seq, err := parseSSEEventID("0x1a2b-42")
+	if err != nil || id != "0x1a2b" || seq != 42 {
+		t.Fatalf("wrong result: %q %d %v", id, seq, err)
+	}
+	for _, invalid := range []string{"", "0x1a2b", "0x1a2b-", "0x1a2b-x"} {
+		if _, _, err := parseSSEEventID(invalid); err == nil {
+			t.Errorf("no error for %q", invalid)
+		}
+	}
+}
+
+// TestSSEStreamLimits checks that the number of open event streams is limited
+// per client and per server, and that streams can only be closed by requests
+// passing the usual request checks.
+func TestSSEStreamLimits(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	server.sseMaxStreams, server.sseMaxClientStreams = 3, 2
+
+	open := func(remoteAddr string) *httptest.ResponseRecorder {
+		body := strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"rpc_modules"}`)
+		req := httptest.NewRequest(http.MethodPost, "/", body)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("content-type", contentType)
+		req.Header.Set("accept", sseContentType)
+		ctx, cancel := context.WithCancel(req.Context())
+		cancel() // only open the stream, don't wait for events
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req.WithContext(ctx))
+		return rec
+	}
+	for i := 0; i < 2; i++ {
+		if rec := open("1.2.3.4:1000"); rec.Code != http.StatusOK {
+			t.Fatalf("stream %d: status %d", i, rec.Code)
+		}
+	}
+	if rec := open("1.2.3.4:2000"); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("stream beyond the client limit: status %d", rec.Code)
+	}
+	if rec := open("5.6.7.8:1000"); rec.Code != http.StatusOK {
+		t.Fatalf("stream of other client: status %d", rec.Code)
+	}
+	if rec := open("9.9.9.9:1000"); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("stream beyond the server limit: status %d", rec.Code)
+	}
+
+	// Close one of the streams. The request must carry a valid content type.
+	server.mutex.Lock()
+	var id string
+	for id = range server.sseStreams {
+		break
+	}
+	server.mutex.Unlock()
+	closeReq := func(ct string) int {
+		req := httptest.NewRequest(http.MethodDelete, "/", nil)
+		req.Header.Set("last-event-id", id+"-1")
+		if ct != "" {
+			req.Header.Set("content-type", ct)
+		}
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	if code := closeReq("text/plain"); code != http.StatusUnsupportedMediaType {
+		t.Fatalf("close with invalid content type: status %d", code)
+	}
+	if code := closeReq(contentType); code != http.StatusOK {
+		t.Fatalf("close: status %d", code)
+	}
+	// The closed stream frees a slot once its codec has ended.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if rec := open("9.9.9.9:1000"); rec.Code == http.StatusOK {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("slot of the closed stream not freed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
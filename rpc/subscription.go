@@ -223,6 +223,10 @@ type ClientSubscription struct {
 	quit        chan error
 	forwardDone chan struct{}
 	unsubDone   chan struct{}
+
+	// ownClient is set if the subscription has a dedicated client connection,
+	// which is closed when the subscription ends.
+	ownClient bool
 }
 
 // This is the sentinel value sent on sub.quit when Unsubscribe is called.
@@ -297,8 +301,11 @@ func (sub *ClientSubscription) run() {
 	// blocked in sub.deliver() or sub.close(). Closing forwardDone unblocks them.
 	close(sub.forwardDone)
 
-	// Call the unsubscribe method on the server.
-	if unsubscribe {
+	// Call the unsubscribe method on the server. A dedicated connection is closed
+	// instead, which also ends the subscription on the server.
+	if sub.ownClient {
+		sub.client.Close()
+	} else if unsubscribe {
 		sub.requestUnsubscribe()
 	}
 
@@ -0,0 +1,85 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TestTraceContext checks that the calls on HTTP and WebSocket connections
+// continue the trace of the caller.
+func TestTraceContext(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	global := tracetest.NewInMemoryExporter()
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(global)))
+
+	// The spans are created by the tracer provider of the server, not the global one.
+	server := newTestServer()
+	server.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer server.Stop()
+	wssrv := httptest.NewServer(server.WebsocketHandler([]string{"*"}, 0))
+	defer wssrv.Close()
+	httpsrv := httptest.NewServer(server)
+	defer httpsrv.Close()
+
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+	for _, url := range []string{httpsrv.URL, "ws:" + strings.TrimPrefix(wssrv.URL, "http:")} {
+		exporter.Reset()
+		client, err := DialOptions(context.Background(), url, WithHeader("traceparent", "00-"+traceID+"-"+spanID+"-01"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := client.Call(nil, "test_echo", "x", 1); err != nil {
+			t.Fatal(err)
+		}
+		client.Call(nil, "test_returnError")
+		client.Close()
+
+		spans := exporter.GetSpans()
+		if len(spans) != 2 {
+			t.Fatalf("%s: wrong number of spans: %d", url, len(spans))
+		}
+		for i, want := range []string{"test_echo", "test_returnError"} {
+			span := spans[i]
+			if span.Name != want || span.SpanKind != trace.SpanKindServer {
+				t.Errorf("%s: wrong span %q of kind %v", url, span.Name, span.SpanKind)
+			}
+			if span.Parent.TraceID().String() != traceID || span.Parent.SpanID().String() != spanID {
+				t.Errorf("%s: span %q not continuing the caller's trace: %v", url, span.Name, span.Parent)
+			}
+		}
+		if spans[1].Status.Code != codes.Error {
+			t.Errorf("%s: failed call not marked as error", url)
+		}
+	}
+	if spans := global.GetSpans(); len(spans) != 0 {
+		t.Errorf("%d spans created by the global tracer provider", len(spans))
+	}
+}
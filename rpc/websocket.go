@@ -28,6 +28,7 @@ import (
 	"time"
 
 	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/ethereum/go-ethereum/internal/telemetry"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/gorilla/websocket"
 )
@@ -64,6 +65,9 @@ func (s *Server) WebsocketHandler(allowedOrigins []string, wsReadLimit int64) ht
 			return
 		}
 		codec := newWebsocketCodec(conn, r.Host, r.Header, wsReadLimit)
+		codec.info.AuthSubject = authSubjectFromContext(r.Context())
+		codec.info.AuthScope = authScopeFromContext(r.Context())
+		codec.traceCtx = telemetry.Extract(context.Background(), r.Header)
 		s.ServeCodec(codec, 0)
 	})
 }
@@ -289,12 +293,16 @@ type websocketCodec struct {
 	conn *websocket.Conn
 	info PeerInfo
 
+	// traceCtx carries the trace context of the upgrade request, which is the
+	// parent of the spans created for the calls on the connection.
+	traceCtx context.Context
+
 	wg           sync.WaitGroup
 	pingReset    chan struct{}
 	pongReceived chan struct{}
 }
 
-func newWebsocketCodec(conn *websocket.Conn, host string, req http.Header, readLimit int64) ServerCodec {
+func newWebsocketCodec(conn *websocket.Conn, host string, req http.Header, readLimit int64) *websocketCodec {
 	conn.SetReadLimit(readLimit)
 	encode := func(v interface{}, isErrorResponse bool) error {
 		return conn.WriteJSON(v)